@@ -15,17 +15,25 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/v1/add": {
+        "/v1/calculus/derivative": {
             "post": {
-                "summary": "Add two numbers",
+                "description": "First or second derivative by central differences, or by Ridders' Richardson extrapolation of central differences with shrinking steps. Points where the expression or its neighborhood cannot be evaluated fail with that evaluation error.",
+                "summary": "Derivative of an expression at a point",
                 "parameters": [
                     {
-                        "description": "Operands",
+                        "type": "string",
+                        "default": "default",
+                        "description": "Tenant scope of user-defined functions",
+                        "name": "X-Tenant-ID",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Expression and point",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/rest.BinaryOperand"
+                            "$ref": "#/definitions/rest.DerivativeRequest"
                         }
                     }
                 ],
@@ -33,7 +41,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/rest.Response"
+                            "$ref": "#/definitions/rest.DerivativeResponse"
                         }
                     },
                     "400": {
@@ -45,17 +53,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/divide": {
+        "/v1/calculus/integrate": {
             "post": {
-                "summary": "Divide two numbers",
+                "description": "Adaptive Gauss-Kronrod (7-15 points, bisecting the subinterval of largest error) or adaptive Simpson. Gauss-Kronrod does not evaluate the bounds, so it handles integrable singularities there. Integrands that cannot be evaluated fail with singular_integrand; integrals whose error estimate does not reach the tolerance fail with divergent_integral, and with timeout after 2 seconds.",
+                "summary": "Definite integral of an expression",
                 "parameters": [
                     {
-                        "description": "Operands",
+                        "type": "string",
+                        "default": "default",
+                        "description": "Tenant scope of user-defined functions",
+                        "name": "X-Tenant-ID",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Expression, bounds and limits",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/rest.BinaryOperand"
+                            "$ref": "#/definitions/rest.IntegrateRequest"
                         }
                     }
                 ],
@@ -63,7 +79,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/rest.Response"
+                            "$ref": "#/definitions/rest.IntegrateResponse"
                         }
                     },
                     "400": {
@@ -75,17 +91,24 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/multiply": {
+        "/v1/evaluate": {
             "post": {
-                "summary": "Multiply two numbers",
+                "summary": "Evaluate an expression once",
                 "parameters": [
                     {
-                        "description": "Operands",
+                        "type": "string",
+                        "default": "default",
+                        "description": "Tenant scope of user-defined functions",
+                        "name": "X-Tenant-ID",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Expression and variable values",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/rest.BinaryOperand"
+                            "$ref": "#/definitions/rest.EvaluateRequest"
                         }
                     }
                 ],
@@ -105,17 +128,86 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/percentage": {
+        "/v1/expressions": {
+            "post": {
+                "summary": "Compile an expression for repeated evaluation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "default",
+                        "description": "Tenant scope of user-defined functions",
+                        "name": "X-Tenant-ID",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Expression and declared variables",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.CompileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/rest.CompileResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/expressions/{id}": {
+            "delete": {
+                "summary": "Delete a compiled expression",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Compiled expression ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/expressions/{id}/evaluate": {
             "post": {
-                "summary": "Percentage calculation",
+                "summary": "Evaluate a compiled expression against many binding sets",
                 "parameters": [
                     {
-                        "description": "Operands",
+                        "type": "string",
+                        "description": "Compiled expression ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Variable bindings, one set per evaluation",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/rest.BinaryOperand"
+                            "$ref": "#/definitions/rest.EvaluateBatchRequest"
                         }
                     }
                 ],
@@ -123,7 +215,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/rest.Response"
+                            "$ref": "#/definitions/rest.EvaluateBatchResponse"
                         }
                     },
                     "400": {
@@ -131,21 +223,28 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/rest.ErrorResponse"
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/v1/power": {
+        "/v1/finance/irr": {
             "post": {
-                "summary": "Power operation",
+                "description": "With dates this is XIRR. Fails with no_convergence when no rate zeroes the NPV.",
+                "summary": "Internal rate of return of cash flows",
                 "parameters": [
                     {
-                        "description": "Operands",
+                        "description": "Cash flows",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/rest.BinaryOperand"
+                            "$ref": "#/definitions/rest.IRRRequest"
                         }
                     }
                 ],
@@ -165,17 +264,18 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/sqrt": {
+        "/v1/finance/npv": {
             "post": {
-                "summary": "Square root",
+                "description": "With dates this is XNPV.",
+                "summary": "Net present value of cash flows",
                 "parameters": [
                     {
-                        "description": "Operand",
+                        "description": "Rate and cash flows",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/rest.UnaryOperand"
+                            "$ref": "#/definitions/rest.NPVRequest"
                         }
                     }
                 ],
@@ -195,17 +295,17 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/subtract": {
+        "/v1/format": {
             "post": {
-                "summary": "Subtract two numbers",
+                "summary": "Format a number in another base or notation",
                 "parameters": [
                     {
-                        "description": "Operands",
+                        "description": "Number and formatting options",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/rest.BinaryOperand"
+                            "$ref": "#/definitions/rest.FormatRequest"
                         }
                     }
                 ],
@@ -224,53 +324,3820 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "rest.BinaryOperand": {
-            "type": "object",
-            "required": [
-                "a",
-                "b"
-            ],
-            "properties": {
-                "a": {
-                    "type": "number",
-                    "example": 12.3
-                },
-                "b": {
-                    "type": "number",
-                    "example": 4.5
+        },
+        "/v1/functions": {
+            "get": {
+                "summary": "List user-defined functions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "default",
+                        "description": "Tenant scope",
+                        "name": "X-Tenant-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.FunctionList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
                 }
             }
         },
-        "rest.ErrorResponse": {
-            "type": "object",
-            "properties": {
-                "error": {
-                    "type": "string",
-                    "example": "invalid input"
+        "/v1/functions/{name}": {
+            "get": {
+                "summary": "Get a user-defined function",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "default",
+                        "description": "Tenant scope",
+                        "name": "X-Tenant-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Function name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.FunctionDefinition"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "summary": "Define or update a user-defined function",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "default",
+                        "description": "Tenant scope",
+                        "name": "X-Tenant-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Function name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Parameters and body expression",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.FunctionInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.FunctionDefinition"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/rest.FunctionDefinition"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "summary": "Delete a user-defined function",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "default",
+                        "description": "Tenant scope",
+                        "name": "X-Tenant-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Function name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
                 }
             }
         },
-        "rest.Response": {
-            "type": "object",
-            "properties": {
-                "result": {
-                    "type": "number",
-                    "example": 8.9
+        "/v1/matrix/cholesky": {
+            "post": {
+                "description": "Lower triangular l with a = l lᵀ. Fails with not_symmetric or not_positive_definite.",
+                "summary": "Cholesky decomposition of a symmetric positive definite matrix",
+                "parameters": [
+                    {
+                        "description": "Matrix",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.MatrixRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.CholeskyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
                 }
             }
         },
-        "rest.UnaryOperand": {
-            "type": "object",
-            "required": [
-                "a"
-            ],
-            "properties": {
-                "a": {
-                    "type": "number",
-                    "example": 6.7
+        "/v1/matrix/determinant": {
+            "post": {
+                "summary": "Determinant of a square matrix",
+                "parameters": [
+                    {
+                        "description": "Matrix",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.MatrixRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/matrix/eigen": {
+            "post": {
+                "description": "Householder tridiagonalization followed by implicit QL. Values are increasing and the columns of vectors are the matching orthonormal eigenvectors. Fails with no_convergence when max_iterations, 1 to 100000, is reached, with overflow when eigenvalues do not fit in a float64 and with timeout after 2 seconds.",
+                "summary": "Eigenvalues and eigenvectors of a symmetric matrix",
+                "parameters": [
+                    {
+                        "description": "Symmetric matrix and iteration limit",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.EigenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.EigenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/matrix/inverse": {
+            "post": {
+                "description": "Singular matrices fail with singular_matrix.",
+                "summary": "Inverse of a square matrix",
+                "parameters": [
+                    {
+                        "description": "Matrix",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.MatrixRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.MatrixResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/matrix/qr": {
+            "post": {
+                "description": "Thin Householder factorization a = q r of an m×n matrix, with q m×k with orthonormal columns and r k×n upper triangular, where k = min(m, n). Fails with overflow when r does not fit in a float64.",
+                "summary": "QR decomposition of a matrix",
+                "parameters": [
+                    {
+                        "description": "Matrix",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.MatrixRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.QRResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/matrix/rank": {
+            "post": {
+                "summary": "Rank of a matrix",
+                "parameters": [
+                    {
+                        "description": "Matrix",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.MatrixRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.RankResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/matrix/solve": {
+            "post": {
+                "description": "Uses LU decomposition with partial pivoting. Singular systems fail with singular_matrix.",
+                "summary": "Solve the linear system a x = b",
+                "parameters": [
+                    {
+                        "description": "Square matrix and right-hand side",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.SolveRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.VectorResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/matrix/svd": {
+            "post": {
+                "description": "Thin decomposition a = u diag(s) vᵀ by one-sided Jacobi, with s non-negative and decreasing. Fails with no_convergence when max_sweeps, 1 to 100000, is reached and with timeout after 2 seconds.",
+                "summary": "Singular value decomposition of a matrix",
+                "parameters": [
+                    {
+                        "description": "Matrix and sweep limit",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.SVDRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.SVDResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/matrix/transpose": {
+            "post": {
+                "summary": "Transpose a matrix",
+                "parameters": [
+                    {
+                        "description": "Matrix",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.MatrixRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.MatrixResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/matrix/{operation}": {
+            "post": {
+                "summary": "Add, subtract or multiply two matrices",
+                "parameters": [
+                    {
+                        "enum": [
+                            "add",
+                            "subtract",
+                            "multiply"
+                        ],
+                        "type": "string",
+                        "description": "Operation",
+                        "name": "operation",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Matrices",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.MatrixBinaryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.MatrixResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/money/allocate": {
+            "post": {
+                "description": "Minor units left over by rounding go to the parts with the largest remainders.",
+                "summary": "Allocate an amount in proportion to ratios",
+                "parameters": [
+                    {
+                        "description": "Amount and ratios",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.MoneyAllocateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.MoneyPartsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/money/convert": {
+            "post": {
+                "description": "Uses the latest rate effective on the date: the direct pair, the inverse pair or,\nfailing both, a rate triangulated through the base currency.",
+                "summary": "Convert an amount to another currency",
+                "parameters": [
+                    {
+                        "description": "Amount, target currency, date and rounding mode",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.MoneyConvertRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.MoneyConvertResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/money/currencies": {
+            "get": {
+                "summary": "List supported ISO 4217 currencies and their minor units",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/rest.CurrencyInfo"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/money/discount": {
+            "post": {
+                "summary": "Apply one or more percent discounts",
+                "parameters": [
+                    {
+                        "description": "Price, discounts and how they stack",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.DiscountRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.DiscountResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/money/multiply": {
+            "post": {
+                "description": "The product is rounded to the minor units of the currency.",
+                "summary": "Multiply an amount by a factor",
+                "parameters": [
+                    {
+                        "description": "Amount, factor and rounding mode",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.MoneyMultiplyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.MoneyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/money/percent-change": {
+            "post": {
+                "description": "The change is relative to the magnitude of from, so -50 to -25 is +50%.",
+                "summary": "Compute the percent change between two values",
+                "parameters": [
+                    {
+                        "description": "Values",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.PercentChangeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.PercentChangeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/money/rates/reload": {
+            "post": {
+                "description": "On error the previous rates stay in use.",
+                "summary": "Reload exchange rates from their file",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.RatesReloadResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/money/split": {
+            "post": {
+                "description": "Parts differ by at most one minor unit, larger parts first.",
+                "summary": "Split an amount in equal parts",
+                "parameters": [
+                    {
+                        "description": "Amount and number of parts",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.MoneySplitRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.MoneyPartsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/money/tax": {
+            "post": {
+                "description": "Exclusive amounts get rate% added; inclusive amounts are split into net and tax.",
+                "summary": "Add or remove tax",
+                "parameters": [
+                    {
+                        "description": "Amount, tax rate and whether the amount includes it",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.TaxRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.TaxResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/money/tip": {
+            "post": {
+                "summary": "Add a tip and split the bill",
+                "parameters": [
+                    {
+                        "description": "Bill, tip percent and number of people",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.TipRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.TipResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/money/{basis}": {
+            "post": {
+                "description": "markup: price = cost × (1 + percent/100). margin: price = cost / (1 − percent/100), percent below 100.",
+                "summary": "Price a cost with a markup or a margin",
+                "parameters": [
+                    {
+                        "enum": [
+                            "markup",
+                            "margin"
+                        ],
+                        "type": "string",
+                        "description": "Pricing basis",
+                        "name": "basis",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Cost and percent",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.PricingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.PricingResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/money/{op}": {
+            "post": {
+                "description": "Amounts with more decimal places than the currency allows are rejected.",
+                "summary": "Add or subtract amounts of the same currency",
+                "parameters": [
+                    {
+                        "enum": [
+                            "add",
+                            "subtract"
+                        ],
+                        "type": "string",
+                        "description": "Operation",
+                        "name": "op",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Amounts",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.MoneyBinaryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.MoneyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/operations": {
+            "get": {
+                "summary": "List supported operations with their request schemas",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.OperationList"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/polynomial/derivative": {
+            "post": {
+                "summary": "Derivative of a polynomial",
+                "parameters": [
+                    {
+                        "description": "Coefficients, constant term first, and order",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.PolynomialDerivativeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.PolynomialResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/polynomial/divide": {
+            "post": {
+                "description": "Quotient and remainder with a = quotient b + remainder, the remainder of lower degree than b. Dividing by the zero polynomial fails with division_by_zero.",
+                "summary": "Long division of two polynomials",
+                "parameters": [
+                    {
+                        "description": "Dividend a and divisor b, constant term first",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.PolynomialBinaryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.PolynomialDivisionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/polynomial/evaluate": {
+            "post": {
+                "description": "Evaluates by Horner's rule at each of up to 1000 points.",
+                "summary": "Evaluate a polynomial at points",
+                "parameters": [
+                    {
+                        "description": "Coefficients, constant term first, and points",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.PolynomialEvaluateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.VectorResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/polynomial/roots": {
+            "post": {
+                "description": "Real and complex roots by the Durand-Kerner method, repeated by multiplicity and sorted by real and then imaginary part. Non-real roots come in conjugate pairs. Roots of multiplicity m are accurate to about the m-th root of the machine epsilon. The zero polynomial fails with zero_polynomial, reaching max_iterations, 1 to 10000, with no_convergence and taking more than 2 seconds with timeout.",
+                "summary": "All roots of a polynomial",
+                "parameters": [
+                    {
+                        "description": "Coefficients, constant term first, and iteration limit",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.PolynomialRootsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.PolynomialRootsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/polynomial/{operation}": {
+            "post": {
+                "summary": "Add, subtract or multiply two polynomials",
+                "parameters": [
+                    {
+                        "enum": [
+                            "add",
+                            "subtract",
+                            "multiply"
+                        ],
+                        "type": "string",
+                        "description": "Operation",
+                        "name": "operation",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Coefficients, constant term first",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.PolynomialBinaryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.PolynomialResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/programmer": {
+            "get": {
+                "summary": "List programmer mode integer types, overflow modes and operations",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ProgrammerInfo"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/programmer/{op}": {
+            "post": {
+                "description": "Operations: add, subtract, multiply, div, mod, negate, and, or, xor, not, shl, shr, rotl, rotr.\nUnary operations ignore b; for shifts and rotates b is the bit count.",
+                "summary": "Evaluate an integer operation in programmer mode",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Operation name",
+                        "name": "op",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Operands, integer type and overflow mode",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.ProgrammerRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ProgrammerResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/random/distributions": {
+            "get": {
+                "summary": "List the distributions random numbers can be drawn from",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/rest.DistributionInfo"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/random/integers": {
+            "post": {
+                "summary": "Draw random integers in a range",
+                "parameters": [
+                    {
+                        "description": "Inclusive range, count and seed",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.RandomIntegersRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.RandomIntegersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/random/numbers": {
+            "post": {
+                "summary": "Draw random numbers from a distribution",
+                "parameters": [
+                    {
+                        "description": "Distribution, parameters, count and seed",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.RandomNumbersRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.RandomNumbersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/random/sample": {
+            "post": {
+                "summary": "Sample items from a list, with or without replacement",
+                "parameters": [
+                    {
+                        "description": "Items, count, replacement and seed",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.SampleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ItemsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/random/shuffle": {
+            "post": {
+                "summary": "Shuffle a list",
+                "parameters": [
+                    {
+                        "description": "Items and seed",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.ShuffleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ItemsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/solve": {
+            "post": {
+                "description": "Solves expression = 0 for variable by bisection, Newton's method with a central-difference derivative, the secant method or Brent's method. Fails with no_convergence when max_iterations is reached or the method breaks down, and with timeout after 2 seconds.",
+                "summary": "Find x where an expression is zero",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "default",
+                        "description": "Tenant scope of user-defined functions",
+                        "name": "X-Tenant-ID",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Expression, starting points and limits",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.RootRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.RootResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/stats": {
+            "post": {
+                "summary": "Descriptive statistics of a list of numbers",
+                "parameters": [
+                    {
+                        "description": "Values and percentiles",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.StatsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.StatsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/stats/regression": {
+            "post": {
+                "description": "Fails with singular_system when the x values cannot determine the coefficients\nand with ill_conditioned when the fit is too sensitive to rounding.",
+                "summary": "Least-squares linear or polynomial regression",
+                "parameters": [
+                    {
+                        "description": "Points, degree and x values to predict",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.RegressionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.RegressionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/symbolic/derivative": {
+            "post": {
+                "description": "Differentiates by the sum, product, quotient, power and chain rules, with functions of angles in radians, and simplifies the result. Functions without a differentiation rule, such as floor or user-defined ones, fail with no derivative rule, and derivatives of more than 5000 nodes with expression too large.",
+                "summary": "Derivative of an expression as an expression",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "default",
+                        "description": "Tenant scope of user-defined functions",
+                        "name": "X-Tenant-ID",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Expression and variable",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.SymbolicDerivativeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.SymbolicResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/symbolic/simplify": {
+            "post": {
+                "description": "Folds constants, exactly for integers and their fractions, combines like terms and powers of the same base, and eliminates identities such as x+0, x*1, x*0, x^1 and x^0. Like terms cancel regardless of domains, so x/x is 1.",
+                "summary": "Simplify an expression",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "default",
+                        "description": "Tenant scope of user-defined functions",
+                        "name": "X-Tenant-ID",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Expression",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.SimplifyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.SymbolicResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/vector/cross": {
+            "post": {
+                "summary": "Cross product of two 3-dimensional vectors",
+                "parameters": [
+                    {
+                        "description": "Vectors",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.VectorBinaryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.VectorResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/vector/dot": {
+            "post": {
+                "summary": "Dot product of two vectors",
+                "parameters": [
+                    {
+                        "description": "Vectors",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.VectorBinaryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "rest.CholeskyResponse": {
+            "type": "object",
+            "properties": {
+                "l": {
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "type": "number",
+                            "format": "float64"
+                        }
+                    }
+                }
+            }
+        },
+        "rest.CompileRequest": {
+            "type": "object",
+            "required": [
+                "expression"
+            ],
+            "properties": {
+                "angle_unit": {
+                    "type": "string",
+                    "default": "radians",
+                    "enum": [
+                        "radians",
+                        "degrees",
+                        "gradians"
+                    ]
+                },
+                "expression": {
+                    "type": "string",
+                    "example": "a*x^2 + b"
+                },
+                "variables": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "a",
+                        "x",
+                        "b"
+                    ]
+                }
+            }
+        },
+        "rest.CompileResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "5ZQ7WJTKBCXH3FQXKDPNPRY2XA"
+                },
+                "variables": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "a",
+                        "x",
+                        "b"
+                    ]
+                }
+            }
+        },
+        "rest.ComplexNumber": {
+            "type": "object",
+            "properties": {
+                "im": {
+                    "type": "number",
+                    "example": 4
+                },
+                "re": {
+                    "type": "number",
+                    "example": 3
+                }
+            }
+        },
+        "rest.CurrencyInfo": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "minor_units": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "name": {
+                    "type": "string",
+                    "example": "US Dollar"
+                }
+            }
+        },
+        "rest.DerivativeRequest": {
+            "type": "object",
+            "required": [
+                "at",
+                "expression"
+            ],
+            "properties": {
+                "angle_unit": {
+                    "type": "string",
+                    "default": "radians",
+                    "enum": [
+                        "radians",
+                        "degrees",
+                        "gradians"
+                    ]
+                },
+                "at": {
+                    "type": "number",
+                    "example": 1
+                },
+                "expression": {
+                    "type": "string",
+                    "example": "sin(x)"
+                },
+                "method": {
+                    "type": "string",
+                    "default": "richardson",
+                    "enum": [
+                        "richardson",
+                        "central"
+                    ]
+                },
+                "order": {
+                    "description": "Order is 1 for the first derivative or 2 for the second.",
+                    "type": "integer",
+                    "default": 1,
+                    "example": 1
+                },
+                "step": {
+                    "description": "Step is the difference step, chosen from at when omitted.",
+                    "type": "number",
+                    "example": 0.1
+                },
+                "variable": {
+                    "description": "Variable is the differentiation variable, x when omitted.",
+                    "type": "string",
+                    "default": "x",
+                    "example": "x"
+                }
+            }
+        },
+        "rest.DerivativeResponse": {
+            "type": "object",
+            "properties": {
+                "error_estimate": {
+                    "type": "number",
+                    "example": 2.3314683517128287e-15
+                },
+                "evaluations": {
+                    "type": "integer",
+                    "example": 13
+                },
+                "method": {
+                    "type": "string",
+                    "example": "richardson"
+                },
+                "order": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "result": {
+                    "type": "number",
+                    "example": 0.5403023058681412
+                }
+            }
+        },
+        "rest.DiscountRequest": {
+            "type": "object",
+            "required": [
+                "a",
+                "percents"
+            ],
+            "properties": {
+                "a": {
+                    "$ref": "#/definitions/rest.Money"
+                },
+                "percents": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "10",
+                        "20"
+                    ]
+                },
+                "rounding_mode": {
+                    "type": "string",
+                    "default": "half_even",
+                    "enum": [
+                        "half_up",
+                        "half_even",
+                        "down",
+                        "up",
+                        "ceiling",
+                        "floor"
+                    ]
+                },
+                "stacking": {
+                    "description": "Stacking is sequential (10% then 20% is 28% off) or additive (30%\noff).",
+                    "type": "string",
+                    "default": "sequential",
+                    "enum": [
+                        "sequential",
+                        "additive"
+                    ]
+                }
+            }
+        },
+        "rest.DiscountResponse": {
+            "type": "object",
+            "properties": {
+                "final": {
+                    "$ref": "#/definitions/rest.Money"
+                },
+                "percent": {
+                    "description": "Percent is the effective discount on price.",
+                    "type": "string",
+                    "example": "28"
+                },
+                "price": {
+                    "$ref": "#/definitions/rest.Money"
+                },
+                "rounding_mode": {
+                    "type": "string",
+                    "example": "half_even"
+                },
+                "stacking": {
+                    "type": "string",
+                    "example": "sequential"
+                },
+                "steps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.DiscountStepInfo"
+                    }
+                },
+                "total": {
+                    "$ref": "#/definitions/rest.Money"
+                }
+            }
+        },
+        "rest.DiscountStepInfo": {
+            "type": "object",
+            "properties": {
+                "after": {
+                    "$ref": "#/definitions/rest.Money"
+                },
+                "amount": {
+                    "$ref": "#/definitions/rest.Money"
+                },
+                "before": {
+                    "$ref": "#/definitions/rest.Money"
+                },
+                "percent": {
+                    "type": "string",
+                    "example": "10"
+                }
+            }
+        },
+        "rest.DistributionInfo": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "normal"
+                },
+                "params": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "mu",
+                        "sigma"
+                    ]
+                }
+            }
+        },
+        "rest.EigenRequest": {
+            "type": "object",
+            "required": [
+                "a"
+            ],
+            "properties": {
+                "a": {
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "type": "number",
+                            "format": "float64"
+                        }
+                    }
+                },
+                "max_iterations": {
+                    "description": "MaxIterations limits the QL iterations, 30 per row when omitted.",
+                    "type": "integer",
+                    "example": 60
+                }
+            }
+        },
+        "rest.EigenResponse": {
+            "type": "object",
+            "properties": {
+                "iterations": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "values": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    },
+                    "example": [
+                        1,
+                        3
+                    ]
+                },
+                "vectors": {
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "type": "number",
+                            "format": "float64"
+                        }
+                    }
+                }
+            }
+        },
+        "rest.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Code is set for domain errors, e.g. \"division_by_zero\".",
+                    "type": "string",
+                    "example": "division_by_zero"
+                },
+                "error": {
+                    "type": "string",
+                    "example": "invalid input"
+                }
+            }
+        },
+        "rest.EvaluateBatchRequest": {
+            "type": "object"
+        },
+        "rest.EvaluateBatchResponse": {
+            "type": "object",
+            "properties": {
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "rounding": {
+                    "$ref": "#/definitions/rest.RoundingOptions"
+                }
+            }
+        },
+        "rest.EvaluateRequest": {
+            "type": "object",
+            "required": [
+                "expression"
+            ],
+            "properties": {
+                "angle_unit": {
+                    "type": "string",
+                    "default": "radians",
+                    "enum": [
+                        "radians",
+                        "degrees",
+                        "gradians"
+                    ]
+                },
+                "expression": {
+                    "type": "string",
+                    "example": "x^2 + 1"
+                },
+                "format": {
+                    "$ref": "#/definitions/rest.FormatOptions"
+                },
+                "rounding": {
+                    "$ref": "#/definitions/rest.RoundingOptions"
+                },
+                "variables": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    }
+                }
+            }
+        },
+        "rest.FormatOptions": {
+            "type": "object",
+            "properties": {
+                "base": {
+                    "description": "Base is between 2 and 36.",
+                    "type": "integer",
+                    "example": 16
+                },
+                "decimals": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "locale": {
+                    "type": "string",
+                    "example": "de-DE"
+                },
+                "significant": {
+                    "description": "Significant is a number of significant figures.",
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "rest.FormatRequest": {
+            "type": "object",
+            "required": [
+                "value"
+            ],
+            "properties": {
+                "base": {
+                    "description": "Base is between 2 and 36.",
+                    "type": "integer",
+                    "example": 16
+                },
+                "decimals": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "locale": {
+                    "type": "string",
+                    "example": "de-DE"
+                },
+                "significant": {
+                    "description": "Significant is a number of significant figures.",
+                    "type": "integer",
+                    "example": 3
+                },
+                "value": {
+                    "type": "number",
+                    "example": 1234567.89
+                }
+            }
+        },
+        "rest.Formatted": {
+            "type": "object",
+            "properties": {
+                "base": {
+                    "type": "string",
+                    "example": "12d687.e3d70a3d"
+                },
+                "engineering": {
+                    "type": "string",
+                    "example": "1.23456789e6"
+                },
+                "fixed": {
+                    "type": "string",
+                    "example": "1234567.89"
+                },
+                "localized": {
+                    "type": "string",
+                    "example": "1.234.567,89"
+                },
+                "scientific": {
+                    "type": "string",
+                    "example": "1.23456789e6"
+                },
+                "significant": {
+                    "type": "string",
+                    "example": "1230000"
+                }
+            }
+        },
+        "rest.FunctionDefinition": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "type": "string",
+                    "example": "max(0.3, x * 0.029)"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "fee"
+                },
+                "params": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "x"
+                    ]
+                }
+            }
+        },
+        "rest.FunctionInput": {
+            "type": "object",
+            "required": [
+                "body"
+            ],
+            "properties": {
+                "body": {
+                    "type": "string",
+                    "example": "max(0.3, x * 0.029)"
+                },
+                "params": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "x"
+                    ]
+                }
+            }
+        },
+        "rest.FunctionList": {
+            "type": "object",
+            "properties": {
+                "functions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.FunctionDefinition"
+                    }
+                }
+            }
+        },
+        "rest.IRRRequest": {
+            "type": "object",
+            "required": [
+                "cash_flows"
+            ],
+            "properties": {
+                "cash_flows": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    },
+                    "example": [
+                        -1000,
+                        300,
+                        400,
+                        500
+                    ]
+                },
+                "dates": {
+                    "description": "Dates are YYYY-MM-DD, one per cash flow. Flows are then discounted by\nthe years of 365 days since the earliest date.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "2026-01-01",
+                        "2026-07-01",
+                        "2027-01-01",
+                        "2027-07-01"
+                    ]
+                },
+                "format": {
+                    "$ref": "#/definitions/rest.FormatOptions"
+                },
+                "guess": {
+                    "description": "Guess picks the closest rate when several zero the NPV.",
+                    "type": "number",
+                    "default": 0.1,
+                    "example": 0.1
+                },
+                "rounding": {
+                    "$ref": "#/definitions/rest.RoundingOptions"
+                }
+            }
+        },
+        "rest.IntegrateRequest": {
+            "type": "object",
+            "required": [
+                "expression",
+                "lower",
+                "upper"
+            ],
+            "properties": {
+                "angle_unit": {
+                    "type": "string",
+                    "default": "radians",
+                    "enum": [
+                        "radians",
+                        "degrees",
+                        "gradians"
+                    ]
+                },
+                "expression": {
+                    "type": "string",
+                    "example": "exp(-x^2)"
+                },
+                "lower": {
+                    "type": "number",
+                    "example": 0
+                },
+                "max_evaluations": {
+                    "description": "MaxEvaluations is 100000 when omitted, up to 1000000.",
+                    "type": "integer",
+                    "example": 100000
+                },
+                "method": {
+                    "type": "string",
+                    "default": "gauss_kronrod",
+                    "enum": [
+                        "gauss_kronrod",
+                        "simpson"
+                    ]
+                },
+                "tolerance": {
+                    "description": "Tolerance is the absolute error to reach, 1e-10 when omitted.",
+                    "type": "number",
+                    "example": 1e-10
+                },
+                "upper": {
+                    "type": "number",
+                    "example": 1
+                },
+                "variable": {
+                    "description": "Variable is the integration variable, x when omitted.",
+                    "type": "string",
+                    "default": "x",
+                    "example": "x"
+                }
+            }
+        },
+        "rest.IntegrateResponse": {
+            "type": "object",
+            "properties": {
+                "error_estimate": {
+                    "type": "number",
+                    "example": 7.887024366937112e-13
+                },
+                "evaluations": {
+                    "type": "integer",
+                    "example": 15
+                },
+                "intervals": {
+                    "description": "Intervals is the number of subintervals the range was split into.",
+                    "type": "integer",
+                    "example": 1
+                },
+                "method": {
+                    "type": "string",
+                    "example": "gauss_kronrod"
+                },
+                "result": {
+                    "type": "number",
+                    "example": 0.746824132812427
+                }
+            }
+        },
+        "rest.ItemsResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "c",
+                        "a",
+                        "b"
+                    ]
+                },
+                "seed": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "source": {
+                    "type": "string",
+                    "enum": [
+                        "seeded",
+                        "crypto"
+                    ],
+                    "example": "seeded"
+                }
+            }
+        },
+        "rest.MatrixBinaryRequest": {
+            "type": "object",
+            "required": [
+                "a",
+                "b"
+            ],
+            "properties": {
+                "a": {
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "type": "number",
+                            "format": "float64"
+                        }
+                    }
+                },
+                "b": {
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "type": "number",
+                            "format": "float64"
+                        }
+                    }
+                }
+            }
+        },
+        "rest.MatrixRequest": {
+            "type": "object",
+            "required": [
+                "a"
+            ],
+            "properties": {
+                "a": {
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "type": "number",
+                            "format": "float64"
+                        }
+                    }
+                }
+            }
+        },
+        "rest.MatrixResponse": {
+            "type": "object",
+            "properties": {
+                "result": {
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "type": "number",
+                            "format": "float64"
+                        }
+                    }
+                }
+            }
+        },
+        "rest.Money": {
+            "type": "object",
+            "required": [
+                "amount",
+                "currency"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "string",
+                    "example": "10.50"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                }
+            }
+        },
+        "rest.MoneyAllocateRequest": {
+            "type": "object",
+            "required": [
+                "a",
+                "ratios"
+            ],
+            "properties": {
+                "a": {
+                    "$ref": "#/definitions/rest.Money"
+                },
+                "ratios": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "1",
+                        "1",
+                        "2"
+                    ]
+                }
+            }
+        },
+        "rest.MoneyBinaryRequest": {
+            "type": "object",
+            "required": [
+                "a",
+                "b"
+            ],
+            "properties": {
+                "a": {
+                    "$ref": "#/definitions/rest.Money"
+                },
+                "b": {
+                    "$ref": "#/definitions/rest.Money"
+                }
+            }
+        },
+        "rest.MoneyConvertRequest": {
+            "type": "object",
+            "required": [
+                "a",
+                "to"
+            ],
+            "properties": {
+                "a": {
+                    "$ref": "#/definitions/rest.Money"
+                },
+                "date": {
+                    "description": "Date selects the rates effective on that day. Defaults to today.",
+                    "type": "string",
+                    "example": "2026-06-01"
+                },
+                "rounding_mode": {
+                    "type": "string",
+                    "default": "half_even",
+                    "enum": [
+                        "half_up",
+                        "half_even",
+                        "down",
+                        "up",
+                        "ceiling",
+                        "floor"
+                    ]
+                },
+                "to": {
+                    "type": "string",
+                    "example": "EUR"
+                }
+            }
+        },
+        "rest.MoneyConvertResponse": {
+            "type": "object",
+            "properties": {
+                "rate": {
+                    "$ref": "#/definitions/rest.RateInfo"
+                },
+                "result": {
+                    "$ref": "#/definitions/rest.Money"
+                },
+                "rounding_mode": {
+                    "type": "string",
+                    "example": "half_even"
+                }
+            }
+        },
+        "rest.MoneyMultiplyRequest": {
+            "type": "object",
+            "required": [
+                "a",
+                "factor"
+            ],
+            "properties": {
+                "a": {
+                    "$ref": "#/definitions/rest.Money"
+                },
+                "factor": {
+                    "type": "string",
+                    "example": "1.075"
+                },
+                "rounding_mode": {
+                    "description": "RoundingMode rounds the product to minor units.",
+                    "type": "string",
+                    "default": "half_even",
+                    "enum": [
+                        "half_up",
+                        "half_even",
+                        "down",
+                        "up",
+                        "ceiling",
+                        "floor"
+                    ]
+                }
+            }
+        },
+        "rest.MoneyPartsResponse": {
+            "type": "object",
+            "properties": {
+                "parts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.Money"
+                    }
+                }
+            }
+        },
+        "rest.MoneyResponse": {
+            "type": "object",
+            "properties": {
+                "result": {
+                    "$ref": "#/definitions/rest.Money"
+                },
+                "rounding_mode": {
+                    "type": "string",
+                    "example": "half_even"
+                }
+            }
+        },
+        "rest.MoneySplitRequest": {
+            "type": "object",
+            "required": [
+                "a",
+                "parts"
+            ],
+            "properties": {
+                "a": {
+                    "$ref": "#/definitions/rest.Money"
+                },
+                "parts": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "rest.NPVRequest": {
+            "type": "object",
+            "required": [
+                "cash_flows",
+                "rate"
+            ],
+            "properties": {
+                "cash_flows": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    },
+                    "example": [
+                        -1000,
+                        300,
+                        400,
+                        500
+                    ]
+                },
+                "dates": {
+                    "description": "Dates are YYYY-MM-DD, one per cash flow. Flows are then discounted by\nthe years of 365 days since the earliest date.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "2026-01-01",
+                        "2026-07-01",
+                        "2027-01-01",
+                        "2027-07-01"
+                    ]
+                },
+                "format": {
+                    "$ref": "#/definitions/rest.FormatOptions"
+                },
+                "rate": {
+                    "description": "Rate is the discount rate per period, or per year with dates.",
+                    "type": "number",
+                    "example": 0.1
+                },
+                "rounding": {
+                    "$ref": "#/definitions/rest.RoundingOptions"
+                }
+            }
+        },
+        "rest.OperandInfo": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Divisor"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "b"
+                }
+            }
+        },
+        "rest.OperationError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "division_by_zero"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "division by zero"
+                }
+            }
+        },
+        "rest.OperationInfo": {
+            "type": "object",
+            "properties": {
+                "arity": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.OperationError"
+                    }
+                },
+                "label": {
+                    "type": "string",
+                    "example": "Division"
+                },
+                "modes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "real",
+                        "complex"
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "example": "divide"
+                },
+                "operands": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.OperandInfo"
+                    }
+                },
+                "path": {
+                    "type": "string",
+                    "example": "/v1/divide"
+                },
+                "schema": {
+                    "description": "Schema is the JSON Schema of the request body.",
+                    "type": "object"
+                },
+                "summary": {
+                    "type": "string",
+                    "example": "Divide two numbers"
+                },
+                "symbol": {
+                    "type": "string",
+                    "example": "/"
+                }
+            }
+        },
+        "rest.OperationList": {
+            "type": "object",
+            "properties": {
+                "operations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.OperationInfo"
+                    }
+                }
+            }
+        },
+        "rest.PercentChangeRequest": {
+            "type": "object",
+            "required": [
+                "from",
+                "to"
+            ],
+            "properties": {
+                "from": {
+                    "type": "string",
+                    "example": "80"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "100"
+                }
+            }
+        },
+        "rest.PercentChangeResponse": {
+            "type": "object",
+            "properties": {
+                "change": {
+                    "type": "string",
+                    "example": "20"
+                },
+                "percent": {
+                    "type": "string",
+                    "example": "25"
+                }
+            }
+        },
+        "rest.PercentileValue": {
+            "type": "object",
+            "properties": {
+                "percentile": {
+                    "type": "number",
+                    "example": 90
+                },
+                "value": {
+                    "type": "number",
+                    "example": 7.6
+                }
+            }
+        },
+        "rest.PolynomialBinaryRequest": {
+            "type": "object",
+            "required": [
+                "a",
+                "b"
+            ],
+            "properties": {
+                "a": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    },
+                    "example": [
+                        -1,
+                        0,
+                        1
+                    ]
+                },
+                "b": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    },
+                    "example": [
+                        1,
+                        1
+                    ]
+                }
+            }
+        },
+        "rest.PolynomialDerivativeRequest": {
+            "type": "object",
+            "required": [
+                "coefficients"
+            ],
+            "properties": {
+                "coefficients": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    },
+                    "example": [
+                        1,
+                        0,
+                        2
+                    ]
+                },
+                "order": {
+                    "description": "Order is the number of times to differentiate, 1 when omitted.",
+                    "type": "integer",
+                    "default": 1,
+                    "example": 1
+                }
+            }
+        },
+        "rest.PolynomialDivisionResponse": {
+            "type": "object",
+            "properties": {
+                "quotient": {
+                    "$ref": "#/definitions/rest.PolynomialResponse"
+                },
+                "remainder": {
+                    "$ref": "#/definitions/rest.PolynomialResponse"
+                }
+            }
+        },
+        "rest.PolynomialEvaluateRequest": {
+            "type": "object",
+            "required": [
+                "coefficients",
+                "x"
+            ],
+            "properties": {
+                "coefficients": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    },
+                    "example": [
+                        1,
+                        0,
+                        2
+                    ]
+                },
+                "x": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    },
+                    "example": [
+                        0,
+                        1,
+                        2
+                    ]
+                }
+            }
+        },
+        "rest.PolynomialResponse": {
+            "type": "object",
+            "properties": {
+                "coefficients": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    },
+                    "example": [
+                        0,
+                        1
+                    ]
+                },
+                "degree": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "rest.PolynomialRootsRequest": {
+            "type": "object",
+            "required": [
+                "coefficients"
+            ],
+            "properties": {
+                "coefficients": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    },
+                    "example": [
+                        2,
+                        -3,
+                        1
+                    ]
+                },
+                "max_iterations": {
+                    "description": "MaxIterations limits the Durand-Kerner iterations, 500 when omitted.",
+                    "type": "integer",
+                    "example": 500
+                }
+            }
+        },
+        "rest.PolynomialRootsResponse": {
+            "type": "object",
+            "properties": {
+                "iterations": {
+                    "type": "integer",
+                    "example": 6
+                },
+                "roots": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.ComplexNumber"
+                    }
+                }
+            }
+        },
+        "rest.Prediction": {
+            "type": "object",
+            "properties": {
+                "x": {
+                    "type": "number",
+                    "example": 10
+                },
+                "y": {
+                    "type": "number",
+                    "example": 20.66
+                }
+            }
+        },
+        "rest.PricingRequest": {
+            "type": "object",
+            "required": [
+                "cost",
+                "percent"
+            ],
+            "properties": {
+                "cost": {
+                    "$ref": "#/definitions/rest.Money"
+                },
+                "percent": {
+                    "type": "string",
+                    "example": "25"
+                },
+                "rounding_mode": {
+                    "type": "string",
+                    "default": "half_even",
+                    "enum": [
+                        "half_up",
+                        "half_even",
+                        "down",
+                        "up",
+                        "ceiling",
+                        "floor"
+                    ]
+                }
+            }
+        },
+        "rest.PricingResponse": {
+            "type": "object",
+            "properties": {
+                "cost": {
+                    "$ref": "#/definitions/rest.Money"
+                },
+                "margin": {
+                    "type": "string",
+                    "example": "20"
+                },
+                "markup": {
+                    "type": "string",
+                    "example": "25"
+                },
+                "price": {
+                    "$ref": "#/definitions/rest.Money"
+                },
+                "profit": {
+                    "$ref": "#/definitions/rest.Money"
+                },
+                "rounding_mode": {
+                    "type": "string",
+                    "example": "half_even"
+                }
+            }
+        },
+        "rest.ProgrammerInfo": {
+            "type": "object",
+            "properties": {
+                "operations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.ProgrammerOperationInfo"
+                    }
+                },
+                "overflows": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "wrap",
+                        "saturate",
+                        "error"
+                    ]
+                },
+                "types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "int8",
+                        "uint8",
+                        "big"
+                    ]
+                }
+            }
+        },
+        "rest.ProgrammerOperationInfo": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.OperationError"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "example": "xor"
+                },
+                "operands": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.OperandInfo"
+                    }
+                },
+                "path": {
+                    "type": "string",
+                    "example": "/v1/programmer/xor"
+                },
+                "summary": {
+                    "type": "string",
+                    "example": "Bitwise XOR"
+                }
+            }
+        },
+        "rest.ProgrammerRequest": {
+            "type": "object",
+            "properties": {
+                "a": {
+                    "type": "string",
+                    "example": "0xff"
+                },
+                "b": {
+                    "type": "string",
+                    "example": "0x0f"
+                },
+                "overflow": {
+                    "type": "string",
+                    "default": "wrap",
+                    "enum": [
+                        "wrap",
+                        "saturate",
+                        "error"
+                    ],
+                    "example": "wrap"
+                },
+                "type": {
+                    "type": "string",
+                    "default": "int64",
+                    "enum": [
+                        "int8",
+                        "int16",
+                        "int32",
+                        "int64",
+                        "uint8",
+                        "uint16",
+                        "uint32",
+                        "uint64",
+                        "big"
+                    ],
+                    "example": "uint8"
+                }
+            }
+        },
+        "rest.ProgrammerResponse": {
+            "type": "object",
+            "properties": {
+                "binary": {
+                    "type": "string",
+                    "example": "0b00001111"
+                },
+                "hex": {
+                    "type": "string",
+                    "example": "0x0f"
+                },
+                "octal": {
+                    "type": "string",
+                    "example": "0o17"
+                },
+                "overflowed": {
+                    "description": "Overflowed reports that the result was wrapped or saturated.",
+                    "type": "boolean",
+                    "example": false
+                },
+                "result": {
+                    "type": "string",
+                    "example": "15"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "uint8"
+                }
+            }
+        },
+        "rest.QRResponse": {
+            "type": "object",
+            "properties": {
+                "q": {
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "type": "number",
+                            "format": "float64"
+                        }
+                    }
+                },
+                "r": {
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "type": "number",
+                            "format": "float64"
+                        }
+                    }
+                }
+            }
+        },
+        "rest.Quartiles": {
+            "type": "object",
+            "properties": {
+                "iqr": {
+                    "type": "number",
+                    "example": 1.5
+                },
+                "q1": {
+                    "type": "number",
+                    "example": 4
+                },
+                "q2": {
+                    "type": "number",
+                    "example": 4.5
+                },
+                "q3": {
+                    "type": "number",
+                    "example": 5.5
+                }
+            }
+        },
+        "rest.RandomIntegersRequest": {
+            "type": "object",
+            "required": [
+                "max",
+                "min"
+            ],
+            "properties": {
+                "count": {
+                    "description": "Count defaults to 1.",
+                    "type": "integer",
+                    "default": 1,
+                    "example": 3
+                },
+                "max": {
+                    "type": "integer",
+                    "example": 6
+                },
+                "min": {
+                    "description": "Min and Max are inclusive.",
+                    "type": "integer",
+                    "example": 1
+                },
+                "seed": {
+                    "description": "Seed makes the output reproducible. Without it values come from a\ncryptographically secure source.",
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "rest.RandomIntegersResponse": {
+            "type": "object",
+            "properties": {
+                "seed": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "source": {
+                    "type": "string",
+                    "enum": [
+                        "seeded",
+                        "crypto"
+                    ],
+                    "example": "seeded"
+                },
+                "values": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        4,
+                        1,
+                        6
+                    ]
+                }
+            }
+        },
+        "rest.RandomNumbersRequest": {
+            "type": "object",
+            "required": [
+                "distribution"
+            ],
+            "properties": {
+                "count": {
+                    "description": "Count defaults to 1.",
+                    "type": "integer",
+                    "default": 1,
+                    "example": 3
+                },
+                "distribution": {
+                    "type": "string",
+                    "enum": [
+                        "normal",
+                        "binomial",
+                        "poisson",
+                        "exponential",
+                        "uniform",
+                        "t"
+                    ],
+                    "example": "normal"
+                },
+                "params": {
+                    "description": "Params are named as in GET /v1/random/distributions.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float64"
+                    },
+                    "example": {
+                        "mu": 0,
+                        "sigma": 1
+                    }
+                },
+                "seed": {
+                    "description": "Seed makes the output reproducible. Without it values come from a\ncryptographically secure source.",
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "rest.RandomNumbersResponse": {
+            "type": "object",
+            "properties": {
+                "seed": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "source": {
+                    "type": "string",
+                    "enum": [
+                        "seeded",
+                        "crypto"
+                    ],
+                    "example": "seeded"
+                },
+                "values": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    },
+                    "example": [
+                        0.3,
+                        -1.2,
+                        0.8
+                    ]
+                }
+            }
+        },
+        "rest.RankResponse": {
+            "type": "object",
+            "properties": {
+                "rank": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "rest.RateInfo": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "2026-06-01"
+                },
+                "from": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "rate": {
+                    "description": "Rate is rounded to 12 decimal places; conversions use its exact value.",
+                    "type": "string",
+                    "example": "0.92"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "EUR"
+                },
+                "via": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.RatesReloadResponse": {
+            "type": "object",
+            "properties": {
+                "rates": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "rest.RegressionRequest": {
+            "type": "object",
+            "required": [
+                "x",
+                "y"
+            ],
+            "properties": {
+                "degree": {
+                    "description": "Degree is 1 for a line, up to 10.",
+                    "type": "integer",
+                    "default": 1,
+                    "example": 1
+                },
+                "predict": {
+                    "description": "Predict lists x values to evaluate the fit at.",
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    },
+                    "example": [
+                        10
+                    ]
+                },
+                "x": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    },
+                    "example": [
+                        0,
+                        1,
+                        2,
+                        3
+                    ]
+                },
+                "y": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    },
+                    "example": [
+                        1.1,
+                        2.9,
+                        5.1,
+                        6.9
+                    ]
+                }
+            }
+        },
+        "rest.RegressionResponse": {
+            "type": "object",
+            "properties": {
+                "coefficients": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    },
+                    "example": [
+                        1.06,
+                        1.96
+                    ]
+                },
+                "predictions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.Prediction"
+                    }
+                },
+                "r_squared": {
+                    "type": "number",
+                    "example": 0.9983367983367983
+                },
+                "residuals": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    },
+                    "example": [
+                        0.04,
+                        -0.12,
+                        0.12,
+                        -0.04
+                    ]
+                }
+            }
+        },
+        "rest.Response": {
+            "type": "object",
+            "properties": {
+                "formatted": {
+                    "description": "Formatted is set when the request has format options.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/rest.Formatted"
+                        }
+                    ]
+                },
+                "result": {
+                    "type": "number",
+                    "example": 8.9
+                },
+                "rounding": {
+                    "description": "Rounding is the rounding applied to Result, if any.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/rest.RoundingOptions"
+                        }
+                    ]
+                }
+            }
+        },
+        "rest.RootRequest": {
+            "type": "object",
+            "required": [
+                "expression"
+            ],
+            "properties": {
+                "angle_unit": {
+                    "type": "string",
+                    "default": "radians",
+                    "enum": [
+                        "radians",
+                        "degrees",
+                        "gradians"
+                    ]
+                },
+                "bracket": {
+                    "description": "Bracket holds two points where the expression has opposite signs,\nrequired by bisection and brent.",
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    },
+                    "example": [
+                        2,
+                        3
+                    ]
+                },
+                "expression": {
+                    "type": "string",
+                    "example": "x^3 - 2*x - 5"
+                },
+                "guess": {
+                    "description": "Guess is the starting point of newton and secant.",
+                    "type": "number",
+                    "example": 2
+                },
+                "max_iterations": {
+                    "description": "MaxIterations is 200 when omitted, up to 10000.",
+                    "type": "integer",
+                    "example": 200
+                },
+                "method": {
+                    "description": "Method defaults to brent when a bracket is given and newton\notherwise.",
+                    "type": "string",
+                    "enum": [
+                        "bisection",
+                        "newton",
+                        "secant",
+                        "brent"
+                    ]
+                },
+                "tolerance": {
+                    "description": "Tolerance is the absolute error to reach, 1e-12 when omitted.",
+                    "type": "number",
+                    "example": 1e-12
+                },
+                "variable": {
+                    "description": "Variable is the unknown, x when omitted.",
+                    "type": "string",
+                    "default": "x",
+                    "example": "x"
+                }
+            }
+        },
+        "rest.RootResponse": {
+            "type": "object",
+            "properties": {
+                "iterations": {
+                    "type": "integer",
+                    "example": 6
+                },
+                "method": {
+                    "type": "string",
+                    "example": "brent"
+                },
+                "root": {
+                    "type": "number",
+                    "example": 2.094551481542327
+                },
+                "tolerance": {
+                    "description": "Tolerance bounds the error in root: the half-width of the final\nbracket for bisection and brent, the last step for newton and\nsecant, and 0 when value is exactly 0.",
+                    "type": "number",
+                    "example": 2.504663143554353e-13
+                },
+                "value": {
+                    "description": "Value is the expression at the root.",
+                    "type": "number",
+                    "example": 3.552713678800501e-15
+                }
+            }
+        },
+        "rest.RoundingOptions": {
+            "type": "object",
+            "properties": {
+                "decimals": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "mode": {
+                    "type": "string",
+                    "enum": [
+                        "half_up",
+                        "half_even",
+                        "down",
+                        "up",
+                        "ceiling",
+                        "floor"
+                    ],
+                    "example": "half_even"
+                },
+                "significant": {
+                    "type": "integer"
+                }
+            }
+        },
+        "rest.SVDRequest": {
+            "type": "object",
+            "required": [
+                "a"
+            ],
+            "properties": {
+                "a": {
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "type": "number",
+                            "format": "float64"
+                        }
+                    }
+                },
+                "max_sweeps": {
+                    "description": "MaxSweeps limits the Jacobi sweeps, 100 when omitted.",
+                    "type": "integer",
+                    "example": 100
+                }
+            }
+        },
+        "rest.SVDResponse": {
+            "type": "object",
+            "properties": {
+                "s": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    },
+                    "example": [
+                        5,
+                        3
+                    ]
+                },
+                "sweeps": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "u": {
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "type": "number",
+                            "format": "float64"
+                        }
+                    }
+                },
+                "v": {
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "type": "number",
+                            "format": "float64"
+                        }
+                    }
+                }
+            }
+        },
+        "rest.SampleRequest": {
+            "type": "object",
+            "required": [
+                "items"
+            ],
+            "properties": {
+                "count": {
+                    "description": "Count defaults to 1.",
+                    "type": "integer",
+                    "default": 1,
+                    "example": 3
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "a",
+                        "b",
+                        "c"
+                    ]
+                },
+                "replace": {
+                    "description": "Replace allows an item to be drawn more than once.",
+                    "type": "boolean",
+                    "example": false
+                },
+                "seed": {
+                    "description": "Seed makes the output reproducible. Without it values come from a\ncryptographically secure source.",
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "rest.ShuffleRequest": {
+            "type": "object",
+            "required": [
+                "items"
+            ],
+            "properties": {
+                "items": {
+                    "description": "Items may be any JSON values.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "a",
+                        "b",
+                        "c"
+                    ]
+                },
+                "seed": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "rest.SimplifyRequest": {
+            "type": "object",
+            "required": [
+                "expression"
+            ],
+            "properties": {
+                "expression": {
+                    "type": "string",
+                    "example": "x*x + 2*x - x + 0"
+                }
+            }
+        },
+        "rest.SolveRequest": {
+            "type": "object",
+            "required": [
+                "a",
+                "b"
+            ],
+            "properties": {
+                "a": {
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "type": "number",
+                            "format": "float64"
+                        }
+                    }
+                },
+                "b": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    },
+                    "example": [
+                        3,
+                        5
+                    ]
+                }
+            }
+        },
+        "rest.StatsRequest": {
+            "type": "object",
+            "required": [
+                "values"
+            ],
+            "properties": {
+                "percentiles": {
+                    "description": "Percentiles are between 0 and 100.",
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    },
+                    "example": [
+                        10,
+                        90
+                    ]
+                },
+                "values": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    },
+                    "example": [
+                        2,
+                        4,
+                        4,
+                        4,
+                        5,
+                        5,
+                        7,
+                        9
+                    ]
+                }
+            }
+        },
+        "rest.StatsResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 8
+                },
+                "max": {
+                    "type": "number",
+                    "example": 9
+                },
+                "mean": {
+                    "type": "number",
+                    "example": 5
+                },
+                "median": {
+                    "type": "number",
+                    "example": 4.5
+                },
+                "min": {
+                    "type": "number",
+                    "example": 2
+                },
+                "mode": {
+                    "description": "Mode holds the most frequent values; it is empty when none repeats.",
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    },
+                    "example": [
+                        4
+                    ]
+                },
+                "percentiles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.PercentileValue"
+                    }
+                },
+                "population_std_dev": {
+                    "type": "number",
+                    "example": 2
+                },
+                "population_variance": {
+                    "type": "number",
+                    "example": 4
+                },
+                "quartiles": {
+                    "$ref": "#/definitions/rest.Quartiles"
+                },
+                "range": {
+                    "type": "number",
+                    "example": 7
+                },
+                "sample_std_dev": {
+                    "type": "number",
+                    "example": 2.138089935299395
+                },
+                "sample_variance": {
+                    "type": "number",
+                    "example": 4.571428571428571
+                },
+                "sum": {
+                    "type": "number",
+                    "example": 40
+                }
+            }
+        },
+        "rest.SymbolicDerivativeRequest": {
+            "type": "object",
+            "required": [
+                "expression"
+            ],
+            "properties": {
+                "expression": {
+                    "type": "string",
+                    "example": "x^2*sin(x)"
+                },
+                "order": {
+                    "description": "Order is the number of times to differentiate, up to 10.",
+                    "type": "integer",
+                    "default": 1,
+                    "example": 1
+                },
+                "variable": {
+                    "description": "Variable is the differentiation variable, x when omitted.",
+                    "type": "string",
+                    "default": "x",
+                    "example": "x"
+                }
+            }
+        },
+        "rest.SymbolicResponse": {
+            "type": "object",
+            "properties": {
+                "expression": {
+                    "description": "Expression evaluates with /v1/evaluate given its variables.",
+                    "type": "string",
+                    "example": "2*x*sin(x) + x^2*cos(x)"
+                },
+                "latex": {
+                    "type": "string",
+                    "example": "2x \\cdot \\sin\\left(x\\right) + x^{2} \\cdot \\cos\\left(x\\right)"
+                }
+            }
+        },
+        "rest.TaxRequest": {
+            "type": "object",
+            "required": [
+                "a",
+                "rate"
+            ],
+            "properties": {
+                "a": {
+                    "$ref": "#/definitions/rest.Money"
+                },
+                "inclusive": {
+                    "description": "Inclusive tells that a already includes the tax, which is then\nremoved rather than added.",
+                    "type": "boolean",
+                    "example": false
+                },
+                "rate": {
+                    "type": "string",
+                    "example": "8.25"
+                },
+                "rounding_mode": {
+                    "type": "string",
+                    "default": "half_even",
+                    "enum": [
+                        "half_up",
+                        "half_even",
+                        "down",
+                        "up",
+                        "ceiling",
+                        "floor"
+                    ]
+                }
+            }
+        },
+        "rest.TaxResponse": {
+            "type": "object",
+            "properties": {
+                "gross": {
+                    "$ref": "#/definitions/rest.Money"
+                },
+                "inclusive": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "net": {
+                    "$ref": "#/definitions/rest.Money"
+                },
+                "rate": {
+                    "type": "string",
+                    "example": "8.25"
+                },
+                "rounding_mode": {
+                    "type": "string",
+                    "example": "half_even"
+                },
+                "tax": {
+                    "$ref": "#/definitions/rest.Money"
+                }
+            }
+        },
+        "rest.TipRequest": {
+            "type": "object",
+            "required": [
+                "bill",
+                "percent"
+            ],
+            "properties": {
+                "bill": {
+                    "$ref": "#/definitions/rest.Money"
+                },
+                "people": {
+                    "description": "People defaults to 1.",
+                    "type": "integer",
+                    "example": 3
+                },
+                "percent": {
+                    "type": "string",
+                    "example": "18"
+                },
+                "rounding_mode": {
+                    "type": "string",
+                    "default": "half_even",
+                    "enum": [
+                        "half_up",
+                        "half_even",
+                        "down",
+                        "up",
+                        "ceiling",
+                        "floor"
+                    ]
+                }
+            }
+        },
+        "rest.TipResponse": {
+            "type": "object",
+            "properties": {
+                "bill": {
+                    "$ref": "#/definitions/rest.Money"
+                },
+                "rounding_mode": {
+                    "type": "string",
+                    "example": "half_even"
+                },
+                "shares": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.Money"
+                    }
+                },
+                "tip": {
+                    "$ref": "#/definitions/rest.Money"
+                },
+                "total": {
+                    "$ref": "#/definitions/rest.Money"
+                }
+            }
+        },
+        "rest.VectorBinaryRequest": {
+            "type": "object",
+            "required": [
+                "a",
+                "b"
+            ],
+            "properties": {
+                "a": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    },
+                    "example": [
+                        1,
+                        2,
+                        3
+                    ]
+                },
+                "b": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    },
+                    "example": [
+                        4,
+                        5,
+                        6
+                    ]
+                }
+            }
+        },
+        "rest.VectorResponse": {
+            "type": "object",
+            "properties": {
+                "result": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    },
+                    "example": [
+                        0.8,
+                        1.4
+                    ]
                 }
             }
         }
@@ -0,0 +1,91 @@
+package calculator
+
+import (
+	"fmt"
+	"math"
+)
+
+// Epsilon is the spacing of float64 values at 1.
+const Epsilon = 0x1p-52
+
+// Brent finds a root of f in [a, b], where fa = f(a) and fb = f(b) do not
+// have the same sign, by Brent's method. It stops once the root is
+// bracketed within tol plus a few Epsilon relative to it, returning the
+// root, f there and the half-width of the final bracket, 0 when f is
+// exactly 0 there. Before each iteration it calls next, whose error ends the
+// search, so that callers bound the iterations and the time spent. Errors
+// of f are returned unchanged.
+func Brent(f func(float64) (float64, error), a, b, fa, fb, tol float64, next func() error) (x, fx, halfWidth float64, err error) {
+	c, fc := a, fa
+	d := b - a
+	e := d
+	for {
+		if (fb > 0) == (fc > 0) {
+			c, fc = a, fa
+			d = b - a
+			e = d
+		}
+		if math.Abs(fc) < math.Abs(fb) {
+			a, b, c = b, c, b
+			fa, fb, fc = fb, fc, fb
+		}
+		tol1 := 2*Epsilon*math.Abs(b) + tol/2
+		m := c/2 - b/2
+		if fb == 0 {
+			return b, 0, 0, nil
+		}
+		if math.Abs(m) <= tol1 {
+			return b, fb, math.Abs(m), nil
+		}
+		if err := next(); err != nil {
+			return 0, 0, 0, err
+		}
+		if math.Abs(e) >= tol1 && math.Abs(fa) > math.Abs(fb) {
+			// Inverse quadratic interpolation, or secant when a == c.
+			var p, q float64
+			s := fb / fa
+			if a == c {
+				p = 2 * m * s
+				q = 1 - s
+			} else {
+				q1, r := fa/fc, fb/fc
+				p = s * (2*m*q1*(q1-r) - (b-a)*(r-1))
+				q = (q1 - 1) * (r - 1) * (s - 1)
+			}
+			if p > 0 {
+				q = -q
+			}
+			p = math.Abs(p)
+			if 2*p < math.Min(3*m*q-math.Abs(tol1*q), math.Abs(e*q)) {
+				e, d = d, p/q
+			} else {
+				d, e = m, m
+			}
+		} else {
+			d, e = m, m
+		}
+		a, fa = b, fb
+		if math.Abs(d) > tol1 {
+			b += d
+		} else {
+			b += math.Copysign(tol1, m)
+		}
+		if fb, err = f(b); err != nil {
+			return 0, 0, 0, err
+		}
+	}
+}
+
+// brent is Brent for f that cannot fail, failing with ErrNoConvergence
+// after maxIter iterations.
+func brent(f func(float64) float64, a, b, tol float64, maxIter int) (float64, error) {
+	iterations := 0
+	x, _, _, err := Brent(func(x float64) (float64, error) { return f(x), nil }, a, b, f(a), f(b), tol, func() error {
+		if iterations == maxIter {
+			return fmt.Errorf("%w: after %d iterations", ErrNoConvergence, maxIter)
+		}
+		iterations++
+		return nil
+	})
+	return x, err
+}
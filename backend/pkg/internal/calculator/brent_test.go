@@ -0,0 +1,42 @@
+package calculator
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestBrent(t *testing.T) {
+	cube := func(x float64) (float64, error) { return x*x*x - 2, nil }
+	iterations := 0
+	next := func() error { iterations++; return nil }
+	x, fx, halfWidth, err := Brent(cube, 0, 2, -2, 6, 1e-15, next)
+	if err != nil || math.Abs(x-math.Cbrt(2)) > 1e-15 || halfWidth > 1e-15 || math.Abs(fx) > 1e-14 {
+		t.Errorf("Brent = %v, %v, %v, %v, want cbrt(2)", x, fx, halfWidth, err)
+	}
+	if iterations == 0 {
+		t.Error("next was not called")
+	}
+
+	// An end that is a root is returned as is.
+	if x, fx, halfWidth, err := Brent(cube, 0, math.Cbrt(2), -2, 0, 1e-15, next); err != nil || x != math.Cbrt(2) || fx != 0 || halfWidth != 0 {
+		t.Errorf("Brent at root = %v, %v, %v, %v", x, fx, halfWidth, err)
+	}
+
+	errStop := errors.New("stop")
+	if _, _, _, err := Brent(cube, 0, 2, -2, 6, 1e-15, func() error { return errStop }); err != errStop {
+		t.Errorf("Brent error = %v, want the error of next", err)
+	}
+	errEval := errors.New("eval")
+	failing := func(float64) (float64, error) { return 0, errEval }
+	if _, _, _, err := Brent(failing, 0, 2, -2, 6, 1e-15, next); err != errEval {
+		t.Errorf("Brent error = %v, want the error of f", err)
+	}
+}
+
+func TestBrentIterationLimit(t *testing.T) {
+	_, err := brent(func(x float64) float64 { return x*x*x - 2 }, 0, 2, 1e-15, 2)
+	if !errors.Is(err, ErrNoConvergence) {
+		t.Errorf("brent error = %v, want %v", err, ErrNoConvergence)
+	}
+}
@@ -1,14 +1,23 @@
 package calculator
 
 import (
-	"errors"
 	"math"
 )
 
+// Error is a domain error with a stable, machine-readable code.
+type Error struct {
+	Code    string
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
 var (
-	ErrDivisionByZero     = errors.New("division by zero")
-	ErrNegativeSqrt       = errors.New("sqrt negative number")
-	ErrNegativePercentage = errors.New("negative percentage")
+	ErrDivisionByZero     = &Error{"division_by_zero", "division by zero"}
+	ErrNegativeSqrt       = &Error{"negative_sqrt", "sqrt negative number"}
+	ErrNegativePercentage = &Error{"negative_percentage", "negative percentage"}
 )
 
 type Calculator interface {
@@ -47,11 +56,7 @@ func (c *simpleCalc) Divide(a, b float64) (float64, error) {
 }
 
 func (c *simpleCalc) Power(a, b float64) (float64, error) {
-	result := math.Pow(a, b)
-	if math.IsNaN(result) {
-		return 0, ErrNegativeSqrt
-	}
-	return result, nil
+	return power(a, b)
 }
 
 func (c *simpleCalc) Sqrt(a float64) (float64, error) {
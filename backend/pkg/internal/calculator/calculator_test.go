@@ -112,7 +112,12 @@ func TestPower(t *testing.T) {
 		{"negative exponent", 2, -1, 0.5, nil},
 		{"fractional exponent", 4, 0.5, 2, nil},
 		{"negative base integer exponent", -2, 3, -8, nil},
-		{"negative base fractional exponent", -4, 0.5, 0, ErrNegativeSqrt},
+		{"negative base fractional exponent", -4, 0.5, 0, ErrComplexResult},
+		{"negative base reciprocal of odd", -8, 1.0 / 3, -2, nil},
+		{"negative base reciprocal of 5", -32, 0.2, -2, nil},
+		{"negative base negative reciprocal", -8, -1.0 / 3, -0.5, nil},
+		{"negative base near reciprocal", -8, 0.3333, 0, ErrComplexResult},
+		{"negative base reciprocal of even", -16, 0.25, 0, ErrComplexResult},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
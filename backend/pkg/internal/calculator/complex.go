@@ -0,0 +1,240 @@
+package calculator
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Mode selects the number domain operations work on.
+type Mode string
+
+const (
+	Real    Mode = "real"
+	Complex Mode = "complex"
+)
+
+var Modes = []Mode{Real, Complex}
+
+var (
+	ErrInvalidMode        = &Error{"invalid_mode", "invalid mode"}
+	ErrInvalidComplex     = &Error{"invalid_complex", "invalid complex number"}
+	ErrComplexUnsupported = &Error{"complex_unsupported", "operation not available in complex mode"}
+	ErrComplexRequired    = &Error{"complex_required", "operation only available in complex mode"}
+)
+
+// ParseMode parses s, defaulting to Real when s is empty.
+func ParseMode(s string) (Mode, error) {
+	if s == "" {
+		return Real, nil
+	}
+	for _, m := range Modes {
+		if string(m) == s {
+			return m, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %q", ErrInvalidMode, s)
+}
+
+// bareImaginary matches a unit imaginary part without coefficient, as in
+// "i", "-i" or "3+i".
+var bareImaginary = regexp.MustCompile(`(^|[+-])i$`)
+
+// ParseComplex parses numbers such as "3+4i", "-2.5i", "i" or "7". Spaces
+// are ignored.
+func ParseComplex(s string) (complex128, error) {
+	t := strings.ReplaceAll(s, " ", "")
+	t = bareImaginary.ReplaceAllString(t, "${1}1i")
+	z, err := strconv.ParseComplex(t, 128)
+	if err != nil || !isFinite(z) {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidComplex, s)
+	}
+	return z, nil
+}
+
+// FormatComplex formats z as "3+4i", "3-4i", "2i" or "3".
+func FormatComplex(z complex128) string {
+	re := strconv.FormatFloat(real(z), 'f', -1, 64)
+	im := strconv.FormatFloat(imag(z), 'f', -1, 64)
+	switch {
+	case imag(z) == 0:
+		return re
+	case real(z) == 0:
+		return im + "i"
+	case imag(z) < 0:
+		return re + im + "i"
+	}
+	return re + "+" + im + "i"
+}
+
+func isFinite(z complex128) bool {
+	return !cmplx.IsNaN(z) && !cmplx.IsInf(z)
+}
+
+func cunary(fn func(complex128) complex128) func([]complex128) (complex128, error) {
+	return func(z []complex128) (complex128, error) { return fn(z[0]), nil }
+}
+
+func cbinary(fn func(a, b complex128) complex128) func([]complex128) (complex128, error) {
+	return func(z []complex128) (complex128, error) { return fn(z[0], z[1]), nil }
+}
+
+func ComplexDivide(a, b complex128) (complex128, error) {
+	if b == 0 {
+		return 0, ErrDivisionByZero
+	}
+	return a / b, nil
+}
+
+func ComplexPower(a, b complex128) (complex128, error) {
+	if a == 0 && real(b) < 0 {
+		return 0, ErrDivisionByZero
+	}
+	return cmplx.Pow(a, b), nil
+}
+
+// ComplexPercentage is a% of b. a must be real and non-negative, as in
+// real mode.
+func ComplexPercentage(a, b complex128) (complex128, error) {
+	if imag(a) != 0 {
+		return 0, ErrInvalidComplex
+	}
+	if real(a) < 0 {
+		return 0, ErrNegativePercentage
+	}
+	return a / 100 * b, nil
+}
+
+func ComplexLog(a complex128) (complex128, error) {
+	if a == 0 {
+		return 0, ErrLogDomain
+	}
+	return cmplx.Log(a), nil
+}
+
+func ComplexLog10(a complex128) (complex128, error) {
+	if a == 0 {
+		return 0, ErrLogDomain
+	}
+	return cmplx.Log10(a), nil
+}
+
+func ComplexLogBase(a, b complex128) (complex128, error) {
+	if b == 0 || b == 1 {
+		return 0, ErrLogBase
+	}
+	if a == 0 {
+		return 0, ErrLogDomain
+	}
+	return cmplx.Log(a) / cmplx.Log(b), nil
+}
+
+// ComplexRoot returns the principal nth root of a.
+func ComplexRoot(a, n complex128) (complex128, error) {
+	if n == 0 {
+		return 0, ErrRootIndex
+	}
+	return ComplexPower(a, 1/n)
+}
+
+// Rect returns the complex number with modulus r and argument theta, in
+// radians.
+func Rect(r, theta complex128) (complex128, error) {
+	if imag(r) != 0 || imag(theta) != 0 {
+		return 0, ErrInvalidComplex
+	}
+	return cmplx.Rect(real(r), real(theta)), nil
+}
+
+// ComplexOperations returns operations specific to complex numbers. abs,
+// arg and conj also work on real numbers.
+func ComplexOperations() []Operation {
+	z := []Operand{{"a", "Number"}}
+	return []Operation{
+		{
+			Name: "abs", Label: "Absolute value", Symbol: "|z|",
+			Summary: "Absolute value or modulus", Operands: z,
+			Eval: func(x []float64) (float64, error) {
+				return math.Abs(x[0]), nil
+			},
+			ComplexEval: cunary(func(a complex128) complex128 {
+				return complex(cmplx.Abs(a), 0)
+			}),
+		},
+		{
+			Name: "arg", Label: "Argument", Symbol: "arg",
+			Summary: "Argument (phase) in radians", Operands: z,
+			Eval: func(x []float64) (float64, error) {
+				if x[0] < 0 {
+					return math.Pi, nil
+				}
+				return 0, nil
+			},
+			ComplexEval: cunary(func(a complex128) complex128 {
+				return complex(cmplx.Phase(a), 0)
+			}),
+		},
+		{
+			Name: "conj", Label: "Conjugate", Symbol: "z̄",
+			Summary: "Complex conjugate", Operands: z,
+			Eval: func(x []float64) (float64, error) {
+				return x[0], nil
+			},
+			ComplexEval: cunary(cmplx.Conj),
+		},
+		{
+			Name: "rect", Label: "Polar to rectangular", Symbol: "rect",
+			Summary:  "Complex number from modulus and argument in radians",
+			Operands: []Operand{{"a", "Modulus"}, {"b", "Argument in radians"}},
+			Errors:   []*Error{ErrComplexRequired, ErrInvalidComplex},
+			ComplexEval: func(z []complex128) (complex128, error) {
+				return Rect(z[0], z[1])
+			},
+		},
+	}
+}
+
+// complexEvals implements the arithmetic and scientific operations in
+// complex mode. sqrt, ln and friends return principal values.
+var complexEvals = map[string]func([]complex128) (complex128, error){
+	"add":      cbinary(func(a, b complex128) complex128 { return a + b }),
+	"subtract": cbinary(func(a, b complex128) complex128 { return a - b }),
+	"multiply": cbinary(func(a, b complex128) complex128 { return a * b }),
+	"divide":   func(z []complex128) (complex128, error) { return ComplexDivide(z[0], z[1]) },
+	"power":    func(z []complex128) (complex128, error) { return ComplexPower(z[0], z[1]) },
+	"sqrt":     cunary(cmplx.Sqrt),
+	"percentage": func(z []complex128) (complex128, error) {
+		return ComplexPercentage(z[0], z[1])
+	},
+	"sin":   cunary(cmplx.Sin),
+	"cos":   cunary(cmplx.Cos),
+	"tan":   cunary(cmplx.Tan),
+	"asin":  cunary(cmplx.Asin),
+	"acos":  cunary(cmplx.Acos),
+	"atan":  cunary(cmplx.Atan),
+	"sinh":  cunary(cmplx.Sinh),
+	"cosh":  cunary(cmplx.Cosh),
+	"tanh":  cunary(cmplx.Tanh),
+	"asinh": cunary(cmplx.Asinh),
+	"acosh": cunary(cmplx.Acosh),
+	"atanh": cunary(cmplx.Atanh),
+	"ln":    func(z []complex128) (complex128, error) { return ComplexLog(z[0]) },
+	"log10": func(z []complex128) (complex128, error) { return ComplexLog10(z[0]) },
+	"log":   func(z []complex128) (complex128, error) { return ComplexLogBase(z[0], z[1]) },
+	"root":  func(z []complex128) (complex128, error) { return ComplexRoot(z[0], z[1]) },
+	"exp":   cunary(cmplx.Exp),
+}
+
+// withComplex sets ComplexEval on the operations of ops that have a complex
+// implementation.
+func withComplex(ops []Operation) []Operation {
+	for i := range ops {
+		if fn, ok := complexEvals[ops[i].Name]; ok && ops[i].ComplexEval == nil {
+			ops[i].ComplexEval = fn
+		}
+	}
+	return ops
+}
@@ -0,0 +1,132 @@
+package calculator
+
+import (
+	"errors"
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+func TestParseComplex(t *testing.T) {
+	tests := []struct {
+		in       string
+		expected complex128
+		valid    bool
+	}{
+		{"3+4i", 3 + 4i, true},
+		{"3 - 4i", 3 - 4i, true},
+		{"-2.5i", -2.5i, true},
+		{"i", 1i, true},
+		{"-i", -1i, true},
+		{"2+i", 2 + 1i, true},
+		{"7", 7, true},
+		{"1e3+2e-1i", 1000 + 0.2i, true},
+		{"", 0, false},
+		{"4i+3", 0, false},
+		{"NaN", 0, false},
+		{"1+Infi", 0, false},
+		{"abc", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			z, err := ParseComplex(tt.in)
+			if tt.valid != (err == nil) {
+				t.Fatalf("ParseComplex(%q) error = %v", tt.in, err)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidComplex) {
+				t.Errorf("ParseComplex(%q) error = %v, want ErrInvalidComplex", tt.in, err)
+			}
+			if z != tt.expected {
+				t.Errorf("ParseComplex(%q) = %v, want %v", tt.in, z, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatComplex(t *testing.T) {
+	tests := []struct {
+		z        complex128
+		expected string
+	}{
+		{3 + 4i, "3+4i"},
+		{3 - 4i, "3-4i"},
+		{2i, "2i"},
+		{-1.5, "-1.5"},
+		{0, "0"},
+	}
+	for _, tt := range tests {
+		if got := FormatComplex(tt.z); got != tt.expected {
+			t.Errorf("FormatComplex(%v) = %q, want %q", tt.z, got, tt.expected)
+		}
+	}
+}
+
+func TestComplexOperations(t *testing.T) {
+	r := DefaultRegistry(New())
+	tests := []struct {
+		op        string
+		args      []complex128
+		expected  complex128
+		expectErr error
+	}{
+		{"add", []complex128{1 + 2i, 3 - 1i}, 4 + 1i, nil},
+		{"subtract", []complex128{1 + 2i, 3 - 1i}, -2 + 3i, nil},
+		{"multiply", []complex128{1 + 2i, 3 - 1i}, 5 + 5i, nil},
+		{"divide", []complex128{5 + 5i, 3 - 1i}, 1 + 2i, nil},
+		{"divide", []complex128{1, 0}, 0, ErrDivisionByZero},
+		{"sqrt", []complex128{-4}, 2i, nil},
+		{"power", []complex128{1i, 2}, -1, nil},
+		{"power", []complex128{0, -1}, 0, ErrDivisionByZero},
+		{"percentage", []complex128{50, 4 + 2i}, 2 + 1i, nil},
+		{"percentage", []complex128{-5, 1}, 0, ErrNegativePercentage},
+		{"percentage", []complex128{1i, 1}, 0, ErrInvalidComplex},
+		{"ln", []complex128{-1}, complex(0, math.Pi), nil},
+		{"ln", []complex128{0}, 0, ErrLogDomain},
+		{"log", []complex128{8, 1}, 0, ErrLogBase},
+		{"exp", []complex128{complex(0, math.Pi)}, -1, nil},
+		{"exp", []complex128{1000}, 0, ErrOverflow},
+		{"root", []complex128{-8, 3}, 1 + complex(0, math.Sqrt(3)), nil},
+		{"root", []complex128{8, 0}, 0, ErrRootIndex},
+		{"acosh", []complex128{0}, complex(0, math.Pi/2), nil},
+		{"abs", []complex128{3 + 4i}, 5, nil},
+		{"arg", []complex128{1i}, math.Pi / 2, nil},
+		{"conj", []complex128{3 + 4i}, 3 - 4i, nil},
+		{"rect", []complex128{2, math.Pi / 2}, 2i, nil},
+		{"rect", []complex128{2i, 0}, 0, ErrInvalidComplex},
+		{"factorial", []complex128{3}, 0, ErrComplexUnsupported},
+	}
+	for _, tt := range tests {
+		t.Run(tt.op, func(t *testing.T) {
+			op, ok := r.Lookup(tt.op)
+			if !ok {
+				t.Fatalf("operation %q not registered", tt.op)
+			}
+			z, err := op.ApplyComplex(tt.args)
+			if !errors.Is(err, tt.expectErr) {
+				t.Fatalf("%s%v error = %v, want %v", tt.op, tt.args, err, tt.expectErr)
+			}
+			if cmplx.Abs(z-tt.expected) > 1e-12 {
+				t.Errorf("%s%v = %v, want %v", tt.op, tt.args, z, tt.expected)
+			}
+		})
+	}
+}
+
+func TestComplexOnlyOperation(t *testing.T) {
+	op, _ := DefaultRegistry(New()).Lookup("rect")
+	if _, err := op.Apply([]float64{1, 0}); err != ErrComplexRequired {
+		t.Errorf("Apply error = %v, want ErrComplexRequired", err)
+	}
+}
+
+func TestParseMode(t *testing.T) {
+	if m, err := ParseMode(""); m != Real || err != nil {
+		t.Errorf(`ParseMode("") = %v, %v`, m, err)
+	}
+	if m, err := ParseMode("complex"); m != Complex || err != nil {
+		t.Errorf(`ParseMode("complex") = %v, %v`, m, err)
+	}
+	if _, err := ParseMode("quaternion"); !errors.Is(err, ErrInvalidMode) {
+		t.Errorf(`ParseMode("quaternion") error = %v`, err)
+	}
+}
@@ -0,0 +1,486 @@
+package calculator
+
+import (
+	"fmt"
+	"math"
+)
+
+var (
+	ErrInvalidDistribution = &Error{"invalid_distribution_parameter", "invalid distribution parameter"}
+	ErrInvalidProbability  = &Error{"invalid_probability", "probability outside the valid range"}
+)
+
+func distErr(format string, args ...any) error {
+	return fmt.Errorf("%w: "+format, append([]any{ErrInvalidDistribution}, args...)...)
+}
+
+func checkProbability(p float64, open bool) error {
+	if !(p >= 0 && p <= 1) || (open && (p == 0 || p == 1)) {
+		if open {
+			return fmt.Errorf("%w: %g, want strictly between 0 and 1", ErrInvalidProbability, p)
+		}
+		return fmt.Errorf("%w: %g, want between 0 and 1", ErrInvalidProbability, p)
+	}
+	return nil
+}
+
+func checkPositive(name string, v float64) error {
+	if !(v > 0) || math.IsInf(v, 0) {
+		return distErr("%s must be positive, got %g", name, v)
+	}
+	return nil
+}
+
+func NormalPDF(x, mu, sigma float64) (float64, error) {
+	if err := checkPositive("sigma", sigma); err != nil {
+		return 0, err
+	}
+	z := (x - mu) / sigma
+	return math.Exp(-z*z/2) / (sigma * math.Sqrt(2*math.Pi)), nil
+}
+
+func NormalCDF(x, mu, sigma float64) (float64, error) {
+	if err := checkPositive("sigma", sigma); err != nil {
+		return 0, err
+	}
+	return math.Erfc(-(x-mu)/(sigma*math.Sqrt2)) / 2, nil
+}
+
+func NormalInv(p, mu, sigma float64) (float64, error) {
+	if err := checkPositive("sigma", sigma); err != nil {
+		return 0, err
+	}
+	if err := checkProbability(p, true); err != nil {
+		return 0, err
+	}
+	// Erfcinv loses accuracy in the tails; Newton steps on the CDF, which
+	// Erfc computes to full relative precision, recover it.
+	z := -math.Sqrt2 * math.Erfcinv(2*p)
+	for range 2 {
+		if p < 0.5 {
+			z -= (math.Erfc(-z/math.Sqrt2)/2 - p) / math.Exp(-z*z/2) * math.Sqrt(2*math.Pi)
+		} else {
+			z += (math.Erfc(z/math.Sqrt2)/2 - (1 - p)) / math.Exp(-z*z/2) * math.Sqrt(2*math.Pi)
+		}
+	}
+	return mu + sigma*z, nil
+}
+
+func checkBinomial(n, p float64) error {
+	if n < 0 || n != math.Trunc(n) || math.IsInf(n, 0) {
+		return distErr("n must be a non-negative integer, got %g", n)
+	}
+	if !(p >= 0 && p <= 1) {
+		return distErr("p must be between 0 and 1, got %g", p)
+	}
+	return nil
+}
+
+// BinomialPMF is the probability of k successes in n trials of
+// probability p.
+func BinomialPMF(k, n, p float64) (float64, error) {
+	if err := checkBinomial(n, p); err != nil {
+		return 0, err
+	}
+	if k < 0 || k > n || k != math.Trunc(k) {
+		return 0, nil
+	}
+	switch {
+	case p == 0:
+		return b2f(k == 0), nil
+	case p == 1:
+		return b2f(k == n), nil
+	}
+	return math.Exp(lchoose(n, k) + k*math.Log(p) + (n-k)*math.Log1p(-p)), nil
+}
+
+func BinomialCDF(k, n, p float64) (float64, error) {
+	if err := checkBinomial(n, p); err != nil {
+		return 0, err
+	}
+	k = math.Floor(k)
+	switch {
+	case k < 0:
+		return 0, nil
+	case k >= n:
+		return 1, nil
+	case p == 0:
+		return 1, nil
+	case p == 1:
+		return 0, nil
+	}
+	return regIncBeta(n-k, k+1, 1-p)
+}
+
+func BinomialInv(q, n, p float64) (float64, error) {
+	if err := checkBinomial(n, p); err != nil {
+		return 0, err
+	}
+	if err := checkProbability(q, false); err != nil {
+		return 0, err
+	}
+	return discreteInv(q, n, func(k float64) (float64, error) { return BinomialCDF(k, n, p) })
+}
+
+// PoissonPMF is the probability of k events at a mean rate lambda.
+func PoissonPMF(k, lambda float64) (float64, error) {
+	if err := checkPositive("lambda", lambda); err != nil {
+		return 0, err
+	}
+	if k < 0 || k != math.Trunc(k) {
+		return 0, nil
+	}
+	lg, _ := math.Lgamma(k + 1)
+	return math.Exp(k*math.Log(lambda) - lambda - lg), nil
+}
+
+func PoissonCDF(k, lambda float64) (float64, error) {
+	if err := checkPositive("lambda", lambda); err != nil {
+		return 0, err
+	}
+	k = math.Floor(k)
+	if k < 0 {
+		return 0, nil
+	}
+	return regGammaQ(k+1, lambda)
+}
+
+func PoissonInv(q, lambda float64) (float64, error) {
+	if err := checkPositive("lambda", lambda); err != nil {
+		return 0, err
+	}
+	if err := checkProbability(q, false); err != nil {
+		return 0, err
+	}
+	if q == 1 {
+		return 0, fmt.Errorf("%w: 1 has no finite Poisson quantile", ErrInvalidProbability)
+	}
+	cdf := func(k float64) (float64, error) { return PoissonCDF(k, lambda) }
+	hi := math.Ceil(lambda + 10*math.Sqrt(lambda) + 10)
+	for {
+		c, err := cdf(hi)
+		if err != nil {
+			return 0, err
+		}
+		if c >= q {
+			break
+		}
+		hi *= 2
+	}
+	return discreteInv(q, hi, cdf)
+}
+
+// discreteInv returns the smallest k in [0, hi] with cdf(k) >= q, given
+// cdf(hi) >= q.
+func discreteInv(q, hi float64, cdf func(float64) (float64, error)) (float64, error) {
+	lo := 0.0
+	for lo < hi {
+		mid := math.Floor((lo + hi) / 2)
+		c, err := cdf(mid)
+		if err != nil {
+			return 0, err
+		}
+		if c >= q {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return lo, nil
+}
+
+func ExponentialPDF(x, lambda float64) (float64, error) {
+	if err := checkPositive("lambda", lambda); err != nil {
+		return 0, err
+	}
+	if x < 0 {
+		return 0, nil
+	}
+	return lambda * math.Exp(-lambda*x), nil
+}
+
+func ExponentialCDF(x, lambda float64) (float64, error) {
+	if err := checkPositive("lambda", lambda); err != nil {
+		return 0, err
+	}
+	if x < 0 {
+		return 0, nil
+	}
+	return -math.Expm1(-lambda * x), nil
+}
+
+func ExponentialInv(p, lambda float64) (float64, error) {
+	if err := checkPositive("lambda", lambda); err != nil {
+		return 0, err
+	}
+	if err := checkProbability(p, false); err != nil {
+		return 0, err
+	}
+	if p == 1 {
+		return 0, fmt.Errorf("%w: 1 has no finite exponential quantile", ErrInvalidProbability)
+	}
+	return -math.Log1p(-p) / lambda, nil
+}
+
+func checkUniform(a, b float64) error {
+	if !(a < b) || math.IsInf(a, 0) || math.IsInf(b, 0) {
+		return distErr("a must be less than b, got %g and %g", a, b)
+	}
+	return nil
+}
+
+func UniformPDF(x, a, b float64) (float64, error) {
+	if err := checkUniform(a, b); err != nil {
+		return 0, err
+	}
+	if x < a || x > b {
+		return 0, nil
+	}
+	return 1 / (b - a), nil
+}
+
+func UniformCDF(x, a, b float64) (float64, error) {
+	if err := checkUniform(a, b); err != nil {
+		return 0, err
+	}
+	return math.Min(1, math.Max(0, (x-a)/(b-a))), nil
+}
+
+func UniformInv(p, a, b float64) (float64, error) {
+	if err := checkUniform(a, b); err != nil {
+		return 0, err
+	}
+	if err := checkProbability(p, false); err != nil {
+		return 0, err
+	}
+	return a + p*(b-a), nil
+}
+
+// StudentTPDF is the density of Student's t distribution with df degrees
+// of freedom.
+func StudentTPDF(x, df float64) (float64, error) {
+	if err := checkPositive("df", df); err != nil {
+		return 0, err
+	}
+	l1, _ := math.Lgamma((df + 1) / 2)
+	l2, _ := math.Lgamma(df / 2)
+	return math.Exp(l1-l2-(df+1)/2*math.Log1p(x*x/df)) / math.Sqrt(df*math.Pi), nil
+}
+
+func StudentTCDF(x, df float64) (float64, error) {
+	if err := checkPositive("df", df); err != nil {
+		return 0, err
+	}
+	if math.IsInf(x, 0) {
+		return b2f(x > 0), nil
+	}
+	tail, err := regIncBeta(df/2, 0.5, df/(df+x*x))
+	if err != nil {
+		return 0, err
+	}
+	if x > 0 {
+		return 1 - tail/2, nil
+	}
+	return tail / 2, nil
+}
+
+// StudentTInv inverts StudentTCDF with Brent's method.
+func StudentTInv(p, df float64) (float64, error) {
+	if err := checkPositive("df", df); err != nil {
+		return 0, err
+	}
+	if err := checkProbability(p, true); err != nil {
+		return 0, err
+	}
+	if p == 0.5 {
+		return 0, nil
+	}
+	f := func(t float64) float64 {
+		c, _ := StudentTCDF(t, df)
+		return c - p
+	}
+	// t is further from 0 than the normal quantile.
+	z, _ := NormalInv(p, 0, 1)
+	lo, hi := 0.0, 2*z
+	if z < 0 {
+		lo, hi = 2*z, 0
+	}
+	for i := 0; f(lo) > 0 || f(hi) < 0; i++ {
+		if i == 1100 {
+			return 0, fmt.Errorf("%w: quantile beyond ±1e300", ErrNoConvergence)
+		}
+		lo, hi = lo*2, hi*2
+	}
+	return brent(f, lo, hi, 1e-14*math.Max(1, math.Abs(z)), 200)
+}
+
+func b2f(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func lchoose(n, k float64) float64 {
+	a, _ := math.Lgamma(n + 1)
+	b, _ := math.Lgamma(k + 1)
+	c, _ := math.Lgamma(n - k + 1)
+	return a - b - c
+}
+
+const (
+	specialEps     = 1e-15
+	specialTiny    = 1e-300
+	specialMaxIter = 10000
+)
+
+// regIncBeta is the regularized incomplete beta function I_x(a, b),
+// evaluated with the continued fraction of Numerical Recipes.
+func regIncBeta(a, b, x float64) (float64, error) {
+	if x <= 0 {
+		return 0, nil
+	}
+	if x >= 1 {
+		return 1, nil
+	}
+	la, _ := math.Lgamma(a)
+	lb, _ := math.Lgamma(b)
+	lab, _ := math.Lgamma(a + b)
+	front := math.Exp(lab - la - lb + a*math.Log(x) + b*math.Log1p(-x))
+	if x < (a+1)/(a+b+2) {
+		cf, err := betaCF(a, b, x)
+		return front * cf / a, err
+	}
+	cf, err := betaCF(b, a, 1-x)
+	return 1 - front*cf/b, err
+}
+
+func betaCF(a, b, x float64) (float64, error) {
+	clamp := func(v float64) float64 {
+		if math.Abs(v) < specialTiny {
+			return specialTiny
+		}
+		return v
+	}
+	c, d := 1.0, 1/clamp(1-(a+b)*x/(a+1))
+	h := d
+	for m := 1.0; m <= specialMaxIter; m++ {
+		aa := m * (b - m) * x / ((a + 2*m - 1) * (a + 2*m))
+		d = 1 / clamp(1+aa*d)
+		c = clamp(1 + aa/c)
+		h *= d * c
+		aa = -(a + m) * (a + b + m) * x / ((a + 2*m) * (a + 2*m + 1))
+		d = 1 / clamp(1+aa*d)
+		c = clamp(1 + aa/c)
+		del := d * c
+		h *= del
+		if math.Abs(del-1) < specialEps {
+			return h, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: incomplete beta after %d iterations", ErrNoConvergence, specialMaxIter)
+}
+
+// regGammaQ is the regularized upper incomplete gamma function Q(a, x),
+// from its series below a+1 and its continued fraction above.
+func regGammaQ(a, x float64) (float64, error) {
+	if x <= 0 {
+		return 1, nil
+	}
+	lg, _ := math.Lgamma(a)
+	front := math.Exp(-x + a*math.Log(x) - lg)
+	if x < a+1 {
+		ap, sum := a, 1/a
+		del := sum
+		for range specialMaxIter {
+			ap++
+			del *= x / ap
+			sum += del
+			if math.Abs(del) < math.Abs(sum)*specialEps {
+				return 1 - sum*front, nil
+			}
+		}
+		return 0, fmt.Errorf("%w: incomplete gamma after %d iterations", ErrNoConvergence, specialMaxIter)
+	}
+	b := x + 1 - a
+	c, d := 1/specialTiny, 1/b
+	h := d
+	for i := 1.0; i <= specialMaxIter; i++ {
+		an := -i * (i - a)
+		b += 2
+		d = an*d + b
+		if math.Abs(d) < specialTiny {
+			d = specialTiny
+		}
+		c = b + an/c
+		if math.Abs(c) < specialTiny {
+			c = specialTiny
+		}
+		d = 1 / d
+		del := d * c
+		h *= del
+		if math.Abs(del-1) < specialEps {
+			return front * h, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: incomplete gamma after %d iterations", ErrNoConvergence, specialMaxIter)
+}
+
+// DistributionOperations returns the PDF (PMF for discrete distributions),
+// CDF and inverse CDF of each supported distribution. They are accurate to
+// about 1e-12 relative, or 1e-12 absolute for probabilities and quantiles
+// near zero. Discrete inverse CDFs return the smallest k whose CDF reaches
+// the probability.
+func DistributionOperations() []Operation {
+	x := Operand{"x", "Value"}
+	k := Operand{"k", "Number of events"}
+	p := Operand{"p", "Probability"}
+	mu := Operand{"mu", "Mean"}
+	sigma := Operand{"sigma", "Standard deviation"}
+	n := Operand{"n", "Number of trials"}
+	prob := Operand{"prob", "Success probability of each trial"}
+	lambda := Operand{"lambda", "Rate"}
+	a := Operand{"a", "Lower bound"}
+	b := Operand{"b", "Upper bound"}
+	df := Operand{"df", "Degrees of freedom"}
+	two := func(fn func(a, b float64) (float64, error)) func([]float64) (float64, error) {
+		return func(x []float64) (float64, error) { return fn(x[0], x[1]) }
+	}
+	three := func(fn func(a, b, c float64) (float64, error)) func([]float64) (float64, error) {
+		return func(x []float64) (float64, error) { return fn(x[0], x[1], x[2]) }
+	}
+	params := []*Error{ErrInvalidDistribution}
+	inv := []*Error{ErrInvalidDistribution, ErrInvalidProbability}
+	op := func(name, label, summary string, operands []Operand, errs []*Error,
+		eval func([]float64) (float64, error)) Operation {
+		return Operation{Name: name, Label: label, Symbol: name, Summary: summary,
+			Operands: operands, Errors: errs, Eval: eval}
+	}
+	return []Operation{
+		op("normal_pdf", "Normal PDF", "Normal probability density", []Operand{x, mu, sigma}, params, three(NormalPDF)),
+		op("normal_cdf", "Normal CDF", "Normal cumulative probability", []Operand{x, mu, sigma}, params, three(NormalCDF)),
+		op("normal_inv", "Normal inverse CDF", "Normal quantile", []Operand{p, mu, sigma}, inv, three(NormalInv)),
+		op("binomial_pmf", "Binomial PMF", "Probability of k successes in n trials", []Operand{k, n, prob}, params, three(BinomialPMF)),
+		op("binomial_cdf", "Binomial CDF", "Probability of at most k successes in n trials", []Operand{k, n, prob}, params,
+			three(BinomialCDF)),
+		op("binomial_inv", "Binomial inverse CDF", "Smallest k whose binomial CDF reaches p", []Operand{p, n, prob}, inv,
+			three(BinomialInv)),
+		op("poisson_pmf", "Poisson PMF", "Probability of k events", []Operand{k, lambda}, params, two(PoissonPMF)),
+		op("poisson_cdf", "Poisson CDF", "Probability of at most k events", []Operand{k, lambda}, params, two(PoissonCDF)),
+		op("poisson_inv", "Poisson inverse CDF", "Smallest k whose Poisson CDF reaches p", []Operand{p, lambda}, inv,
+			two(PoissonInv)),
+		op("exponential_pdf", "Exponential PDF", "Exponential probability density", []Operand{x, lambda}, params,
+			two(ExponentialPDF)),
+		op("exponential_cdf", "Exponential CDF", "Exponential cumulative probability", []Operand{x, lambda}, params,
+			two(ExponentialCDF)),
+		op("exponential_inv", "Exponential inverse CDF", "Exponential quantile", []Operand{p, lambda}, inv,
+			two(ExponentialInv)),
+		op("uniform_pdf", "Uniform PDF", "Uniform probability density", []Operand{x, a, b}, params, three(UniformPDF)),
+		op("uniform_cdf", "Uniform CDF", "Uniform cumulative probability", []Operand{x, a, b}, params, three(UniformCDF)),
+		op("uniform_inv", "Uniform inverse CDF", "Uniform quantile", []Operand{p, a, b}, inv, three(UniformInv)),
+		op("t_pdf", "Student's t PDF", "Student's t probability density", []Operand{x, df}, params, two(StudentTPDF)),
+		op("t_cdf", "Student's t CDF", "Student's t cumulative probability", []Operand{x, df}, params, two(StudentTCDF)),
+		op("t_inv", "Student's t inverse CDF", "Student's t quantile", []Operand{p, df},
+			[]*Error{ErrInvalidDistribution, ErrInvalidProbability, ErrNoConvergence}, two(StudentTInv)),
+	}
+}
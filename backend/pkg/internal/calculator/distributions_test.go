@@ -0,0 +1,133 @@
+package calculator
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// distTolerance is the documented accuracy of the distribution functions:
+// relative for values above 1 and absolute below.
+const distTolerance = 1e-12
+
+func TestDistributionOperations(t *testing.T) {
+	r := DefaultRegistry(New())
+	cauchyInv := math.Tan(math.Pi * (0.975 - 0.5))
+	tests := []struct {
+		name      string
+		op        string
+		args      []float64
+		expected  float64
+		expectErr error
+	}{
+		{"normal pdf at mean", "normal_pdf", []float64{0, 0, 1}, 1 / math.Sqrt(2*math.Pi), nil},
+		{"normal pdf scaled", "normal_pdf", []float64{12, 10, 2}, math.Exp(-0.5) / (2 * math.Sqrt(2*math.Pi)), nil},
+		{"normal cdf", "normal_cdf", []float64{1.96, 0, 1}, 0.9750021048517795, nil},
+		{"normal cdf lower tail", "normal_cdf", []float64{-10, 0, 1}, 7.619853024160527e-24, nil},
+		{"normal inv", "normal_inv", []float64{0.975, 0, 1}, 1.959963984540054, nil},
+		{"normal inv scaled", "normal_inv", []float64{0.5, 100, 15}, 100, nil},
+		{"normal zero sigma", "normal_cdf", []float64{0, 0, 0}, 0, ErrInvalidDistribution},
+		{"normal inv of 1", "normal_inv", []float64{1, 0, 1}, 0, ErrInvalidProbability},
+		{"binomial pmf", "binomial_pmf", []float64{3, 10, 0.5}, 120.0 / 1024, nil},
+		{"binomial pmf fractional k", "binomial_pmf", []float64{2.5, 10, 0.5}, 0, nil},
+		{"binomial pmf certain", "binomial_pmf", []float64{10, 10, 1}, 1, nil},
+		{"binomial cdf", "binomial_cdf", []float64{3, 10, 0.5}, 176.0 / 1024, nil},
+		{"binomial cdf above n", "binomial_cdf", []float64{11, 10, 0.3}, 1, nil},
+		{"binomial inv", "binomial_inv", []float64{0.171875, 10, 0.5}, 3, nil},
+		{"binomial inv just above", "binomial_inv", []float64{0.171876, 10, 0.5}, 4, nil},
+		{"binomial inv of 1", "binomial_inv", []float64{1, 10, 0.5}, 10, nil},
+		{"binomial fractional n", "binomial_pmf", []float64{1, 2.5, 0.5}, 0, ErrInvalidDistribution},
+		{"binomial invalid p", "binomial_cdf", []float64{1, 10, 1.5}, 0, ErrInvalidDistribution},
+		{"poisson pmf", "poisson_pmf", []float64{2, 3}, 4.5 * math.Exp(-3), nil},
+		{"poisson cdf", "poisson_cdf", []float64{2, 3}, 8.5 * math.Exp(-3), nil},
+		{"poisson cdf negative k", "poisson_cdf", []float64{-1, 3}, 0, nil},
+		{"poisson inv", "poisson_inv", []float64{0.5, 3}, 3, nil},
+		{"poisson inv of 0", "poisson_inv", []float64{0, 3}, 0, nil},
+		{"poisson inv of 1", "poisson_inv", []float64{1, 3}, 0, ErrInvalidProbability},
+		{"poisson zero lambda", "poisson_pmf", []float64{0, 0}, 0, ErrInvalidDistribution},
+		{"exponential pdf", "exponential_pdf", []float64{1, 2}, 2 * math.Exp(-2), nil},
+		{"exponential pdf negative x", "exponential_pdf", []float64{-1, 2}, 0, nil},
+		{"exponential cdf", "exponential_cdf", []float64{1, 2}, 1 - math.Exp(-2), nil},
+		{"exponential cdf small x", "exponential_cdf", []float64{1e-20, 1}, 1e-20, nil},
+		{"exponential inv", "exponential_inv", []float64{0.5, 2}, math.Ln2 / 2, nil},
+		{"exponential inv of 1", "exponential_inv", []float64{1, 2}, 0, ErrInvalidProbability},
+		{"uniform pdf", "uniform_pdf", []float64{3, 2, 6}, 0.25, nil},
+		{"uniform pdf outside", "uniform_pdf", []float64{7, 2, 6}, 0, nil},
+		{"uniform cdf", "uniform_cdf", []float64{3, 2, 6}, 0.25, nil},
+		{"uniform inv", "uniform_inv", []float64{1, 2, 6}, 6, nil},
+		{"uniform reversed bounds", "uniform_cdf", []float64{3, 6, 2}, 0, ErrInvalidDistribution},
+		{"uniform inv negative p", "uniform_inv", []float64{-0.1, 2, 6}, 0, ErrInvalidProbability},
+		{"t pdf cauchy", "t_pdf", []float64{1, 1}, 1 / (2 * math.Pi), nil},
+		{"t cdf cauchy", "t_cdf", []float64{2, 1}, 0.5 + math.Atan(2)/math.Pi, nil},
+		{"t cdf two df", "t_cdf", []float64{-1.5, 2}, 0.5 - 1.5/(2*math.Sqrt(2+1.5*1.5)), nil},
+		{"t inv cauchy", "t_inv", []float64{0.975, 1}, cauchyInv, nil},
+		{"t inv two df", "t_inv", []float64{0.025, 2}, -(0.95 * math.Sqrt(2/(4*0.975*0.025))), nil},
+		{"t inv ten df", "t_inv", []float64{0.975, 10}, 2.2281388519862747, nil},
+		{"t inv median", "t_inv", []float64{0.5, 3}, 0, nil},
+		{"t negative df", "t_pdf", []float64{0, -1}, 0, ErrInvalidDistribution},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op, _ := r.Lookup(tt.op)
+			got, err := op.Apply(tt.args)
+			if !errors.Is(err, tt.expectErr) {
+				t.Fatalf("%s%v error = %v, want %v", tt.op, tt.args, err, tt.expectErr)
+			}
+			if err == nil && math.Abs(got-tt.expected) > distTolerance*math.Max(1, math.Abs(tt.expected)) {
+				t.Errorf("%s%v = %v, want %v", tt.op, tt.args, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestDiscreteCDFs checks the incomplete beta and gamma functions against
+// summing the PMFs.
+func TestDiscreteCDFs(t *testing.T) {
+	for _, c := range []struct{ n, p float64 }{{50, 0.3}, {200, 0.02}, {1000, 0.5}} {
+		sum := 0.0
+		for k := 0.0; k <= c.n; k++ {
+			pmf, _ := BinomialPMF(k, c.n, c.p)
+			sum += pmf
+			got, err := BinomialCDF(k, c.n, c.p)
+			if err != nil || math.Abs(got-sum) > 1e-10 {
+				t.Fatalf("BinomialCDF(%g, %g, %g) = %v, %v, want %v", k, c.n, c.p, got, err, sum)
+			}
+		}
+	}
+	for _, lambda := range []float64{0.1, 7.5, 100, 2500} {
+		sum := 0.0
+		for k := 0.0; k <= 2*lambda+50; k++ {
+			pmf, _ := PoissonPMF(k, lambda)
+			sum += pmf
+			got, err := PoissonCDF(k, lambda)
+			if err != nil || math.Abs(got-sum) > 1e-10 {
+				t.Fatalf("PoissonCDF(%g, %g) = %v, %v, want %v", k, lambda, got, err, sum)
+			}
+		}
+	}
+}
+
+func TestInverseCDFs(t *testing.T) {
+	for _, p := range []float64{1e-10, 0.001, 0.1, 0.5, 0.9, 0.999, 1 - 1e-10} {
+		for _, df := range []float64{0.5, 1, 3, 30, 1000} {
+			x, err := StudentTInv(p, df)
+			if err != nil {
+				t.Fatalf("StudentTInv(%g, %g) error = %v", p, df, err)
+			}
+			got, _ := StudentTCDF(x, df)
+			if math.Abs(got-p) > distTolerance*math.Max(p, 1e-3) {
+				t.Errorf("StudentTCDF(StudentTInv(%g, %g)) = %v", p, df, got)
+			}
+		}
+		x, _ := NormalInv(p, 0, 1)
+		if got, _ := NormalCDF(x, 0, 1); math.Abs(got-p) > distTolerance*p {
+			t.Errorf("NormalCDF(NormalInv(%g)) = %v", p, got)
+		}
+		k, _ := PoissonInv(p, 40)
+		above, _ := PoissonCDF(k, 40)
+		below, _ := PoissonCDF(k-1, 40)
+		if above < p || below >= p {
+			t.Errorf("PoissonInv(%g, 40) = %g with CDFs %v and %v", p, k, below, above)
+		}
+	}
+}
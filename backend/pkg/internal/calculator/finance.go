@@ -0,0 +1,232 @@
+package calculator
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"time"
+)
+
+var (
+	ErrInvalidRate      = &Error{"invalid_rate", "rate must be greater than -1"}
+	ErrInvalidPeriods   = &Error{"invalid_periods", "invalid number of periods"}
+	ErrInvalidCashFlows = &Error{"invalid_cash_flows", "not enough cash flows"}
+	ErrCashFlowSigns    = &Error{"cash_flow_signs", "cash flows must include both positive and negative values"}
+	ErrInvalidDates     = &Error{"invalid_dates", "there must be one date per cash flow"}
+	ErrNoConvergence    = &Error{"no_convergence", "root finding did not converge"}
+)
+
+// FutureValue returns the value after n periods at rate of pv plus a
+// payment pmt at the end of each period.
+func FutureValue(rate, n, pmt, pv float64) (float64, error) {
+	if rate <= -1 {
+		return 0, ErrInvalidRate
+	}
+	if n < 0 {
+		return 0, ErrInvalidPeriods
+	}
+	g := math.Pow(1+rate, n)
+	annuity := n
+	if rate != 0 {
+		annuity = (g - 1) / rate
+	}
+	return finite(pv*g + pmt*annuity)
+}
+
+// PresentValue returns the value today at rate of fv due in n periods plus
+// a payment pmt at the end of each period.
+func PresentValue(rate, n, pmt, fv float64) (float64, error) {
+	if rate <= -1 {
+		return 0, ErrInvalidRate
+	}
+	if n < 0 {
+		return 0, ErrInvalidPeriods
+	}
+	d := math.Pow(1+rate, -n)
+	annuity := n
+	if rate != 0 {
+		annuity = (1 - d) / rate
+	}
+	return finite(fv*d + pmt*annuity)
+}
+
+// CompoundInterest returns principal after years at the annual rate,
+// compounded m times a year, or continuously when m is 0.
+func CompoundInterest(principal, rate, years, m float64) (float64, error) {
+	if years < 0 || m < 0 || m != math.Trunc(m) {
+		return 0, ErrInvalidPeriods
+	}
+	if m == 0 {
+		return finite(principal * math.Exp(rate*years))
+	}
+	if rate/m <= -1 {
+		return 0, ErrInvalidRate
+	}
+	return finite(principal * math.Pow(1+rate/m, m*years))
+}
+
+// NPV returns the net present value at rate of flows, the first of which
+// happens now and the others at the end of each following period.
+func NPV(rate float64, flows []float64) (float64, error) {
+	if rate <= -1 {
+		return 0, ErrInvalidRate
+	}
+	if len(flows) == 0 {
+		return 0, fmt.Errorf("%w: at least one is required", ErrInvalidCashFlows)
+	}
+	sum := 0.0
+	for i, f := range flows {
+		sum += f / math.Pow(1+rate, float64(i))
+	}
+	return finite(sum)
+}
+
+// XNPV is like NPV but with flows happening on dates, discounted by the
+// years of 365 days since the earliest date.
+func XNPV(rate float64, flows []float64, dates []time.Time) (float64, error) {
+	if rate <= -1 {
+		return 0, ErrInvalidRate
+	}
+	if len(flows) == 0 {
+		return 0, fmt.Errorf("%w: at least one is required", ErrInvalidCashFlows)
+	}
+	if len(dates) != len(flows) {
+		return 0, ErrInvalidDates
+	}
+	years := yearFractions(dates)
+	sum := 0.0
+	for i, f := range flows {
+		sum += f / math.Pow(1+rate, years[i])
+	}
+	return finite(sum)
+}
+
+func yearFractions(dates []time.Time) []float64 {
+	first := slices.MinFunc(dates, func(a, b time.Time) int { return a.Compare(b) })
+	years := make([]float64, len(dates))
+	for i, d := range dates {
+		years[i] = d.Sub(first).Hours() / 24 / 365
+	}
+	return years
+}
+
+// IRR returns the rate at which the NPV of flows is zero. Flows with more
+// than one sign change may have several such rates; IRR returns the one
+// closest to guess.
+func IRR(flows []float64, guess float64) (float64, error) {
+	if err := checkFlows(flows); err != nil {
+		return 0, err
+	}
+	return irr(func(r float64) float64 {
+		v, _ := NPV(r, flows)
+		return v
+	}, guess)
+}
+
+// XIRR is like IRR but with flows happening on dates.
+func XIRR(flows []float64, dates []time.Time, guess float64) (float64, error) {
+	if err := checkFlows(flows); err != nil {
+		return 0, err
+	}
+	if len(dates) != len(flows) {
+		return 0, ErrInvalidDates
+	}
+	years := yearFractions(dates)
+	return irr(func(r float64) float64 {
+		sum := 0.0
+		for i, f := range flows {
+			sum += f / math.Pow(1+r, years[i])
+		}
+		return sum
+	}, guess)
+}
+
+func checkFlows(flows []float64) error {
+	if len(flows) < 2 {
+		return fmt.Errorf("%w: at least two are required", ErrInvalidCashFlows)
+	}
+	if slices.Max(flows) <= 0 || slices.Min(flows) >= 0 {
+		return ErrCashFlowSigns
+	}
+	return nil
+}
+
+// irrGrid are the rates where irr looks for sign changes of the NPV.
+var irrGrid = []float64{
+	-0.999, -0.99, -0.95, -0.9, -0.8, -0.7, -0.6, -0.5, -0.4, -0.3, -0.2, -0.1, -0.05,
+	0, 0.02, 0.05, 0.1, 0.15, 0.2, 0.3, 0.4, 0.5, 0.75, 1, 1.5, 2, 3, 5, 10, 20, 50, 100, 1e3, 1e4, 1e6,
+}
+
+// irr brackets the roots of npv over irrGrid and refines the one closest to
+// guess with Brent's method.
+func irr(npv func(float64) float64, guess float64) (float64, error) {
+	best, bestDist := -1, math.Inf(1)
+	prev := math.NaN()
+	for i, r := range irrGrid {
+		v := npv(r)
+		if math.IsInf(v, 0) || math.IsNaN(v) {
+			prev = math.NaN()
+			continue
+		}
+		if v == 0 {
+			return r, nil
+		}
+		if !math.IsNaN(prev) && (prev < 0) != (v < 0) {
+			lo, hi := irrGrid[i-1], r
+			dist := 0.0
+			if guess < lo {
+				dist = lo - guess
+			} else if guess > hi {
+				dist = guess - hi
+			}
+			if dist < bestDist {
+				best, bestDist = i, dist
+			}
+		}
+		prev = v
+	}
+	if best < 0 {
+		return 0, fmt.Errorf("%w: no rate between %g and %g zeroes the NPV",
+			ErrNoConvergence, irrGrid[0], irrGrid[len(irrGrid)-1])
+	}
+	return brent(npv, irrGrid[best-1], irrGrid[best], 1e-12, 200)
+}
+
+// FinanceOperations returns the time value of money operations. Rates, there
+// and in the functions above, are fractions per period, e.g. 0.05 for 5%.
+func FinanceOperations() []Operation {
+	rate := Operand{"rate", "Interest rate per period, e.g. 0.05 for 5%"}
+	periods := Operand{"n", "Number of periods"}
+	payment := Operand{"pmt", "Payment at the end of each period"}
+	four := func(fn func(a, b, c, d float64) (float64, error)) func([]float64) (float64, error) {
+		return func(x []float64) (float64, error) { return fn(x[0], x[1], x[2], x[3]) }
+	}
+	return []Operation{
+		{
+			Name: "fv", Label: "Future value", Symbol: "FV",
+			Summary:  "Future value of a present value and periodic payments",
+			Operands: []Operand{rate, periods, payment, {"pv", "Present value"}},
+			Errors:   []*Error{ErrInvalidRate, ErrInvalidPeriods, ErrOverflow},
+			Eval:     four(FutureValue),
+		},
+		{
+			Name: "pv", Label: "Present value", Symbol: "PV",
+			Summary:  "Present value of a future value and periodic payments",
+			Operands: []Operand{rate, periods, payment, {"fv", "Future value"}},
+			Errors:   []*Error{ErrInvalidRate, ErrInvalidPeriods, ErrOverflow},
+			Eval:     four(PresentValue),
+		},
+		{
+			Name: "compound", Label: "Compound interest", Symbol: "compound",
+			Summary: "Principal grown at an annual rate compounded m times a year",
+			Operands: []Operand{
+				{"principal", "Principal"},
+				{"rate", "Annual interest rate, e.g. 0.05 for 5%"},
+				{"years", "Number of years"},
+				{"m", "Compounding periods per year, e.g. 12 for monthly; 0 for continuous"},
+			},
+			Errors: []*Error{ErrInvalidRate, ErrInvalidPeriods, ErrOverflow},
+			Eval:   four(CompoundInterest),
+		},
+	}
+}
@@ -0,0 +1,117 @@
+package calculator
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestFinanceOperations(t *testing.T) {
+	r := DefaultRegistry(New())
+	tests := []struct {
+		name      string
+		op        string
+		args      []float64
+		expected  float64
+		expectErr error
+	}{
+		{"fv lump sum", "fv", []float64{0.05, 10, 0, 1000}, 1628.894626777442, nil},
+		{"fv annuity", "fv", []float64{0.05, 10, 100, 0}, 1257.7892535548839, nil},
+		{"fv zero rate", "fv", []float64{0, 10, 100, 1000}, 2000, nil},
+		{"fv invalid rate", "fv", []float64{-1, 10, 0, 1000}, 0, ErrInvalidRate},
+		{"fv negative periods", "fv", []float64{0.05, -1, 0, 1000}, 0, ErrInvalidPeriods},
+		{"fv overflow", "fv", []float64{1, 1e6, 0, 1}, 0, ErrOverflow},
+		{"pv lump sum", "pv", []float64{0.05, 10, 0, 1628.894626777442}, 1000, nil},
+		{"pv annuity", "pv", []float64{0.05, 10, 100, 0}, 772.1734929184818, nil},
+		{"pv zero rate", "pv", []float64{0, 10, 100, 1000}, 2000, nil},
+		{"compound monthly", "compound", []float64{1000, 0.05, 10, 12}, 1647.0094976902801, nil},
+		{"compound annually", "compound", []float64{1000, 0.05, 10, 1}, 1628.894626777442, nil},
+		{"compound continuous", "compound", []float64{1000, 0.05, 10, 0}, 1648.7212707001282, nil},
+		{"compound fractional frequency", "compound", []float64{1000, 0.05, 10, 2.5}, 0, ErrInvalidPeriods},
+		{"compound negative years", "compound", []float64{1000, 0.05, -1, 12}, 0, ErrInvalidPeriods},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op, _ := r.Lookup(tt.op)
+			got, err := op.Apply(tt.args)
+			if !errors.Is(err, tt.expectErr) {
+				t.Fatalf("%s%v error = %v, want %v", tt.op, tt.args, err, tt.expectErr)
+			}
+			if err == nil && math.Abs(got-tt.expected) > 1e-9*math.Max(1, math.Abs(tt.expected)) {
+				t.Errorf("%s%v = %v, want %v", tt.op, tt.args, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNPV(t *testing.T) {
+	got, err := NPV(0.1, []float64{-1000, 300, 400, 500})
+	if err != nil || math.Abs(got-(-1000+300/1.1+400/1.21+500/1.331)) > 1e-9 {
+		t.Errorf("NPV = %v, %v", got, err)
+	}
+	if _, err := NPV(-1, []float64{1}); !errors.Is(err, ErrInvalidRate) {
+		t.Errorf("NPV(-1) error = %v", err)
+	}
+	if _, err := NPV(0.1, nil); !errors.Is(err, ErrInvalidCashFlows) {
+		t.Errorf("NPV(no flows) error = %v", err)
+	}
+	if _, err := XNPV(0.1, nil, nil); !errors.Is(err, ErrInvalidCashFlows) {
+		t.Errorf("XNPV(no flows) error = %v", err)
+	}
+}
+
+func TestIRR(t *testing.T) {
+	tests := []struct {
+		name      string
+		flows     []float64
+		guess     float64
+		expected  float64
+		expectErr error
+	}{
+		{"simple", []float64{-1000, 300, 400, 500}, 0.1, 0.0889633947, nil},
+		{"one period", []float64{-100, 110}, 0.1, 0.1, nil},
+		{"negative rate", []float64{-100, 50, 40}, 0.1, -0.0699264746, nil},
+		{"large rate", []float64{-1, 100}, 0.1, 99, nil},
+		// -100 + 230/(1+r) - 132/(1+r)^2 is zero at 10% and 20%.
+		{"two roots near 10%", []float64{-100, 230, -132}, 0.05, 0.1, nil},
+		{"two roots near 20%", []float64{-100, 230, -132}, 0.25, 0.2, nil},
+		{"no sign change", []float64{100, 200}, 0.1, 0, ErrCashFlowSigns},
+		{"single flow", []float64{-100}, 0.1, 0, ErrInvalidCashFlows},
+		// -100 + 50/(1+r) - 50/(1+r)^2 is negative for every rate.
+		{"no root", []float64{-100, 50, -50, 1e-9}, 0.1, 0, ErrNoConvergence},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := IRR(tt.flows, tt.guess)
+			if !errors.Is(err, tt.expectErr) {
+				t.Fatalf("IRR(%v) error = %v, want %v", tt.flows, err, tt.expectErr)
+			}
+			if err == nil && math.Abs(got-tt.expected) > 1e-6 {
+				t.Errorf("IRR(%v) = %v, want %v", tt.flows, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestXIRR(t *testing.T) {
+	dates := []time.Time{
+		time.Date(2008, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2008, 3, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2008, 10, 30, 0, 0, 0, 0, time.UTC),
+		time.Date(2009, 2, 15, 0, 0, 0, 0, time.UTC),
+		time.Date(2009, 4, 1, 0, 0, 0, 0, time.UTC),
+	}
+	flows := []float64{-10000, 2750, 4250, 3250, 2750}
+	got, err := XIRR(flows, dates, 0.1)
+	// The spreadsheet example gives 37.34%.
+	if err != nil || math.Abs(got-0.373362535) > 1e-6 {
+		t.Errorf("XIRR = %v, %v", got, err)
+	}
+	if v, err := XNPV(got, flows, dates); err != nil || math.Abs(v) > 1e-6 {
+		t.Errorf("XNPV at XIRR = %v, %v", v, err)
+	}
+	if _, err := XIRR(flows, dates[:2], 0.1); !errors.Is(err, ErrInvalidDates) {
+		t.Errorf("XIRR with missing dates error = %v", err)
+	}
+}
@@ -0,0 +1,355 @@
+package calculator
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// IntType is an integer type of programmer mode. Bits is 0 for unbounded
+// (big) integers.
+type IntType struct {
+	Name   string
+	Bits   uint
+	Signed bool
+}
+
+var (
+	Int8   = IntType{"int8", 8, true}
+	Int16  = IntType{"int16", 16, true}
+	Int32  = IntType{"int32", 32, true}
+	Int64  = IntType{"int64", 64, true}
+	Uint8  = IntType{"uint8", 8, false}
+	Uint16 = IntType{"uint16", 16, false}
+	Uint32 = IntType{"uint32", 32, false}
+	Uint64 = IntType{"uint64", 64, false}
+	BigInt = IntType{"big", 0, true}
+)
+
+var IntTypes = []IntType{Int8, Int16, Int32, Int64, Uint8, Uint16, Uint32, Uint64, BigInt}
+
+// Overflow selects what happens when a result does not fit its type.
+type Overflow string
+
+const (
+	Wrap          Overflow = "wrap"
+	Saturate      Overflow = "saturate"
+	OverflowError Overflow = "error"
+)
+
+var Overflows = []Overflow{Wrap, Saturate, OverflowError}
+
+// MaxBigBits bounds the size of big integers.
+const MaxBigBits = 1 << 16
+
+var (
+	ErrInvalidIntType   = &Error{"invalid_int_type", "invalid integer type"}
+	ErrInvalidOverflow  = &Error{"invalid_overflow", "invalid overflow mode"}
+	ErrInvalidInteger   = &Error{"invalid_integer", "invalid integer"}
+	ErrIntegerRange     = &Error{"integer_range", "operand out of range for type"}
+	ErrIntegerOverflow  = &Error{"integer_overflow", "integer overflow"}
+	ErrShiftCount       = &Error{"invalid_shift", "shift count must not be negative"}
+	ErrRotateUnbounded  = &Error{"rotate_unbounded", "rotate requires a fixed width type"}
+	ErrUnsignedNegation = &Error{"unsigned_negation", "unsigned integers cannot be negated"}
+)
+
+// ParseIntType parses s, defaulting to Int64 when s is empty.
+func ParseIntType(s string) (IntType, error) {
+	if s == "" {
+		return Int64, nil
+	}
+	for _, t := range IntTypes {
+		if t.Name == s {
+			return t, nil
+		}
+	}
+	return IntType{}, fmt.Errorf("%w: %q", ErrInvalidIntType, s)
+}
+
+// ParseOverflow parses s, defaulting to Wrap when s is empty.
+func ParseOverflow(s string) (Overflow, error) {
+	if s == "" {
+		return Wrap, nil
+	}
+	for _, o := range Overflows {
+		if string(o) == s {
+			return o, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %q", ErrInvalidOverflow, s)
+}
+
+func (t IntType) bounds() (lo, hi *big.Int) {
+	m := new(big.Int).Lsh(big.NewInt(1), t.Bits)
+	if !t.Signed {
+		return new(big.Int), m.Sub(m, big.NewInt(1))
+	}
+	half := new(big.Int).Rsh(m, 1)
+	return new(big.Int).Neg(half), half.Sub(half, big.NewInt(1))
+}
+
+// wrap reduces v modulo 2^Bits into the range of t.
+func (t IntType) wrap(v *big.Int) *big.Int {
+	m := new(big.Int).Lsh(big.NewInt(1), t.Bits)
+	r := new(big.Int).Mod(v, m)
+	if t.Signed && r.Bit(int(t.Bits)-1) == 1 {
+		r.Sub(r, m)
+	}
+	return r
+}
+
+// fit applies ov to v. It reports whether v was wrapped or saturated.
+func (t IntType) fit(v *big.Int, ov Overflow) (*big.Int, bool, error) {
+	if t.Bits == 0 {
+		if v.BitLen() > MaxBigBits {
+			return nil, false, ErrIntegerOverflow
+		}
+		return v, false, nil
+	}
+	lo, hi := t.bounds()
+	switch {
+	case v.Cmp(lo) >= 0 && v.Cmp(hi) <= 0:
+		return v, false, nil
+	case ov == Wrap:
+		return t.wrap(v), true, nil
+	case ov == Saturate && v.Sign() < 0:
+		return lo, true, nil
+	case ov == Saturate:
+		return hi, true, nil
+	}
+	return nil, false, ErrIntegerOverflow
+}
+
+// Parse parses a decimal, or 0b, 0o or 0x prefixed, integer of type t. For
+// signed fixed width types, unsigned prefixed literals are read as two's
+// complement bit patterns, so 0xff is -1 as an int8.
+func (t IntType) Parse(s string) (*big.Int, error) {
+	digits, neg := strings.CutPrefix(s, "-")
+	base := 10
+	if len(digits) > 2 && digits[0] == '0' {
+		switch digits[1] {
+		case 'b', 'B':
+			base = 2
+		case 'o', 'O':
+			base = 8
+		case 'x', 'X':
+			base = 16
+		}
+		if base != 10 {
+			digits = digits[2:]
+		}
+	}
+	v, ok := new(big.Int).SetString(digits, base)
+	if !ok || strings.ContainsAny(digits, "+-") {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidInteger, s)
+	}
+	if neg {
+		v.Neg(v)
+	}
+	if t.Bits == 0 {
+		if v.BitLen() > MaxBigBits {
+			return nil, fmt.Errorf("%w: %q", ErrIntegerRange, s)
+		}
+		return v, nil
+	}
+	if t.Signed && base != 10 && !neg && uint(v.BitLen()) == t.Bits {
+		v = t.wrap(v)
+	}
+	if lo, hi := t.bounds(); v.Cmp(lo) < 0 || v.Cmp(hi) > 0 {
+		return nil, fmt.Errorf("%w: %q for %s", ErrIntegerRange, s, t.Name)
+	}
+	return v, nil
+}
+
+// Format formats v in base 2, 8, 10 or 16. Other than decimal, values carry
+// a 0b, 0o or 0x prefix; negative values of fixed width types are shown as
+// two's complement and binary and hex are padded to the full width.
+func (t IntType) Format(v *big.Int, base int) string {
+	if base == 10 {
+		return v.String()
+	}
+	sign := ""
+	u := v
+	if v.Sign() < 0 {
+		if t.Bits == 0 {
+			sign, u = "-", new(big.Int).Neg(v)
+		} else {
+			u = new(big.Int).Add(v, new(big.Int).Lsh(big.NewInt(1), t.Bits))
+		}
+	}
+	digits := u.Text(base)
+	width := 0
+	switch base {
+	case 2:
+		width = int(t.Bits)
+	case 16:
+		width = int(t.Bits / 4)
+	}
+	if len(digits) < width {
+		digits = strings.Repeat("0", width-len(digits)) + digits
+	}
+	return sign + map[int]string{2: "0b", 8: "0o", 16: "0x"}[base] + digits
+}
+
+// IntOperation is an operation of programmer mode.
+type IntOperation struct {
+	Name     string
+	Summary  string
+	Operands []Operand
+	Errors   []*Error
+	// Count is set when the last operand is a bit count, which is not of
+	// the operation type.
+	Count bool
+	Eval  func(t IntType, args []*big.Int) (*big.Int, error)
+}
+
+// OperandType returns the type of operand i when the operation works on t.
+func (op IntOperation) OperandType(i int, t IntType) IntType {
+	if op.Count && i == len(op.Operands)-1 {
+		return BigInt
+	}
+	return t
+}
+
+// IntResult is the result of an IntOperation. Overflowed reports that the
+// result was wrapped or saturated.
+type IntResult struct {
+	Value      *big.Int
+	Overflowed bool
+}
+
+// Apply evaluates op on args of type t, handling overflow with ov.
+func (op IntOperation) Apply(t IntType, ov Overflow, args []*big.Int) (IntResult, error) {
+	if len(args) != len(op.Operands) {
+		return IntResult{}, fmt.Errorf("%w: %s takes %d, got %d",
+			ErrOperandCount, op.Name, len(op.Operands), len(args))
+	}
+	v, err := op.Eval(t, args)
+	if err != nil {
+		return IntResult{}, err
+	}
+	v, overflowed, err := t.fit(v, ov)
+	if err != nil {
+		return IntResult{}, err
+	}
+	return IntResult{Value: v, Overflowed: overflowed}, nil
+}
+
+// shiftCount returns n capped so that shifting by it gives the same result
+// after fit, without building huge intermediate values.
+func (t IntType) shiftCount(v, n *big.Int) (uint, error) {
+	if n.Sign() < 0 {
+		return 0, ErrShiftCount
+	}
+	limit := uint(MaxBigBits + 1)
+	if t.Bits != 0 {
+		limit = t.Bits + 1
+	}
+	limit += uint(v.BitLen())
+	if !n.IsUint64() || n.Uint64() > uint64(limit) {
+		return limit, nil
+	}
+	return uint(n.Uint64()), nil
+}
+
+func (t IntType) rotate(v, n *big.Int, left bool) (*big.Int, error) {
+	if t.Bits == 0 {
+		return nil, ErrRotateUnbounded
+	}
+	if n.Sign() < 0 {
+		return nil, ErrShiftCount
+	}
+	k := uint(new(big.Int).Mod(n, big.NewInt(int64(t.Bits))).Uint64())
+	if !left {
+		k = (t.Bits - k) % t.Bits
+	}
+	u := t.wrapUnsigned(v)
+	r := new(big.Int).Lsh(u, k)
+	r.Or(r, new(big.Int).Rsh(u, t.Bits-k))
+	return t.wrap(r), nil
+}
+
+func (t IntType) wrapUnsigned(v *big.Int) *big.Int {
+	return IntType{Bits: t.Bits}.wrap(v)
+}
+
+// IntOperations returns the operations of programmer mode. div truncates
+// toward zero and mod has the sign of the dividend, as in Go and C.
+func IntOperations() []IntOperation {
+	a := Operand{Name: "a", Description: "First operand"}
+	b := Operand{Name: "b", Description: "Second operand"}
+	n := Operand{Name: "b", Description: "Bit count"}
+	binary := func(fn func(z, x, y *big.Int) *big.Int) func(IntType, []*big.Int) (*big.Int, error) {
+		return func(_ IntType, x []*big.Int) (*big.Int, error) { return fn(new(big.Int), x[0], x[1]), nil }
+	}
+	division := func(fn func(z, x, y *big.Int) *big.Int) func(IntType, []*big.Int) (*big.Int, error) {
+		return func(_ IntType, x []*big.Int) (*big.Int, error) {
+			if x[1].Sign() == 0 {
+				return nil, ErrDivisionByZero
+			}
+			return fn(new(big.Int), x[0], x[1]), nil
+		}
+	}
+	overflow := []*Error{ErrIntegerOverflow}
+	return []IntOperation{
+		{Name: "add", Summary: "Add two integers", Operands: []Operand{a, b},
+			Errors: overflow, Eval: binary((*big.Int).Add)},
+		{Name: "subtract", Summary: "Subtract two integers", Operands: []Operand{a, b},
+			Errors: overflow, Eval: binary((*big.Int).Sub)},
+		{Name: "multiply", Summary: "Multiply two integers", Operands: []Operand{a, b},
+			Errors: overflow, Eval: binary((*big.Int).Mul)},
+		{Name: "div", Summary: "Integer division truncated toward zero",
+			Operands: []Operand{{"a", "Dividend"}, {"b", "Divisor"}},
+			Errors:   []*Error{ErrDivisionByZero, ErrIntegerOverflow}, Eval: division((*big.Int).Quo)},
+		{Name: "mod", Summary: "Remainder of truncated division",
+			Operands: []Operand{{"a", "Dividend"}, {"b", "Divisor"}},
+			Errors:   []*Error{ErrDivisionByZero}, Eval: division((*big.Int).Rem)},
+		{Name: "negate", Summary: "Negate an integer", Operands: []Operand{a},
+			Errors: []*Error{ErrUnsignedNegation, ErrIntegerOverflow},
+			Eval: func(t IntType, x []*big.Int) (*big.Int, error) {
+				if !t.Signed {
+					return nil, ErrUnsignedNegation
+				}
+				return new(big.Int).Neg(x[0]), nil
+			}},
+		{Name: "and", Summary: "Bitwise AND", Operands: []Operand{a, b}, Eval: binary((*big.Int).And)},
+		{Name: "or", Summary: "Bitwise OR", Operands: []Operand{a, b}, Eval: binary((*big.Int).Or)},
+		{Name: "xor", Summary: "Bitwise XOR", Operands: []Operand{a, b}, Eval: binary((*big.Int).Xor)},
+		{Name: "not", Summary: "Bitwise NOT", Operands: []Operand{a},
+			Eval: func(t IntType, x []*big.Int) (*big.Int, error) {
+				if !t.Signed {
+					_, hi := t.bounds()
+					return hi.Xor(hi, x[0]), nil
+				}
+				return new(big.Int).Not(x[0]), nil
+			}},
+		{Name: "shl", Count: true, Summary: "Shift left", Operands: []Operand{a, n},
+			Errors: []*Error{ErrShiftCount, ErrIntegerOverflow},
+			Eval: func(t IntType, x []*big.Int) (*big.Int, error) {
+				k, err := t.shiftCount(x[0], x[1])
+				if err != nil {
+					return nil, err
+				}
+				return new(big.Int).Lsh(x[0], k), nil
+			}},
+		{Name: "shr", Count: true, Summary: "Shift right, arithmetic for signed types", Operands: []Operand{a, n},
+			Errors: []*Error{ErrShiftCount},
+			Eval: func(t IntType, x []*big.Int) (*big.Int, error) {
+				k, err := t.shiftCount(x[0], x[1])
+				if err != nil {
+					return nil, err
+				}
+				return new(big.Int).Rsh(x[0], k), nil
+			}},
+		{Name: "rotl", Count: true, Summary: "Rotate left within the type width", Operands: []Operand{a, n},
+			Errors: []*Error{ErrShiftCount, ErrRotateUnbounded},
+			Eval: func(t IntType, x []*big.Int) (*big.Int, error) {
+				return t.rotate(x[0], x[1], true)
+			}},
+		{Name: "rotr", Count: true, Summary: "Rotate right within the type width", Operands: []Operand{a, n},
+			Errors: []*Error{ErrShiftCount, ErrRotateUnbounded},
+			Eval: func(t IntType, x []*big.Int) (*big.Int, error) {
+				return t.rotate(x[0], x[1], false)
+			}},
+	}
+}
@@ -0,0 +1,144 @@
+package calculator
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func TestIntParse(t *testing.T) {
+	tests := []struct {
+		typ       IntType
+		in        string
+		expected  string
+		expectErr error
+	}{
+		{Int64, "42", "42", nil},
+		{Int64, "-0x10", "-16", nil},
+		{Int64, "0b1010", "10", nil},
+		{Int64, "0o17", "15", nil},
+		{Int64, "9223372036854775807", "9223372036854775807", nil},
+		{Int64, "9223372036854775808", "", ErrIntegerRange},
+		{Int8, "0xff", "-1", nil},
+		{Int8, "0x80", "-128", nil},
+		{Int8, "128", "", ErrIntegerRange},
+		{Int8, "0x100", "", ErrIntegerRange},
+		{Uint8, "0xff", "255", nil},
+		{Uint8, "-1", "", ErrIntegerRange},
+		{Uint64, "18446744073709551615", "18446744073709551615", nil},
+		{BigInt, "0x10000000000000000", "18446744073709551616", nil},
+		{Int64, "1.5", "", ErrInvalidInteger},
+		{Int64, "0x", "", ErrInvalidInteger},
+		{Int64, "--1", "", ErrInvalidInteger},
+		{Int64, "", "", ErrInvalidInteger},
+	}
+	for _, tt := range tests {
+		t.Run(tt.typ.Name+" "+tt.in, func(t *testing.T) {
+			v, err := tt.typ.Parse(tt.in)
+			if !errors.Is(err, tt.expectErr) {
+				t.Fatalf("Parse(%q) error = %v, want %v", tt.in, err, tt.expectErr)
+			}
+			if err == nil && v.String() != tt.expected {
+				t.Errorf("Parse(%q) = %v, want %v", tt.in, v, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIntFormat(t *testing.T) {
+	tests := []struct {
+		typ      IntType
+		v        int64
+		base     int
+		expected string
+	}{
+		{Int8, -1, 16, "0xff"},
+		{Int8, -1, 2, "0b11111111"},
+		{Int8, -1, 8, "0o377"},
+		{Int8, -1, 10, "-1"},
+		{Uint16, 10, 16, "0x000a"},
+		{Uint16, 5, 2, "0b0000000000000101"},
+		{BigInt, -255, 16, "-0xff"},
+	}
+	for _, tt := range tests {
+		if got := tt.typ.Format(big.NewInt(tt.v), tt.base); got != tt.expected {
+			t.Errorf("%s.Format(%d, %d) = %q, want %q", tt.typ.Name, tt.v, tt.base, got, tt.expected)
+		}
+	}
+}
+
+func TestIntOperations(t *testing.T) {
+	ops := make(map[string]IntOperation)
+	for _, op := range IntOperations() {
+		ops[op.Name] = op
+	}
+	tests := []struct {
+		op         string
+		typ        IntType
+		overflow   Overflow
+		args       []string
+		expected   string
+		overflowed bool
+		expectErr  error
+	}{
+		{"add", Int64, OverflowError, []string{"9007199254740993", "2"}, "9007199254740995", false, nil},
+		{"add", Int8, Wrap, []string{"127", "1"}, "-128", true, nil},
+		{"add", Int8, Saturate, []string{"127", "1"}, "127", true, nil},
+		{"add", Int8, OverflowError, []string{"127", "1"}, "", false, ErrIntegerOverflow},
+		{"subtract", Uint8, Wrap, []string{"0", "1"}, "255", true, nil},
+		{"subtract", Uint8, Saturate, []string{"0", "1"}, "0", true, nil},
+		{"multiply", Uint64, Wrap, []string{"0xffffffffffffffff", "2"}, "18446744073709551614", true, nil},
+		{"multiply", BigInt, Wrap, []string{"0xffffffffffffffff", "2"}, "36893488147419103230", false, nil},
+		{"div", Int64, Wrap, []string{"-7", "2"}, "-3", false, nil},
+		{"div", Int8, OverflowError, []string{"-128", "-1"}, "", false, ErrIntegerOverflow},
+		{"div", Int64, Wrap, []string{"1", "0"}, "", false, ErrDivisionByZero},
+		{"mod", Int64, Wrap, []string{"-7", "2"}, "-1", false, nil},
+		{"mod", Int64, Wrap, []string{"7", "0"}, "", false, ErrDivisionByZero},
+		{"negate", Int8, Saturate, []string{"-128"}, "127", true, nil},
+		{"negate", Uint8, Wrap, []string{"1"}, "", false, ErrUnsignedNegation},
+		{"and", Uint8, Wrap, []string{"0xf0", "0x3c"}, "48", false, nil},
+		{"or", Int8, Wrap, []string{"0xf0", "0x0f"}, "-1", false, nil},
+		{"xor", Uint8, Wrap, []string{"0xff", "0x0f"}, "240", false, nil},
+		{"not", Uint8, Wrap, []string{"0x0f"}, "240", false, nil},
+		{"not", Int8, Wrap, []string{"0"}, "-1", false, nil},
+		{"shl", Uint8, Wrap, []string{"0x81", "1"}, "2", true, nil},
+		{"shl", Uint8, OverflowError, []string{"1", "8"}, "", false, ErrIntegerOverflow},
+		{"shl", Uint8, Wrap, []string{"1", "1000000000000"}, "0", true, nil},
+		{"shl", BigInt, Wrap, []string{"1", "100"}, "1267650600228229401496703205376", false, nil},
+		{"shl", BigInt, Wrap, []string{"1", "1000000000000"}, "", false, ErrIntegerOverflow},
+		{"shl", Int64, Wrap, []string{"1", "-1"}, "", false, ErrShiftCount},
+		{"shr", Int8, Wrap, []string{"-128", "3"}, "-16", false, nil},
+		{"shr", Uint8, Wrap, []string{"0x80", "3"}, "16", false, nil},
+		{"shr", Int64, Wrap, []string{"-1", "1000"}, "-1", false, nil},
+		{"rotl", Uint8, Wrap, []string{"0x81", "1"}, "3", false, nil},
+		{"rotr", Uint8, Wrap, []string{"0x81", "1"}, "192", false, nil},
+		{"rotl", Int8, Wrap, []string{"0x40", "1"}, "-128", false, nil},
+		{"rotr", Uint16, Wrap, []string{"1", "17"}, "32768", false, nil},
+		{"rotl", Uint8, Wrap, []string{"7", "0"}, "7", false, nil},
+		{"rotl", BigInt, Wrap, []string{"1", "1"}, "", false, ErrRotateUnbounded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.op+" "+tt.typ.Name, func(t *testing.T) {
+			op := ops[tt.op]
+			args := make([]*big.Int, len(tt.args))
+			for i, s := range tt.args {
+				v, err := op.OperandType(i, tt.typ).Parse(s)
+				if err != nil {
+					t.Fatalf("Parse(%q): %v", s, err)
+				}
+				args[i] = v
+			}
+			r, err := op.Apply(tt.typ, tt.overflow, args)
+			if !errors.Is(err, tt.expectErr) {
+				t.Fatalf("%s%v error = %v, want %v", tt.op, tt.args, err, tt.expectErr)
+			}
+			if err != nil {
+				return
+			}
+			if r.Value.String() != tt.expected || r.Overflowed != tt.overflowed {
+				t.Errorf("%s%v = %v (overflowed %v), want %v (overflowed %v)",
+					tt.op, tt.args, r.Value, r.Overflowed, tt.expected, tt.overflowed)
+			}
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package calculator
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+var (
+	ErrOperandCount     = errors.New("wrong number of operands")
+	ErrInvalidOperation = errors.New("invalid operation")
+)
+
+type Operand struct {
+	Name        string
+	Description string
+}
+
+// Operation describes a calculation exposed by transports and the
+// expression evaluator. Adding an operation to a Registry is all it takes
+// to serve it.
+type Operation struct {
+	// Name identifies the operation, e.g. in routes such as /v1/{name}.
+	Name string
+	// Label and Symbol are meant for display, e.g. "Division" and "/".
+	Label    string
+	Symbol   string
+	Summary  string
+	Operands []Operand
+	// Errors lists the domain errors Eval may return.
+	Errors []*Error
+	// Eval is nil for operations only available in complex mode.
+	Eval func(args []float64) (float64, error)
+	// AngleEval is set by operations whose operands or result are angles.
+	// It evaluates with angles in unit; Eval then uses Radians.
+	AngleEval func(args []float64, unit AngleUnit) (float64, error)
+	// ComplexEval is set by operations available in complex mode, where
+	// angles are always in radians.
+	ComplexEval func(args []complex128) (complex128, error)
+}
+
+// Apply checks the operand count and evaluates the operation.
+func (op Operation) Apply(args []float64) (float64, error) {
+	if len(args) != len(op.Operands) {
+		return 0, fmt.Errorf("%w: %s takes %d, got %d",
+			ErrOperandCount, op.Name, len(op.Operands), len(args))
+	}
+	if op.Eval == nil {
+		return 0, ErrComplexRequired
+	}
+	return op.Eval(args)
+}
+
+// ApplyUnit is like Apply but with angles in unit.
+func (op Operation) ApplyUnit(args []float64, unit AngleUnit) (float64, error) {
+	if op.AngleEval == nil || unit == Radians {
+		return op.Apply(args)
+	}
+	if len(args) != len(op.Operands) {
+		return 0, fmt.Errorf("%w: %s takes %d, got %d",
+			ErrOperandCount, op.Name, len(op.Operands), len(args))
+	}
+	return op.AngleEval(args, unit)
+}
+
+// ApplyComplex is like Apply but in complex mode.
+func (op Operation) ApplyComplex(args []complex128) (complex128, error) {
+	if op.ComplexEval == nil {
+		return 0, fmt.Errorf("%w: %s", ErrComplexUnsupported, op.Name)
+	}
+	if len(args) != len(op.Operands) {
+		return 0, fmt.Errorf("%w: %s takes %d, got %d",
+			ErrOperandCount, op.Name, len(op.Operands), len(args))
+	}
+	z, err := op.ComplexEval(args)
+	if err == nil && !isFinite(z) {
+		return 0, ErrOverflow
+	}
+	return z, err
+}
+
+// Registry is an ordered set of operations. It is not safe for concurrent
+// modification; register operations before serving.
+type Registry struct {
+	ops   []Operation
+	index map[string]int
+}
+
+func NewRegistry() *Registry {
+	return &Registry{index: make(map[string]int)}
+}
+
+// DefaultRegistry returns a registry with the operations backed by calc, the
+// scientific, finance, distribution and complex operations.
+func DefaultRegistry(calc Calculator) *Registry {
+	r := NewRegistry()
+	ops := withComplex(slices.Concat(Operations(calc), ScientificOperations(), FinanceOperations(),
+		DistributionOperations()))
+	for _, op := range append(ops, ComplexOperations()...) {
+		if err := r.Register(op); err != nil {
+			panic(err)
+		}
+	}
+	return r
+}
+
+func (r *Registry) Register(op Operation) error {
+	if op.Name == "" || (op.Eval == nil && op.ComplexEval == nil) {
+		return fmt.Errorf("%w: missing name or implementation", ErrInvalidOperation)
+	}
+	if _, ok := r.index[op.Name]; ok {
+		return fmt.Errorf("%w: %s registered twice", ErrInvalidOperation, op.Name)
+	}
+	r.index[op.Name] = len(r.ops)
+	r.ops = append(r.ops, op)
+	return nil
+}
+
+func (r *Registry) Lookup(name string) (Operation, bool) {
+	i, ok := r.index[name]
+	if !ok {
+		return Operation{}, false
+	}
+	return r.ops[i], true
+}
+
+// Operations returns the operations in registration order.
+func (r *Registry) Operations() []Operation {
+	return r.ops
+}
+
+// Operations returns the basic arithmetic operations backed by calc.
+func Operations(calc Calculator) []Operation {
+	a := Operand{Name: "a", Description: "First operand"}
+	b := Operand{Name: "b", Description: "Second operand"}
+	binary := func(fn func(a, b float64) float64) func([]float64) (float64, error) {
+		return func(x []float64) (float64, error) { return fn(x[0], x[1]), nil }
+	}
+	binaryErr := func(fn func(a, b float64) (float64, error)) func([]float64) (float64, error) {
+		return func(x []float64) (float64, error) { return fn(x[0], x[1]) }
+	}
+	return []Operation{
+		{
+			Name:     "add",
+			Label:    "Addition",
+			Symbol:   "+",
+			Summary:  "Add two numbers",
+			Operands: []Operand{a, b},
+			Eval:     binary(calc.Add),
+		},
+		{
+			Name:     "subtract",
+			Label:    "Subtraction",
+			Symbol:   "-",
+			Summary:  "Subtract two numbers",
+			Operands: []Operand{a, b},
+			Eval:     binary(calc.Subtract),
+		},
+		{
+			Name:     "multiply",
+			Label:    "Multiplication",
+			Symbol:   "*",
+			Summary:  "Multiply two numbers",
+			Operands: []Operand{a, b},
+			Eval:     binary(calc.Multiply),
+		},
+		{
+			Name:     "divide",
+			Label:    "Division",
+			Symbol:   "/",
+			Summary:  "Divide two numbers",
+			Operands: []Operand{{"a", "Dividend"}, {"b", "Divisor"}},
+			Errors:   []*Error{ErrDivisionByZero},
+			Eval:     binaryErr(calc.Divide),
+		},
+		{
+			Name:     "power",
+			Label:    "Power",
+			Symbol:   "^",
+			Summary:  "Power operation",
+			Operands: []Operand{{"a", "Base"}, {"b", "Exponent"}},
+			Errors:   []*Error{ErrComplexResult},
+			Eval:     binaryErr(calc.Power),
+		},
+		{
+			Name:     "sqrt",
+			Label:    "Square root",
+			Symbol:   "√",
+			Summary:  "Square root",
+			Operands: []Operand{{"a", "Radicand"}},
+			Errors:   []*Error{ErrNegativeSqrt},
+			Eval: func(x []float64) (float64, error) {
+				return calc.Sqrt(x[0])
+			},
+		},
+		{
+			Name:     "percentage",
+			Label:    "Percentage",
+			Symbol:   "%",
+			Summary:  "Percentage calculation",
+			Operands: []Operand{{"a", "Percentage"}, {"b", "Whole"}},
+			Errors:   []*Error{ErrNegativePercentage},
+			Eval:     binaryErr(calc.Percentage),
+		},
+	}
+}
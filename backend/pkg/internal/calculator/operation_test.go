@@ -0,0 +1,67 @@
+package calculator
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDefaultRegistry(t *testing.T) {
+	r := DefaultRegistry(New())
+	tests := []struct {
+		name      string
+		args      []float64
+		expected  float64
+		expectErr error
+	}{
+		{"add", []float64{2, 3}, 5, nil},
+		{"subtract", []float64{5, 3}, 2, nil},
+		{"multiply", []float64{4, 3}, 12, nil},
+		{"divide", []float64{10, 4}, 2.5, nil},
+		{"divide", []float64{1, 0}, 0, ErrDivisionByZero},
+		{"power", []float64{2, 3}, 8, nil},
+		{"sqrt", []float64{9}, 3, nil},
+		{"sqrt", []float64{-1}, 0, ErrNegativeSqrt},
+		{"percentage", []float64{10, 200}, 20, nil},
+		{"add", []float64{1}, 0, ErrOperandCount},
+		{"sqrt", []float64{1, 2}, 0, ErrOperandCount},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op, ok := r.Lookup(tt.name)
+			if !ok {
+				t.Fatalf("Lookup(%q) not found", tt.name)
+			}
+			result, err := op.Apply(tt.args)
+			if !errors.Is(err, tt.expectErr) {
+				t.Errorf("Apply(%v) error = %v, want %v", tt.args, err, tt.expectErr)
+			}
+			if result != tt.expected {
+				t.Errorf("Apply(%v) = %v, want %v", tt.args, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	r := NewRegistry()
+	double := Operation{
+		Name:     "double",
+		Operands: []Operand{{Name: "a"}},
+		Eval:     func(x []float64) (float64, error) { return 2 * x[0], nil },
+	}
+	if err := r.Register(double); err != nil {
+		t.Fatalf("Register error = %v", err)
+	}
+	if err := r.Register(double); !errors.Is(err, ErrInvalidOperation) {
+		t.Errorf("Register twice error = %v, want %v", err, ErrInvalidOperation)
+	}
+	if err := r.Register(Operation{Name: "noop"}); !errors.Is(err, ErrInvalidOperation) {
+		t.Errorf("Register without Eval error = %v, want %v", err, ErrInvalidOperation)
+	}
+	if _, ok := r.Lookup("missing"); ok {
+		t.Error("Lookup(missing) found an operation")
+	}
+	if ops := r.Operations(); len(ops) != 1 || ops[0].Name != "double" {
+		t.Errorf("Operations() = %v", ops)
+	}
+}
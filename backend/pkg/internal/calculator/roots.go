@@ -0,0 +1,109 @@
+package calculator
+
+import "math"
+
+var (
+	ErrComplexResult = &Error{"complex_result", "result is a complex number"}
+	ErrRootIndex     = &Error{"invalid_root_index", "root index must not be zero"}
+)
+
+// reciprocalTolerance is how close 1/b must be to an odd integer n for a
+// negative base raised to b to be taken as the real nth root.
+const reciprocalTolerance = 1e-9
+
+// Root returns the real nth root of a. Odd integer roots of negative numbers
+// are real, e.g. Root(-8, 3) is -2; other roots of negative numbers fail with
+// ErrComplexResult.
+func Root(a, n float64) (float64, error) {
+	if n == 0 {
+		return 0, ErrRootIndex
+	}
+	if n < 0 {
+		r, err := Root(a, -n)
+		if err != nil {
+			return 0, err
+		}
+		if r == 0 {
+			return 0, ErrDivisionByZero
+		}
+		return 1 / r, nil
+	}
+	if a < 0 {
+		if !isOddInteger(n) {
+			return 0, ErrComplexResult
+		}
+		r, err := Root(-a, n)
+		return -r, err
+	}
+	switch n {
+	case 1:
+		return a, nil
+	case 2:
+		return math.Sqrt(a), nil
+	case 3:
+		return math.Cbrt(a), nil
+	}
+	r := math.Pow(a, 1/n)
+	// Prefer the exact root, e.g. 2 rather than 2.0000000000000004 for
+	// Root(32, 5), when there is one.
+	if rr := math.Round(r); math.Pow(rr, n) == a {
+		return rr, nil
+	}
+	return r, nil
+}
+
+// RationalPower returns a raised to the exact rational p/q. The fraction is
+// reduced first, so negative bases have real results when q is odd.
+func RationalPower(a float64, p, q int64) (float64, error) {
+	if q == 0 {
+		return 0, ErrDivisionByZero
+	}
+	if q < 0 {
+		p, q = -p, -q
+	}
+	g := gcd(p, q)
+	p, q = p/g, q/g
+	r, err := Root(a, float64(q))
+	if err != nil {
+		return 0, err
+	}
+	if r == 0 && p < 0 {
+		return 0, ErrDivisionByZero
+	}
+	return math.Pow(r, float64(p)), nil
+}
+
+// power is math.Pow with real results for negative bases raised to
+// reciprocals of odd integers, within reciprocalTolerance.
+func power(a, b float64) (float64, error) {
+	if a < 0 && b != math.Trunc(b) {
+		inv := 1 / b
+		n := math.Round(inv)
+		if isOddInteger(n) && math.Abs(inv-n) <= reciprocalTolerance*math.Abs(n) {
+			return Root(a, n)
+		}
+		return 0, ErrComplexResult
+	}
+	result := math.Pow(a, b)
+	if math.IsNaN(result) {
+		return 0, ErrComplexResult
+	}
+	return result, nil
+}
+
+func isOddInteger(n float64) bool {
+	return n == math.Trunc(n) && math.Mod(n, 2) != 0
+}
+
+func gcd(a, b int64) int64 {
+	if a < 0 {
+		a = -a
+	}
+	for b != 0 {
+		a, b = b, a%b
+	}
+	if a == 0 {
+		return 1
+	}
+	return a
+}
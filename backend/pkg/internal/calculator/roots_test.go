@@ -0,0 +1,72 @@
+package calculator
+
+import (
+	"testing"
+)
+
+func TestRoot(t *testing.T) {
+	tests := []struct {
+		name      string
+		a, n      float64
+		expected  float64
+		expectErr error
+	}{
+		{"square root", 16, 2, 4, nil},
+		{"cube root", 27, 3, 3, nil},
+		{"fifth root", 32, 5, 2, nil},
+		{"negative cube root", -8, 3, -2, nil},
+		{"negative fifth root", -243, 5, -3, nil},
+		{"first root", -7, 1, -7, nil},
+		{"inexact root", 2, 4, 1.189207115002721, nil},
+		{"fractional index", 8, 1.5, 4, nil},
+		{"negative index", 8, -3, 0.5, nil},
+		{"zero", 0, 3, 0, nil},
+		{"zero negative index", 0, -2, 0, ErrDivisionByZero},
+		{"negative even root", -16, 4, 0, ErrComplexResult},
+		{"negative fractional index", -8, 1.5, 0, ErrComplexResult},
+		{"zero index", 8, 0, 0, ErrRootIndex},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Root(tt.a, tt.n)
+			if err != tt.expectErr {
+				t.Errorf("Root(%v, %v) error = %v, want %v", tt.a, tt.n, err, tt.expectErr)
+			}
+			if result != tt.expected {
+				t.Errorf("Root(%v, %v) = %v, want %v", tt.a, tt.n, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRationalPower(t *testing.T) {
+	tests := []struct {
+		name      string
+		a         float64
+		p, q      int64
+		expected  float64
+		expectErr error
+	}{
+		{"cube root", -8, 1, 3, -2, nil},
+		{"two thirds", -8, 2, 3, 4, nil},
+		{"negative numerator", -8, -1, 3, -0.5, nil},
+		{"negative denominator", -8, 1, -3, -0.5, nil},
+		{"reduced fraction", -8, 2, 6, -2, nil},
+		{"integer exponent", -2, 3, 1, -8, nil},
+		{"positive base", 8, 2, 3, 4, nil},
+		{"even denominator", -4, 1, 2, 0, ErrComplexResult},
+		{"zero denominator", 2, 1, 0, 0, ErrDivisionByZero},
+		{"zero base negative exponent", 0, -1, 3, 0, ErrDivisionByZero},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := RationalPower(tt.a, tt.p, tt.q)
+			if err != tt.expectErr {
+				t.Errorf("RationalPower(%v, %v/%v) error = %v, want %v", tt.a, tt.p, tt.q, err, tt.expectErr)
+			}
+			if result != tt.expected {
+				t.Errorf("RationalPower(%v, %v/%v) = %v, want %v", tt.a, tt.p, tt.q, result, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package calculator
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+type RoundingMode string
+
+const (
+	HalfUp   RoundingMode = "half_up"
+	HalfEven RoundingMode = "half_even"
+	Down     RoundingMode = "down"
+	Up       RoundingMode = "up"
+	Ceiling  RoundingMode = "ceiling"
+	Floor    RoundingMode = "floor"
+)
+
+var RoundingModes = []RoundingMode{HalfUp, HalfEven, Down, Up, Ceiling, Floor}
+
+// Scale tells what the digits of a Rounding count.
+type Scale string
+
+const (
+	Decimals    Scale = "decimals"
+	Significant Scale = "significant"
+)
+
+// MaxRoundingDigits bounds the digits of a Rounding.
+const MaxRoundingDigits = 100
+
+var (
+	ErrInvalidRoundingMode = &Error{"invalid_rounding_mode", "invalid rounding mode"}
+	ErrInvalidRounding     = &Error{"invalid_rounding", "invalid rounding precision"}
+)
+
+// ParseRoundingMode parses s, defaulting to HalfEven when s is empty.
+func ParseRoundingMode(s string) (RoundingMode, error) {
+	if s == "" {
+		return HalfEven, nil
+	}
+	for _, m := range RoundingModes {
+		if string(m) == s {
+			return m, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %q", ErrInvalidRoundingMode, s)
+}
+
+// Rounding rounds results to Digits decimal places or significant digits.
+// The zero value does not round.
+type Rounding struct {
+	Mode   RoundingMode
+	Scale  Scale
+	Digits int
+}
+
+func (r Rounding) Validate() error {
+	switch {
+	case r.Scale == "":
+		return nil
+	case r.Scale == Decimals && r.Digits >= 0 && r.Digits <= MaxRoundingDigits:
+	case r.Scale == Significant && r.Digits >= 1 && r.Digits <= MaxRoundingDigits:
+	default:
+		return fmt.Errorf("%w: %d %s", ErrInvalidRounding, r.Digits, r.Scale)
+	}
+	_, err := ParseRoundingMode(string(r.Mode))
+	return err
+}
+
+// Round rounds x. It works on the shortest decimal representation of x, so
+// that 2.675 rounds half up to 2.68 even though its binary value is
+// slightly below. Infinities and NaN are returned unchanged. r must be
+// valid.
+func (r Rounding) Round(x float64) float64 {
+	if r.Scale == "" || x == 0 || math.IsInf(x, 0) || math.IsNaN(x) {
+		return x
+	}
+	// x is 0.d1d2...dn × 10^(exp+1).
+	m, e, _ := strings.Cut(strconv.FormatFloat(x, 'e', -1, 64), "e")
+	exp, _ := strconv.Atoi(e)
+	neg := strings.HasPrefix(m, "-")
+	digits := strings.Replace(strings.TrimPrefix(m, "-"), ".", "", 1)
+	places := r.Digits
+	if r.Scale == Significant {
+		places = r.Digits - exp - 1
+	}
+	// x × 10^places is digits × 10^shift.
+	shift := exp + 1 - len(digits) + places
+	if shift >= 0 {
+		return x
+	}
+	n, _ := new(big.Int).SetString(digits, 10)
+	if neg {
+		n.Neg(n)
+	}
+	div := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-shift)), nil)
+	q := r.Mode.RoundRat(new(big.Rat).SetFrac(n, div))
+	if q.Sign() == 0 {
+		return 0
+	}
+	v, _ := strconv.ParseFloat(q.String()+"e"+strconv.Itoa(-places), 64)
+	return v
+}
+
+// RoundRat rounds x to an integer.
+func (m RoundingMode) RoundRat(x *big.Rat) *big.Int {
+	neg := x.Sign() < 0
+	div := x.Denom()
+	q, rem := new(big.Int).QuoRem(new(big.Int).Abs(x.Num()), div, new(big.Int))
+	if m.roundsAway(q, rem, div, neg) {
+		q.Add(q, big.NewInt(1))
+	}
+	if neg {
+		q.Neg(q)
+	}
+	return q
+}
+
+// roundsAway reports whether the magnitude q, with remainder rem of div,
+// must be incremented.
+func (m RoundingMode) roundsAway(q, rem, div *big.Int, neg bool) bool {
+	if rem.Sign() == 0 {
+		return false
+	}
+	half := new(big.Int).Lsh(rem, 1).Cmp(div)
+	switch m {
+	case Down:
+		return false
+	case Up:
+		return true
+	case Ceiling:
+		return !neg
+	case Floor:
+		return neg
+	case HalfUp:
+		return half >= 0
+	}
+	return half > 0 || (half == 0 && q.Bit(0) == 1)
+}
@@ -0,0 +1,74 @@
+package calculator
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestRound(t *testing.T) {
+	tests := []struct {
+		x        float64
+		r        Rounding
+		expected float64
+	}{
+		{33.333333333333336, Rounding{HalfEven, Decimals, 2}, 33.33},
+		{2.675, Rounding{HalfUp, Decimals, 2}, 2.68},
+		{2.665, Rounding{HalfEven, Decimals, 2}, 2.66},
+		{2.675, Rounding{HalfEven, Decimals, 2}, 2.68},
+		{-2.5, Rounding{HalfUp, Decimals, 0}, -3},
+		{-2.5, Rounding{HalfEven, Decimals, 0}, -2},
+		{2.5, Rounding{HalfEven, Decimals, 0}, 2},
+		{3.5, Rounding{HalfEven, Decimals, 0}, 4},
+		{1.19, Rounding{Down, Decimals, 1}, 1.1},
+		{-1.19, Rounding{Down, Decimals, 1}, -1.1},
+		{1.11, Rounding{Up, Decimals, 1}, 1.2},
+		{-1.11, Rounding{Up, Decimals, 1}, -1.2},
+		{1.11, Rounding{Ceiling, Decimals, 1}, 1.2},
+		{-1.19, Rounding{Ceiling, Decimals, 1}, -1.1},
+		{1.19, Rounding{Floor, Decimals, 1}, 1.1},
+		{-1.11, Rounding{Floor, Decimals, 1}, -1.2},
+		{0.004, Rounding{HalfUp, Decimals, 2}, 0},
+		{-0.004, Rounding{HalfUp, Decimals, 2}, 0},
+		{0.004, Rounding{Up, Decimals, 2}, 0.01},
+		{9.995, Rounding{HalfUp, Decimals, 2}, 10},
+		{1.5, Rounding{HalfUp, Decimals, 3}, 1.5},
+		{1234567, Rounding{HalfUp, Significant, 3}, 1230000},
+		{0.00012345, Rounding{HalfEven, Significant, 3}, 0.000123},
+		{99.96, Rounding{HalfUp, Significant, 3}, 100},
+		{1e300, Rounding{HalfUp, Decimals, 2}, 1e300},
+		{1e-300, Rounding{Ceiling, Decimals, 2}, 0.01},
+		{1.23456, Rounding{}, 1.23456},
+		{math.Inf(1), Rounding{HalfEven, Decimals, 2}, math.Inf(1)},
+		{math.Inf(-1), Rounding{HalfUp, Significant, 3}, math.Inf(-1)},
+	}
+	for _, tt := range tests {
+		if got := tt.r.Round(tt.x); got != tt.expected {
+			t.Errorf("%+v.Round(%v) = %v, want %v", tt.r, tt.x, got, tt.expected)
+		}
+	}
+}
+
+func TestRoundingValidate(t *testing.T) {
+	valid := []Rounding{{}, {HalfUp, Decimals, 0}, {Floor, Significant, 17}}
+	for _, r := range valid {
+		if err := r.Validate(); err != nil {
+			t.Errorf("%+v.Validate() = %v", r, err)
+		}
+	}
+	invalid := []struct {
+		r   Rounding
+		err error
+	}{
+		{Rounding{HalfUp, Decimals, -1}, ErrInvalidRounding},
+		{Rounding{HalfUp, Significant, 0}, ErrInvalidRounding},
+		{Rounding{HalfUp, Decimals, MaxRoundingDigits + 1}, ErrInvalidRounding},
+		{Rounding{"nearest", Decimals, 2}, ErrInvalidRoundingMode},
+		{Rounding{HalfUp, "bits", 2}, ErrInvalidRounding},
+	}
+	for _, tt := range invalid {
+		if err := tt.r.Validate(); !errors.Is(err, tt.err) {
+			t.Errorf("%+v.Validate() = %v, want %v", tt.r, err, tt.err)
+		}
+	}
+}
@@ -0,0 +1,359 @@
+package calculator
+
+import (
+	"fmt"
+	"math"
+)
+
+type AngleUnit string
+
+const (
+	Radians  AngleUnit = "radians"
+	Degrees  AngleUnit = "degrees"
+	Gradians AngleUnit = "gradians"
+)
+
+var AngleUnits = []AngleUnit{Radians, Degrees, Gradians}
+
+var (
+	ErrInvalidAngleUnit = &Error{"invalid_angle_unit", "invalid angle unit"}
+	ErrLogDomain        = &Error{"log_domain", "logarithm of non-positive number"}
+	ErrLogBase          = &Error{"invalid_log_base", "logarithm base must be positive and not 1"}
+	ErrTrigDomain       = &Error{"trig_domain", "argument outside [-1, 1]"}
+	ErrTanUndefined     = &Error{"tan_undefined", "tangent undefined at odd multiples of a right angle"}
+	ErrHyperbolicDomain = &Error{"hyperbolic_domain", "argument outside the function domain"}
+	ErrFactorialDomain  = &Error{"factorial_domain", "factorial of negative or non-integer number"}
+	ErrGammaPole        = &Error{"gamma_pole", "gamma undefined at zero and negative integers"}
+	ErrOverflow         = &Error{"overflow", "result overflows"}
+)
+
+// ParseAngleUnit parses s, defaulting to Radians when s is empty.
+func ParseAngleUnit(s string) (AngleUnit, error) {
+	if s == "" {
+		return Radians, nil
+	}
+	for _, u := range AngleUnits {
+		if string(u) == s {
+			return u, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %q", ErrInvalidAngleUnit, s)
+}
+
+// toDegrees converts x to degrees. Gradians use x*9/10 rather than x*0.9 so
+// that multiples of 100 map to exact right angles.
+func (u AngleUnit) toDegrees(x float64) float64 {
+	switch u {
+	case Gradians:
+		return x * 9 / 10
+	case Radians:
+		return x * 180 / math.Pi
+	}
+	return x
+}
+
+func (u AngleUnit) fromDegrees(x float64) float64 {
+	switch u {
+	case Gradians:
+		return x * 10 / 9
+	case Radians:
+		return x * math.Pi / 180
+	}
+	return x
+}
+
+func (u AngleUnit) toRadians(x float64) float64 {
+	if u == Radians {
+		return x
+	}
+	return u.toDegrees(x) * math.Pi / 180
+}
+
+func (u AngleUnit) fromRadians(x float64) float64 {
+	if u == Radians {
+		return x
+	}
+	return u.fromDegrees(x * 180 / math.Pi)
+}
+
+// sinTable holds sin(k*30°). Trigonometric functions use it in degrees and
+// gradians so that e.g. sin(180°) is exactly 0 and sin(30°) exactly 0.5.
+var sinTable = [12]float64{
+	0, 0.5, math.Sqrt(3) / 2, 1, math.Sqrt(3) / 2, 0.5,
+	0, -0.5, -math.Sqrt(3) / 2, -1, -math.Sqrt(3) / 2, -0.5,
+}
+
+// exactDegrees reduces an angle given in a non-radian unit to [0, 360)
+// degrees and reports whether it is a whole multiple of step degrees.
+func exactDegrees(x float64, unit AngleUnit, step float64) (float64, int, bool) {
+	if unit == Radians || math.IsInf(x, 0) {
+		return 0, 0, false
+	}
+	d := math.Mod(unit.toDegrees(x), 360)
+	if d < 0 {
+		d += 360
+	}
+	if d >= 360 {
+		// Tiny negative angles round up to 360.
+		d = 0
+	}
+	k := d / step
+	return d, int(k), k == math.Trunc(k)
+}
+
+func Sin(x float64, unit AngleUnit) float64 {
+	if _, k, ok := exactDegrees(x, unit, 30); ok {
+		return sinTable[k]
+	}
+	return math.Sin(unit.toRadians(x))
+}
+
+func Cos(x float64, unit AngleUnit) float64 {
+	if _, k, ok := exactDegrees(x, unit, 30); ok {
+		return sinTable[(k+3)%12]
+	}
+	return math.Cos(unit.toRadians(x))
+}
+
+func Tan(x float64, unit AngleUnit) (float64, error) {
+	if _, k, ok := exactDegrees(x, unit, 45); ok {
+		switch k % 4 {
+		case 0:
+			return 0, nil
+		case 1:
+			return 1, nil
+		case 3:
+			return -1, nil
+		}
+		return 0, ErrTanUndefined
+	}
+	return math.Tan(unit.toRadians(x)), nil
+}
+
+// inverseTrig evaluates fn in radians, returning exact angles for the
+// arguments listed in exact (argument to degrees) when unit is not radians.
+func inverseTrig(x float64, unit AngleUnit, fn func(float64) float64, exact map[float64]float64) float64 {
+	if d, ok := exact[x]; ok && unit != Radians {
+		return unit.fromDegrees(d)
+	}
+	return unit.fromRadians(fn(x))
+}
+
+func Asin(x float64, unit AngleUnit) (float64, error) {
+	if x < -1 || x > 1 {
+		return 0, ErrTrigDomain
+	}
+	return inverseTrig(x, unit, math.Asin,
+		map[float64]float64{-1: -90, -0.5: -30, 0: 0, 0.5: 30, 1: 90}), nil
+}
+
+func Acos(x float64, unit AngleUnit) (float64, error) {
+	if x < -1 || x > 1 {
+		return 0, ErrTrigDomain
+	}
+	return inverseTrig(x, unit, math.Acos,
+		map[float64]float64{-1: 180, -0.5: 120, 0: 90, 0.5: 60, 1: 0}), nil
+}
+
+func Atan(x float64, unit AngleUnit) float64 {
+	return inverseTrig(x, unit, math.Atan, map[float64]float64{-1: -45, 0: 0, 1: 45})
+}
+
+func finite(x float64) (float64, error) {
+	if math.IsInf(x, 0) || math.IsNaN(x) {
+		return 0, ErrOverflow
+	}
+	return x, nil
+}
+
+func Sinh(x float64) (float64, error) {
+	return finite(math.Sinh(x))
+}
+
+func Cosh(x float64) (float64, error) {
+	return finite(math.Cosh(x))
+}
+
+func Acosh(x float64) (float64, error) {
+	if x < 1 {
+		return 0, ErrHyperbolicDomain
+	}
+	return math.Acosh(x), nil
+}
+
+func Atanh(x float64) (float64, error) {
+	if x <= -1 || x >= 1 {
+		return 0, ErrHyperbolicDomain
+	}
+	return math.Atanh(x), nil
+}
+
+func Ln(x float64) (float64, error) {
+	if x <= 0 {
+		return 0, ErrLogDomain
+	}
+	return math.Log(x), nil
+}
+
+func Log10(x float64) (float64, error) {
+	if x <= 0 {
+		return 0, ErrLogDomain
+	}
+	return math.Log10(x), nil
+}
+
+// Log returns the logarithm of x in base b. Bases 2 and 10 use dedicated
+// functions so that e.g. log(1000, 10) is exactly 3.
+func Log(x, b float64) (float64, error) {
+	if b <= 0 || b == 1 {
+		return 0, ErrLogBase
+	}
+	if x <= 0 {
+		return 0, ErrLogDomain
+	}
+	switch b {
+	case 2:
+		return math.Log2(x), nil
+	case 10:
+		return math.Log10(x), nil
+	}
+	return math.Log(x) / math.Log(b), nil
+}
+
+func Exp(x float64) (float64, error) {
+	return finite(math.Exp(x))
+}
+
+// Factorial returns n! for integer n in [0, 170]; larger values overflow.
+func Factorial(n float64) (float64, error) {
+	if n < 0 || n != math.Trunc(n) {
+		return 0, ErrFactorialDomain
+	}
+	if n > 170 {
+		return 0, ErrOverflow
+	}
+	result := 1.0
+	for i := 2.0; i <= n; i++ {
+		result *= i
+	}
+	return result, nil
+}
+
+func Gamma(x float64) (float64, error) {
+	if x <= 0 && x == math.Trunc(x) {
+		return 0, ErrGammaPole
+	}
+	return finite(math.Gamma(x))
+}
+
+// ScientificOperations returns trigonometric, hyperbolic, logarithmic and
+// exponential operations, plus nth root, factorial and gamma.
+func ScientificOperations() []Operation {
+	angle := []Operand{{"a", "Angle"}}
+	ratio := []Operand{{"a", "Ratio in [-1, 1]"}}
+	value := []Operand{{"a", "Value"}}
+	unary := func(fn func(float64) (float64, error)) func([]float64) (float64, error) {
+		return func(x []float64) (float64, error) { return fn(x[0]) }
+	}
+	total := func(fn func(float64) float64) func([]float64) (float64, error) {
+		return func(x []float64) (float64, error) { return fn(x[0]), nil }
+	}
+	angleOp := func(name, label, summary string, operands []Operand, errs []*Error,
+		fn func(x float64, unit AngleUnit) (float64, error)) Operation {
+		eval := func(x []float64, unit AngleUnit) (float64, error) { return fn(x[0], unit) }
+		return Operation{
+			Name:      name,
+			Label:     label,
+			Symbol:    name,
+			Summary:   summary,
+			Operands:  operands,
+			Errors:    errs,
+			Eval:      func(x []float64) (float64, error) { return eval(x, Radians) },
+			AngleEval: eval,
+		}
+	}
+	noErr := func(fn func(float64, AngleUnit) float64) func(float64, AngleUnit) (float64, error) {
+		return func(x float64, unit AngleUnit) (float64, error) { return fn(x, unit), nil }
+	}
+	return []Operation{
+		angleOp("sin", "Sine", "Sine of an angle", angle, nil, noErr(Sin)),
+		angleOp("cos", "Cosine", "Cosine of an angle", angle, nil, noErr(Cos)),
+		angleOp("tan", "Tangent", "Tangent of an angle", angle, []*Error{ErrTanUndefined}, Tan),
+		angleOp("asin", "Arcsine", "Angle whose sine is a", ratio, []*Error{ErrTrigDomain}, Asin),
+		angleOp("acos", "Arccosine", "Angle whose cosine is a", ratio, []*Error{ErrTrigDomain}, Acos),
+		angleOp("atan", "Arctangent", "Angle whose tangent is a", value, nil, noErr(Atan)),
+		{
+			Name: "sinh", Label: "Hyperbolic sine", Symbol: "sinh",
+			Summary: "Hyperbolic sine", Operands: value,
+			Errors: []*Error{ErrOverflow}, Eval: unary(Sinh),
+		},
+		{
+			Name: "cosh", Label: "Hyperbolic cosine", Symbol: "cosh",
+			Summary: "Hyperbolic cosine", Operands: value,
+			Errors: []*Error{ErrOverflow}, Eval: unary(Cosh),
+		},
+		{
+			Name: "tanh", Label: "Hyperbolic tangent", Symbol: "tanh",
+			Summary: "Hyperbolic tangent", Operands: value,
+			Eval: total(math.Tanh),
+		},
+		{
+			Name: "asinh", Label: "Inverse hyperbolic sine", Symbol: "asinh",
+			Summary: "Inverse hyperbolic sine", Operands: value,
+			Eval: total(math.Asinh),
+		},
+		{
+			Name: "acosh", Label: "Inverse hyperbolic cosine", Symbol: "acosh",
+			Summary: "Inverse hyperbolic cosine", Operands: []Operand{{"a", "Value not less than 1"}},
+			Errors: []*Error{ErrHyperbolicDomain}, Eval: unary(Acosh),
+		},
+		{
+			Name: "atanh", Label: "Inverse hyperbolic tangent", Symbol: "atanh",
+			Summary: "Inverse hyperbolic tangent", Operands: []Operand{{"a", "Value in (-1, 1)"}},
+			Errors: []*Error{ErrHyperbolicDomain}, Eval: unary(Atanh),
+		},
+		{
+			Name: "ln", Label: "Natural logarithm", Symbol: "ln",
+			Summary: "Natural logarithm", Operands: []Operand{{"a", "Positive value"}},
+			Errors: []*Error{ErrLogDomain}, Eval: unary(Ln),
+		},
+		{
+			Name: "log10", Label: "Common logarithm", Symbol: "log",
+			Summary: "Base 10 logarithm", Operands: []Operand{{"a", "Positive value"}},
+			Errors: []*Error{ErrLogDomain}, Eval: unary(Log10),
+		},
+		{
+			Name: "log", Label: "Logarithm", Symbol: "logₙ",
+			Summary:  "Logarithm in an arbitrary base",
+			Operands: []Operand{{"a", "Positive value"}, {"b", "Base"}},
+			Errors:   []*Error{ErrLogDomain, ErrLogBase},
+			Eval: func(x []float64) (float64, error) {
+				return Log(x[0], x[1])
+			},
+		},
+		{
+			Name: "root", Label: "Nth root", Symbol: "ⁿ√",
+			Summary:  "Real nth root, also of negative numbers for odd n",
+			Operands: []Operand{{"a", "Radicand"}, {"b", "Root index"}},
+			Errors:   []*Error{ErrComplexResult, ErrRootIndex, ErrDivisionByZero},
+			Eval: func(x []float64) (float64, error) {
+				return Root(x[0], x[1])
+			},
+		},
+		{
+			Name: "exp", Label: "Exponential", Symbol: "eˣ",
+			Summary: "Euler's number raised to a", Operands: []Operand{{"a", "Exponent"}},
+			Errors: []*Error{ErrOverflow}, Eval: unary(Exp),
+		},
+		{
+			Name: "factorial", Label: "Factorial", Symbol: "n!",
+			Summary: "Factorial of a non-negative integer", Operands: []Operand{{"a", "Integer in [0, 170]"}},
+			Errors: []*Error{ErrFactorialDomain, ErrOverflow}, Eval: unary(Factorial),
+		},
+		{
+			Name: "gamma", Label: "Gamma function", Symbol: "Γ",
+			Summary: "Gamma function", Operands: value,
+			Errors: []*Error{ErrGammaPole, ErrOverflow}, Eval: unary(Gamma),
+		},
+	}
+}
@@ -0,0 +1,138 @@
+package calculator
+
+import (
+	"math"
+	"testing"
+)
+
+// tolerance is the documented relative precision of results that are not
+// exact by construction.
+const tolerance = 1e-12
+
+func approxEqual(a, b float64) bool {
+	if a == b {
+		return true
+	}
+	return math.Abs(a-b) <= tolerance*math.Max(math.Abs(a), math.Abs(b))
+}
+
+func TestScientificOperations(t *testing.T) {
+	r := DefaultRegistry(New())
+	tests := []struct {
+		name      string
+		op        string
+		args      []float64
+		unit      AngleUnit
+		expected  float64
+		exact     bool
+		expectErr error
+	}{
+		{"sin radians", "sin", []float64{math.Pi / 6}, Radians, 0.5, false, nil},
+		{"sin 30 degrees", "sin", []float64{30}, Degrees, 0.5, true, nil},
+		{"sin 180 degrees", "sin", []float64{180}, Degrees, 0, true, nil},
+		{"sin -90 degrees", "sin", []float64{-90}, Degrees, -1, true, nil},
+		{"sin 720 degrees", "sin", []float64{720}, Degrees, 0, true, nil},
+		{"sin 200 gradians", "sin", []float64{200}, Gradians, 0, true, nil},
+		{"sin tiny negative degrees", "sin", []float64{-1e-20}, Degrees, 0, true, nil},
+		{"sin tiny negative gradians", "sin", []float64{-1e-20}, Gradians, 0, true, nil},
+		{"sin 45 degrees", "sin", []float64{45}, Degrees, math.Sqrt2 / 2, false, nil},
+		{"cos 60 degrees", "cos", []float64{60}, Degrees, 0.5, true, nil},
+		{"cos 90 degrees", "cos", []float64{90}, Degrees, 0, true, nil},
+		{"cos 100 gradians", "cos", []float64{100}, Gradians, 0, true, nil},
+		{"cos tiny negative degrees", "cos", []float64{-1e-20}, Degrees, 1, true, nil},
+		{"cos radians", "cos", []float64{math.Pi}, Radians, -1, false, nil},
+		{"tan 45 degrees", "tan", []float64{45}, Degrees, 1, true, nil},
+		{"tan 135 degrees", "tan", []float64{135}, Degrees, -1, true, nil},
+		{"tan 180 degrees", "tan", []float64{180}, Degrees, 0, true, nil},
+		{"tan 90 degrees", "tan", []float64{90}, Degrees, 0, true, ErrTanUndefined},
+		{"tan -270 degrees", "tan", []float64{-270}, Degrees, 0, true, ErrTanUndefined},
+		{"tan 300 gradians", "tan", []float64{300}, Gradians, 0, true, ErrTanUndefined},
+		{"tan tiny negative gradians", "tan", []float64{-1e-20}, Gradians, 0, true, nil},
+		{"tan 60 degrees", "tan", []float64{60}, Degrees, math.Sqrt(3), false, nil},
+		{"asin degrees", "asin", []float64{0.5}, Degrees, 30, true, nil},
+		{"asin gradians", "asin", []float64{1}, Gradians, 100, true, nil},
+		{"asin radians", "asin", []float64{1}, Radians, math.Pi / 2, true, nil},
+		{"asin above 1", "asin", []float64{1.0000001}, Degrees, 0, true, ErrTrigDomain},
+		{"asin below -1", "asin", []float64{-2}, Radians, 0, true, ErrTrigDomain},
+		{"acos degrees", "acos", []float64{-0.5}, Degrees, 120, true, nil},
+		{"acos outside", "acos", []float64{2}, Radians, 0, true, ErrTrigDomain},
+		{"atan degrees", "atan", []float64{1}, Degrees, 45, true, nil},
+		{"atan radians", "atan", []float64{math.Inf(1)}, Radians, math.Pi / 2, true, nil},
+		{"atan non-special", "atan", []float64{2}, Degrees, 63.43494882292201, false, nil},
+		{"sinh", "sinh", []float64{1}, Radians, 1.1752011936438014, false, nil},
+		{"sinh overflow", "sinh", []float64{1000}, Radians, 0, true, ErrOverflow},
+		{"cosh", "cosh", []float64{0}, Radians, 1, true, nil},
+		{"cosh overflow", "cosh", []float64{-1000}, Radians, 0, true, ErrOverflow},
+		{"tanh", "tanh", []float64{1000}, Radians, 1, true, nil},
+		{"asinh", "asinh", []float64{0}, Radians, 0, true, nil},
+		{"acosh", "acosh", []float64{1}, Radians, 0, true, nil},
+		{"acosh below 1", "acosh", []float64{0.5}, Radians, 0, true, ErrHyperbolicDomain},
+		{"atanh", "atanh", []float64{0.5}, Radians, 0.5493061443340549, false, nil},
+		{"atanh at 1", "atanh", []float64{1}, Radians, 0, true, ErrHyperbolicDomain},
+		{"ln", "ln", []float64{math.E}, Radians, 1, true, nil},
+		{"ln zero", "ln", []float64{0}, Radians, 0, true, ErrLogDomain},
+		{"ln negative", "ln", []float64{-1}, Radians, 0, true, ErrLogDomain},
+		{"log10", "log10", []float64{1000}, Radians, 3, true, nil},
+		{"log10 negative", "log10", []float64{-10}, Radians, 0, true, ErrLogDomain},
+		{"log base 10", "log", []float64{1000, 10}, Radians, 3, true, nil},
+		{"log base 2", "log", []float64{1024, 2}, Radians, 10, true, nil},
+		{"log base 3", "log", []float64{81, 3}, Radians, 4, false, nil},
+		{"log fractional base", "log", []float64{8, 0.5}, Radians, -3, false, nil},
+		{"log base 1", "log", []float64{5, 1}, Radians, 0, true, ErrLogBase},
+		{"log base 0", "log", []float64{5, 0}, Radians, 0, true, ErrLogBase},
+		{"log of zero", "log", []float64{0, 2}, Radians, 0, true, ErrLogDomain},
+		{"exp", "exp", []float64{1}, Radians, math.E, true, nil},
+		{"exp underflow", "exp", []float64{-1000}, Radians, 0, true, nil},
+		{"exp overflow", "exp", []float64{710}, Radians, 0, true, ErrOverflow},
+		{"factorial 0", "factorial", []float64{0}, Radians, 1, true, nil},
+		{"factorial 20", "factorial", []float64{20}, Radians, 2432902008176640000, true, nil},
+		{"factorial 170", "factorial", []float64{170}, Radians, 7.257415615307994e306, false, nil},
+		{"factorial 171", "factorial", []float64{171}, Radians, 0, true, ErrOverflow},
+		{"factorial negative", "factorial", []float64{-1}, Radians, 0, true, ErrFactorialDomain},
+		{"factorial fraction", "factorial", []float64{2.5}, Radians, 0, true, ErrFactorialDomain},
+		{"gamma integer", "gamma", []float64{5}, Radians, 24, true, nil},
+		{"gamma half", "gamma", []float64{0.5}, Radians, math.Sqrt(math.Pi), false, nil},
+		{"gamma negative fraction", "gamma", []float64{-0.5}, Radians, -2 * math.Sqrt(math.Pi), false, nil},
+		{"gamma zero", "gamma", []float64{0}, Radians, 0, true, ErrGammaPole},
+		{"gamma negative integer", "gamma", []float64{-3}, Radians, 0, true, ErrGammaPole},
+		{"gamma overflow", "gamma", []float64{172}, Radians, 0, true, ErrOverflow},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op, ok := r.Lookup(tt.op)
+			if !ok {
+				t.Fatalf("Lookup(%q) not found", tt.op)
+			}
+			result, err := op.ApplyUnit(tt.args, tt.unit)
+			if err != tt.expectErr {
+				t.Errorf("%s(%v) error = %v, want %v", tt.op, tt.args, err, tt.expectErr)
+			}
+			if tt.exact && result != tt.expected {
+				t.Errorf("%s(%v) = %v, want exactly %v", tt.op, tt.args, result, tt.expected)
+			}
+			if !tt.exact && !approxEqual(result, tt.expected) {
+				t.Errorf("%s(%v) = %v, want %v within %v", tt.op, tt.args, result, tt.expected, tolerance)
+			}
+		})
+	}
+}
+
+func TestParseAngleUnit(t *testing.T) {
+	tests := []struct {
+		in        string
+		expected  AngleUnit
+		expectErr bool
+	}{
+		{"", Radians, false},
+		{"radians", Radians, false},
+		{"degrees", Degrees, false},
+		{"gradians", Gradians, false},
+		{"turns", "", true},
+	}
+	for _, tt := range tests {
+		unit, err := ParseAngleUnit(tt.in)
+		if unit != tt.expected || (err != nil) != tt.expectErr {
+			t.Errorf("ParseAngleUnit(%q) = %v, %v", tt.in, unit, err)
+		}
+	}
+}
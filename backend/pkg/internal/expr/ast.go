@@ -0,0 +1,37 @@
+package expr
+
+// Node is an element of a parsed expression.
+type Node interface {
+	node()
+}
+
+type Num struct {
+	Value float64
+}
+
+type Var struct {
+	Name string
+}
+
+// Unary is a prefix operation. Op is '-' or '+'.
+type Unary struct {
+	Op byte
+	X  Node
+}
+
+// Binary is an infix operation. Op is one of '+', '-', '*', '/' or '^'.
+type Binary struct {
+	Op   byte
+	X, Y Node
+}
+
+type Call struct {
+	Name string
+	Args []Node
+}
+
+func (*Num) node()    {}
+func (*Var) node()    {}
+func (*Unary) node()  {}
+func (*Binary) node() {}
+func (*Call) node()   {}
@@ -0,0 +1,133 @@
+package expr
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+)
+
+// MaxCallDepth bounds how deeply user-defined functions may call each other.
+const MaxCallDepth = 8
+
+var (
+	ErrInvalidFunction = errors.New("invalid function definition")
+	ErrRecursion       = errors.New("recursive function definition")
+	ErrCallDepth       = errors.New("function call depth exceeded")
+)
+
+// Definition is a user-defined function such as fee(x) = max(0.3, x*0.029).
+type Definition struct {
+	Name   string   `json:"name"`
+	Params []string `json:"params"`
+	Body   string   `json:"body"`
+}
+
+// With returns a copy of e extended with the user-defined functions defs.
+// Definitions may call builtins and each other but not recursively, call
+// chains are limited to MaxCallDepth and a call may run at most MaxCost
+// instructions.
+func (e *Env) With(defs []Definition) (*Env, error) {
+	if len(defs) == 0 {
+		return e, nil
+	}
+	l := &linker{
+		env: &Env{
+			ops:      e.ops,
+			funcs:    maps.Clone(e.funcs),
+			consts:   e.consts,
+			angleOps: e.angleOps,
+		},
+		defs:  make(map[string]Definition, len(defs)),
+		depth: make(map[string]int, len(defs)),
+	}
+	for _, d := range defs {
+		if !IsIdent(d.Name) {
+			return nil, fmt.Errorf("%w: invalid name %q", ErrInvalidFunction, d.Name)
+		}
+		if _, ok := e.funcs[d.Name]; ok {
+			return nil, fmt.Errorf("%w: %s is a builtin function", ErrInvalidFunction, d.Name)
+		}
+		if _, ok := l.defs[d.Name]; ok {
+			return nil, fmt.Errorf("%w: %s defined twice", ErrInvalidFunction, d.Name)
+		}
+		l.defs[d.Name] = d
+	}
+	for _, d := range defs {
+		if _, err := l.link(d.Name, nil); err != nil {
+			return nil, err
+		}
+	}
+	return l.env, nil
+}
+
+type linker struct {
+	env   *Env
+	defs  map[string]Definition
+	depth map[string]int // Call depth of linked definitions.
+}
+
+// link compiles the definition of name after its dependencies and returns
+// its call depth. path holds the definitions being linked.
+func (l *linker) link(name string, path []string) (int, error) {
+	if d, ok := l.depth[name]; ok {
+		return d, nil
+	}
+	for _, p := range path {
+		if p == name {
+			return 0, fmt.Errorf("%w: %s", ErrRecursion,
+				strings.Join(append(path, name), " -> "))
+		}
+	}
+	def := l.defs[name]
+	root, err := Parse(def.Body)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", name, err)
+	}
+	depth := 1
+	var linkErr error
+	walk(root, func(n Node) {
+		call, ok := n.(*Call)
+		if !ok || linkErr != nil {
+			return
+		}
+		if _, ok := l.defs[call.Name]; !ok {
+			return
+		}
+		d, err := l.link(call.Name, append(path, name))
+		if err != nil {
+			linkErr = err
+			return
+		}
+		depth = max(depth, d+1)
+	})
+	if linkErr != nil {
+		return 0, linkErr
+	}
+	if depth > MaxCallDepth {
+		return 0, fmt.Errorf("%w: %s nests %d calls, limit is %d",
+			ErrCallDepth, name, depth, MaxCallDepth)
+	}
+	prog, err := l.env.Compile(def.Body, def.Params)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", name, err)
+	}
+	l.env.funcs[name] = Func{Arity: len(def.Params), Fn: prog.Eval, Cost: prog.cost}
+	l.depth[name] = depth
+	return depth, nil
+}
+
+func walk(n Node, fn func(Node)) {
+	fn(n)
+	switch n := n.(type) {
+	case *Unary:
+		walk(n.X, fn)
+	case *Binary:
+		walk(n.X, fn)
+		walk(n.Y, fn)
+	case *Call:
+		for _, arg := range n.Args {
+			walk(arg, fn)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package expr
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/igorgatis/sezzle/backend/pkg/internal/calculator"
+)
+
+func TestWith(t *testing.T) {
+	env, err := newTestEnv().With([]Definition{
+		{Name: "total", Params: []string{"x"}, Body: "x + fee(x)"},
+		{Name: "fee", Params: []string{"x"}, Body: "max(0.3, x * 0.5)"},
+		{Name: "answer", Body: "42"},
+	})
+	if err != nil {
+		t.Fatalf("With error = %v", err)
+	}
+	tests := []struct {
+		src      string
+		expected float64
+	}{
+		{"fee(0)", 0.3},
+		{"fee(10)", 5},
+		{"total(10)", 15},
+		{"answer() + 1", 43},
+	}
+	for _, tt := range tests {
+		t.Run(tt.src, func(t *testing.T) {
+			result, err := env.Evaluate(tt.src, nil)
+			if err != nil || result != tt.expected {
+				t.Errorf("Evaluate(%q) = %v, %v, want %v", tt.src, result, err, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWithErrors(t *testing.T) {
+	chain := make([]Definition, MaxCallDepth+1)
+	for i := range chain {
+		chain[i] = Definition{Name: fmt.Sprintf("f%d", i), Body: fmt.Sprintf("f%d() + 1", i+1)}
+	}
+	chain[MaxCallDepth].Body = "1"
+	// Each function calls the next 10 times, so g0 runs over 10⁶ calls.
+	fanOut := make([]Definition, 7)
+	for i := range fanOut {
+		fanOut[i] = Definition{Name: fmt.Sprintf("g%d", i), Body: strings.TrimSuffix(strings.Repeat(fmt.Sprintf("g%d()+", i+1), 10), "+")}
+	}
+	fanOut[6].Body = "1"
+
+	tests := []struct {
+		name      string
+		defs      []Definition
+		expectErr error
+	}{
+		{"invalid name", []Definition{{Name: "1f", Body: "1"}}, ErrInvalidFunction},
+		{"builtin name", []Definition{{Name: "sqrt", Params: []string{"x"}, Body: "x"}}, ErrInvalidFunction},
+		{"duplicate", []Definition{{Name: "f", Body: "1"}, {Name: "f", Body: "2"}}, ErrInvalidFunction},
+		{"syntax error", []Definition{{Name: "f", Body: "1 +"}}, ErrSyntax},
+		{"invalid param", []Definition{{Name: "f", Params: []string{"1x"}, Body: "1"}}, ErrInvalidVariable},
+		{"unknown variable", []Definition{{Name: "f", Params: []string{"x"}, Body: "y"}}, ErrUnknownVariable},
+		{"unknown function", []Definition{{Name: "f", Body: "g()"}}, ErrUnknownFunction},
+		{"wrong arity", []Definition{{Name: "f", Body: "g(1)"}, {Name: "g", Body: "1"}}, ErrArgumentCount},
+		{"self recursion", []Definition{{Name: "f", Params: []string{"x"}, Body: "f(x - 1)"}}, ErrRecursion},
+		{"mutual recursion", []Definition{{Name: "f", Body: "g()"}, {Name: "g", Body: "f()"}}, ErrRecursion},
+		{"too deep", chain, ErrCallDepth},
+		{"too complex", fanOut, ErrTooComplex},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := newTestEnv().With(tt.defs); !errors.Is(err, tt.expectErr) {
+				t.Errorf("With error = %v, want %v", err, tt.expectErr)
+			}
+		})
+	}
+
+	if _, err := newTestEnv().With(chain[1:]); err != nil {
+		t.Errorf("With(%d nested calls) error = %v", MaxCallDepth, err)
+	}
+}
+
+func TestUserFunctionErrors(t *testing.T) {
+	env, err := newTestEnv().With([]Definition{
+		{Name: "inv", Params: []string{"x"}, Body: "1 / x"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := env.Evaluate("inv(0)", nil); !errors.Is(err, calculator.ErrDivisionByZero) {
+		t.Errorf("Evaluate error = %v, want %v", err, calculator.ErrDivisionByZero)
+	}
+}
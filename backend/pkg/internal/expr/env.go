@@ -0,0 +1,146 @@
+package expr
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+
+	"github.com/igorgatis/sezzle/backend/pkg/internal/calculator"
+)
+
+var (
+	ErrUnknownVariable = errors.New("unknown variable")
+	ErrUnknownFunction = errors.New("unknown function")
+	ErrArgumentCount   = errors.New("wrong number of arguments")
+	ErrInvalidVariable = errors.New("invalid variable name")
+	ErrMissingVariable = errors.New("missing variable")
+	ErrNotFinite       = errors.New("result is not a finite number")
+	ErrTooComplex      = errors.New("expression too complex")
+)
+
+// Func is a function callable from expressions.
+type Func struct {
+	// Arity is the number of arguments, or -1 for one or more.
+	Arity int
+	Fn    func(args []float64) (float64, error)
+	// Cost is the number of instructions a call runs for user-defined
+	// functions, 0 for the others.
+	Cost int
+}
+
+// operatorOps names the operations implementing '+', '-', '*', '/' and '^'.
+var operatorOps = [...]string{"add", "subtract", "multiply", "divide", "power"}
+
+type operators [len(operatorOps)]func(args []float64) (float64, error)
+
+// Env holds the functions and constants available to expressions and the
+// operations implementing arithmetic operators.
+type Env struct {
+	ops      *operators
+	funcs    map[string]Func
+	consts   map[string]float64
+	angleOps []calculator.Operation
+}
+
+// NewEnv returns an environment where every operation of ops is callable as
+// a function. ops must include the operations named in operatorOps.
+func NewEnv(ops *calculator.Registry) *Env {
+	e := &Env{
+		ops:    new(operators),
+		funcs:  builtinFuncs(),
+		consts: map[string]float64{"pi": math.Pi, "e": math.E},
+	}
+	for _, op := range ops.Operations() {
+		if op.Eval == nil {
+			continue
+		}
+		e.funcs[op.Name] = Func{Arity: len(op.Operands), Fn: op.Eval}
+		if op.AngleEval != nil {
+			e.angleOps = append(e.angleOps, op)
+		}
+	}
+	for i, name := range operatorOps {
+		op, ok := ops.Lookup(name)
+		if !ok {
+			panic("expr: missing operation " + name)
+		}
+		e.ops[i] = op.Eval
+	}
+	return e
+}
+
+// WithAngleUnit returns a copy of e whose functions on angles take and
+// return angles in unit.
+func (e *Env) WithAngleUnit(unit calculator.AngleUnit) *Env {
+	if unit == calculator.Radians {
+		return e
+	}
+	c := &Env{
+		ops:      e.ops,
+		funcs:    maps.Clone(e.funcs),
+		consts:   e.consts,
+		angleOps: e.angleOps,
+	}
+	for _, op := range e.angleOps {
+		c.funcs[op.Name] = Func{Arity: len(op.Operands), Fn: func(x []float64) (float64, error) {
+			return op.AngleEval(x, unit)
+		}}
+	}
+	return c
+}
+
+// Funcs returns the sorted names of the available functions.
+func (e *Env) Funcs() []string {
+	return slices.Sorted(maps.Keys(e.funcs))
+}
+
+// Evaluate parses and evaluates src once. Variables are taken from bindings.
+func (e *Env) Evaluate(src string, bindings map[string]float64) (float64, error) {
+	p, err := e.Compile(src, slices.Sorted(maps.Keys(bindings)))
+	if err != nil {
+		return 0, err
+	}
+	return p.EvalMap(bindings)
+}
+
+func builtinFuncs() map[string]Func {
+	unary := func(fn func(float64) float64) Func {
+		return Func{Arity: 1, Fn: func(x []float64) (float64, error) {
+			return fn(x[0]), nil
+		}}
+	}
+	return map[string]Func{
+		"ceil":  unary(math.Ceil),
+		"floor": unary(math.Floor),
+		"round": unary(math.Round),
+		"min": {Arity: -1, Fn: func(x []float64) (float64, error) {
+			return slices.Min(x), nil
+		}},
+		"max": {Arity: -1, Fn: func(x []float64) (float64, error) {
+			return slices.Max(x), nil
+		}},
+		// npv(rate, cf0, cf1, ...) with cf0 happening now.
+		"npv": {Arity: -1, Fn: func(x []float64) (float64, error) {
+			if len(x) < 2 {
+				return 0, fmt.Errorf("%w: npv takes at least 2, got %d", ErrArgumentCount, len(x))
+			}
+			return calculator.NPV(x[0], x[1:])
+		}},
+		// irr(cf0, cf1, ...) picks the rate closest to 10%.
+		"irr": {Arity: -1, Fn: func(x []float64) (float64, error) {
+			return calculator.IRR(x, 0.1)
+		}},
+	}
+}
+
+func checkArity(name string, f Func, n int) error {
+	if f.Arity == n || (f.Arity < 0 && n > 0) {
+		return nil
+	}
+	if f.Arity < 0 {
+		return fmt.Errorf("%w: %s takes at least 1, got %d", ErrArgumentCount, name, n)
+	}
+	return fmt.Errorf("%w: %s takes %d, got %d", ErrArgumentCount, name, f.Arity, n)
+}
@@ -0,0 +1,249 @@
+package expr
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Precedence levels of nodes when printed, from loosest to tightest.
+const (
+	precSum = iota + 1
+	precProduct
+	precUnary
+	precPower
+	precAtom
+)
+
+func precedence(n Node) int {
+	switch n := n.(type) {
+	case *Num:
+		if n.Value < 0 {
+			return precUnary
+		}
+	case *Unary:
+		return precUnary
+	case *Binary:
+		switch n.Op {
+		case '+', '-':
+			return precSum
+		case '*', '/':
+			return precProduct
+		}
+		return precPower
+	}
+	return precAtom
+}
+
+// Format prints n as an expression that parses back into the same tree,
+// with only the parentheses it needs.
+func Format(n Node) string {
+	var b strings.Builder
+	format(&b, n)
+	return b.String()
+}
+
+func format(b *strings.Builder, n Node) {
+	switch n := n.(type) {
+	case *Num:
+		b.WriteString(strconv.FormatFloat(n.Value, 'g', -1, 64))
+	case *Var:
+		b.WriteString(n.Name)
+	case *Unary:
+		b.WriteByte(n.Op)
+		// Unary operators bind looser than '^' but tighter than '*'.
+		formatParen(b, n.X, precedence(n.X) < precUnary)
+	case *Binary:
+		p := precedence(n)
+		if n.Op == '^' {
+			// The base is a primary and the exponent is parenthesized
+			// unless it is one too.
+			formatParen(b, n.X, precedence(n.X) < precAtom)
+			b.WriteByte('^')
+			formatParen(b, n.Y, precedence(n.Y) < precAtom)
+			return
+		}
+		formatParen(b, n.X, precedence(n.X) < p)
+		if p == precSum {
+			b.WriteString(" " + string(n.Op) + " ")
+		} else {
+			b.WriteByte(n.Op)
+		}
+		formatParen(b, n.Y, precedence(n.Y) <= p)
+	case *Call:
+		b.WriteString(n.Name + "(")
+		for i, arg := range n.Args {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			format(b, arg)
+		}
+		b.WriteByte(')')
+	}
+}
+
+func formatParen(b *strings.Builder, n Node, paren bool) {
+	if paren {
+		b.WriteByte('(')
+	}
+	format(b, n)
+	if paren {
+		b.WriteByte(')')
+	}
+}
+
+// latexFuncs are the functions LaTeX typesets as operators.
+var latexFuncs = map[string]string{
+	"sin": `\sin`, "cos": `\cos`, "tan": `\tan`,
+	"asin": `\arcsin`, "acos": `\arccos`, "atan": `\arctan`,
+	"sinh": `\sinh`, "cosh": `\cosh`, "tanh": `\tanh`,
+	"ln": `\ln`, "log10": `\log_{10}`,
+	"min": `\min`, "max": `\max`,
+}
+
+// LaTeX typesets n, with fractions as \frac, products of a number and a
+// symbol by juxtaposition and constants pi and e as symbols.
+func LaTeX(n Node) string {
+	var b strings.Builder
+	latex(&b, n)
+	return b.String()
+}
+
+// latexPrecedence is the precedence of n in LaTeX, where fractions and
+// function calls need no parentheses.
+func latexPrecedence(n Node) int {
+	if b, ok := n.(*Binary); ok && b.Op == '/' {
+		return precAtom
+	}
+	return precedence(n)
+}
+
+func latex(b *strings.Builder, n Node) {
+	switch n := n.(type) {
+	case *Num:
+		s := strconv.FormatFloat(n.Value, 'g', -1, 64)
+		if mant, exp, ok := strings.Cut(s, "e"); ok {
+			exp = strings.TrimPrefix(strings.TrimPrefix(exp, "+"), "0")
+			s = mant + ` \times 10^{` + strings.Replace(exp, "-0", "-", 1) + "}"
+		}
+		b.WriteString(s)
+	case *Var:
+		switch {
+		case n.Name == "pi":
+			b.WriteString(`\pi`)
+		case len(n.Name) == 1:
+			b.WriteString(n.Name)
+		default:
+			b.WriteString(`\mathrm{` + strings.ReplaceAll(n.Name, "_", `\_`) + "}")
+		}
+	case *Unary:
+		b.WriteByte(n.Op)
+		latexParen(b, n.X, latexPrecedence(n.X) < precUnary)
+	case *Binary:
+		switch n.Op {
+		case '/':
+			x, negative := leadingNeg(n.X)
+			if negative {
+				b.WriteByte('-')
+			}
+			b.WriteString(`\frac{`)
+			latex(b, x)
+			b.WriteString("}{")
+			latex(b, n.Y)
+			b.WriteString("}")
+		case '^':
+			// Bases are parenthesized as in text, as are calls so that
+			// the exponent does not read as applying to the argument.
+			_, isCall := n.X.(*Call)
+			latexParen(b, n.X, isCall || precedence(n.X) < precAtom)
+			b.WriteString("^{")
+			latex(b, n.Y)
+			b.WriteString("}")
+		case '*':
+			latexParen(b, n.X, latexPrecedence(n.X) < precProduct)
+			if x, _ := leadingNeg(n.X); !isNum(x) || !startsWithSymbol(n.Y) {
+				b.WriteString(` \cdot `)
+			}
+			latexParen(b, n.Y, latexPrecedence(n.Y) < precProduct)
+		default:
+			latexParen(b, n.X, latexPrecedence(n.X) < precSum)
+			b.WriteString(" " + string(n.Op) + " ")
+			latexParen(b, n.Y, latexPrecedence(n.Y) < precSum || n.Op == '-' && latexPrecedence(n.Y) == precSum)
+		}
+	case *Call:
+		latexCall(b, n)
+	}
+}
+
+func isNum(n Node) bool {
+	_, ok := n.(*Num)
+	return ok
+}
+
+// startsWithSymbol reports whether n is typeset starting with a variable or
+// function, which may follow a number without a multiplication sign.
+func startsWithSymbol(n Node) bool {
+	switch n := n.(type) {
+	case *Var, *Call:
+		return true
+	case *Binary:
+		return (n.Op == '^' || n.Op == '*') && startsWithSymbol(n.X)
+	}
+	return false
+}
+
+func latexCall(b *strings.Builder, n *Call) {
+	switch {
+	case n.Name == "sqrt" && len(n.Args) == 1:
+		b.WriteString(`\sqrt{`)
+		latex(b, n.Args[0])
+		b.WriteString("}")
+		return
+	case n.Name == "root" && len(n.Args) == 2:
+		b.WriteString(`\sqrt[`)
+		latex(b, n.Args[1])
+		b.WriteString("]{")
+		latex(b, n.Args[0])
+		b.WriteString("}")
+		return
+	case n.Name == "exp" && len(n.Args) == 1:
+		b.WriteString("e^{")
+		latex(b, n.Args[0])
+		b.WriteString("}")
+		return
+	case n.Name == "abs" && len(n.Args) == 1:
+		b.WriteString(`\left|`)
+		latex(b, n.Args[0])
+		b.WriteString(`\right|`)
+		return
+	case n.Name == "log" && len(n.Args) == 2:
+		b.WriteString(`\log_{`)
+		latex(b, n.Args[1])
+		b.WriteString(`}\left(`)
+		latex(b, n.Args[0])
+		b.WriteString(`\right)`)
+		return
+	}
+	if name, ok := latexFuncs[n.Name]; ok {
+		b.WriteString(name)
+	} else {
+		b.WriteString(`\operatorname{` + strings.ReplaceAll(n.Name, "_", `\_`) + "}")
+	}
+	b.WriteString(`\left(`)
+	for i, arg := range n.Args {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		latex(b, arg)
+	}
+	b.WriteString(`\right)`)
+}
+
+func latexParen(b *strings.Builder, n Node, paren bool) {
+	if paren {
+		b.WriteString(`\left(`)
+	}
+	latex(b, n)
+	if paren {
+		b.WriteString(`\right)`)
+	}
+}
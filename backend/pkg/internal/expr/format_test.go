@@ -0,0 +1,84 @@
+package expr
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		src, expected string
+	}{
+		{"1+2*3", "1 + 2*3"},
+		{"(1+2)*3", "(1 + 2)*3"},
+		{"a-(b-c)", "a - (b - c)"},
+		{"a-b-c", "a - b - c"},
+		{"a/(b*c)", "a/(b*c)"},
+		{"a*b/c", "a*b/c"},
+		{"-x^2", "-x^2"},
+		{"(-x)^2", "(-x)^2"},
+		{"-(x*y)", "-(x*y)"},
+		{"-x*y", "-x*y"},
+		{"x^y^z", "x^(y^z)"},
+		{"(x^y)^z", "(x^y)^z"},
+		{"x^-2", "x^(-2)"},
+		{"x^(1/3)", "x^(1/3)"},
+		{"2*-x", "2*-x"},
+		{"max( x,1 ,y)", "max(x, 1, y)"},
+		{"1.5e21 + 0.1", "1.5e+21 + 0.1"},
+		{"irr()", "irr()"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.src, func(t *testing.T) {
+			n, err := Parse(tt.src)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := Format(n)
+			if got != tt.expected {
+				t.Errorf("Format = %s, want %s", got, tt.expected)
+			}
+			back, err := Parse(got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(back, n) {
+				t.Errorf("%s parses into a different tree", got)
+			}
+		})
+	}
+}
+
+func TestLaTeX(t *testing.T) {
+	tests := []struct {
+		src, expected string
+	}{
+		{"2*x^2 - 4*x + 1", `2x^{2} - 4x + 1`},
+		{"x^2*sin(x)", `x^{2} \cdot \sin\left(x\right)`},
+		{"-2*x", `-2x`},
+		{"2*3", `2 \cdot 3`},
+		{"(x + 1)/(x - 1)", `\frac{x + 1}{x - 1}`},
+		{"-1/x^2", `-\frac{1}{x^{2}}`},
+		{"x^(1/3)", `x^{\frac{1}{3}}`},
+		{"sin(x)^2", `\left(\sin\left(x\right)\right)^{2}`},
+		{"(x/2)^2", `\left(\frac{x}{2}\right)^{2}`},
+		{"a - (b + c)", `a - \left(b + c\right)`},
+		{"sqrt(x) + root(x, 3)", `\sqrt{x} + \sqrt[3]{x}`},
+		{"exp(-x) + abs(x)", `e^{-x} + \left|x\right|`},
+		{"log(x, 2) + ln(x) + log10(x)", `\log_{2}\left(x\right) + \ln\left(x\right) + \log_{10}\left(x\right)`},
+		{"asin(x) + pi*e", `\arcsin\left(x\right) + \pi \cdot e`},
+		{"fee(rate, my_var)", `\operatorname{fee}\left(\mathrm{rate}, \mathrm{my\_var}\right)`},
+		{"1.5e21 + 2e-7", `1.5 \times 10^{21} + 2 \times 10^{-7}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.src, func(t *testing.T) {
+			n, err := Parse(tt.src)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := LaTeX(n); got != tt.expected {
+				t.Errorf("LaTeX = %s, want %s", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,265 @@
+package expr
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+var ErrSyntax = errors.New("syntax error")
+
+const (
+	// MaxLength bounds the length of expressions in bytes.
+	MaxLength = 10_000
+	// MaxDepth bounds how deeply parentheses, calls, unary operators and
+	// exponents nest, as the parser and the tree walks recurse on them.
+	MaxDepth = 256
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokNum
+	tokIdent
+	tokOp
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// Parse parses src into an expression tree. The grammar supports numbers,
+// identifiers, function calls, parentheses, unary '+'/'-' and the binary
+// operators '+', '-', '*', '/' and '^' with the usual precedence; '^' is
+// right-associative and binds tighter than unary minus, so -2^2 is -4.
+// Expressions longer than MaxLength or nesting deeper than MaxDepth fail with
+// ErrSyntax.
+func Parse(src string) (Node, error) {
+	if len(src) > MaxLength {
+		return nil, fmt.Errorf("%w: expression longer than %d bytes", ErrSyntax, MaxLength)
+	}
+	p := &parser{src: src}
+	p.next()
+	n, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokEOF {
+		return nil, p.unexpected()
+	}
+	return n, nil
+}
+
+type parser struct {
+	src   string
+	pos   int
+	tok   token
+	depth int
+}
+
+func (p *parser) next() {
+	for p.pos < len(p.src) && isSpace(p.src[p.pos]) {
+		p.pos++
+	}
+	start := p.pos
+	if p.pos >= len(p.src) {
+		p.tok = token{kind: tokEOF, pos: start}
+		return
+	}
+	c := p.src[p.pos]
+	switch {
+	case isDigit(c) || c == '.':
+		for p.pos < len(p.src) && (isDigit(p.src[p.pos]) || p.src[p.pos] == '.') {
+			p.pos++
+		}
+		if p.pos < len(p.src) && (p.src[p.pos] == 'e' || p.src[p.pos] == 'E') {
+			end := p.pos + 1
+			if end < len(p.src) && (p.src[end] == '+' || p.src[end] == '-') {
+				end++
+			}
+			if end < len(p.src) && isDigit(p.src[end]) {
+				for end < len(p.src) && isDigit(p.src[end]) {
+					end++
+				}
+				p.pos = end
+			}
+		}
+		p.tok = token{kind: tokNum, text: p.src[start:p.pos], pos: start}
+	case isLetter(c):
+		for p.pos < len(p.src) && (isLetter(p.src[p.pos]) || isDigit(p.src[p.pos])) {
+			p.pos++
+		}
+		p.tok = token{kind: tokIdent, text: p.src[start:p.pos], pos: start}
+	default:
+		p.pos++
+		p.tok = token{kind: tokOp, text: p.src[start:p.pos], pos: start}
+	}
+}
+
+func (p *parser) is(op string) bool {
+	return p.tok.kind == tokOp && p.tok.text == op
+}
+
+func (p *parser) unexpected() error {
+	if p.tok.kind == tokEOF {
+		return fmt.Errorf("%w: unexpected end of expression", ErrSyntax)
+	}
+	return fmt.Errorf("%w at position %d: unexpected %q", ErrSyntax, p.tok.pos, p.tok.text)
+}
+
+func (p *parser) expect(op string) error {
+	if !p.is(op) {
+		return p.unexpected()
+	}
+	p.next()
+	return nil
+}
+
+func (p *parser) parseExpr() (Node, error) {
+	x, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+	for p.is("+") || p.is("-") {
+		op := p.tok.text[0]
+		p.next()
+		y, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		x = &Binary{Op: op, X: x, Y: y}
+	}
+	return x, nil
+}
+
+func (p *parser) parseTerm() (Node, error) {
+	x, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.is("*") || p.is("/") {
+		op := p.tok.text[0]
+		p.next()
+		y, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		x = &Binary{Op: op, X: x, Y: y}
+	}
+	return x, nil
+}
+
+// parseUnary is where every nested subexpression starts, so it keeps track
+// of the depth.
+func (p *parser) parseUnary() (Node, error) {
+	if p.depth == MaxDepth {
+		return nil, fmt.Errorf("%w at position %d: expression nested deeper than %d", ErrSyntax, p.tok.pos, MaxDepth)
+	}
+	p.depth++
+	defer func() { p.depth-- }()
+	if p.is("-") || p.is("+") {
+		op := p.tok.text[0]
+		p.next()
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &Unary{Op: op, X: x}, nil
+	}
+	return p.parsePower()
+}
+
+func (p *parser) parsePower() (Node, error) {
+	x, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	if p.is("^") {
+		p.next()
+		y, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &Binary{Op: '^', X: x, Y: y}, nil
+	}
+	return x, nil
+}
+
+func (p *parser) parsePrimary() (Node, error) {
+	switch {
+	case p.tok.kind == tokNum:
+		v, err := strconv.ParseFloat(p.tok.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w at position %d: invalid number %q",
+				ErrSyntax, p.tok.pos, p.tok.text)
+		}
+		p.next()
+		return &Num{Value: v}, nil
+	case p.tok.kind == tokIdent:
+		name := p.tok.text
+		p.next()
+		if !p.is("(") {
+			return &Var{Name: name}, nil
+		}
+		p.next()
+		call := &Call{Name: name}
+		if p.is(")") {
+			p.next()
+			return call, nil
+		}
+		for {
+			arg, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			call.Args = append(call.Args, arg)
+			if !p.is(",") {
+				break
+			}
+			p.next()
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		return call, nil
+	case p.is("("):
+		p.next()
+		x, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		return x, nil
+	}
+	return nil, p.unexpected()
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func isLetter(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || c == '_'
+}
+
+// IsIdent reports whether s is a valid variable or function name.
+func IsIdent(s string) bool {
+	if s == "" || !isLetter(s[0]) {
+		return false
+	}
+	for i := 1; i < len(s); i++ {
+		if !isLetter(s[i]) && !isDigit(s[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,82 @@
+package expr
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	num := func(v float64) Node { return &Num{Value: v} }
+	tests := []struct {
+		name string
+		src  string
+		want Node
+	}{
+		{"number", "1.5", num(1.5)},
+		{"exponent", "2e3", num(2000)},
+		{"leading dot", ".5", num(0.5)},
+		{"variable", "x_1", &Var{Name: "x_1"}},
+		{"precedence", "1+2*3", &Binary{Op: '+', X: num(1), Y: &Binary{Op: '*', X: num(2), Y: num(3)}}},
+		{"left assoc", "1-2-3", &Binary{Op: '-', X: &Binary{Op: '-', X: num(1), Y: num(2)}, Y: num(3)}},
+		{"power right assoc", "2^3^2", &Binary{Op: '^', X: num(2), Y: &Binary{Op: '^', X: num(3), Y: num(2)}}},
+		{"unary minus below power", "-2^2", &Unary{Op: '-', X: &Binary{Op: '^', X: num(2), Y: num(2)}}},
+		{"negative exponent", "2^-1", &Binary{Op: '^', X: num(2), Y: &Unary{Op: '-', X: num(1)}}},
+		{"parentheses", "(1+2)*3", &Binary{Op: '*', X: &Binary{Op: '+', X: num(1), Y: num(2)}, Y: num(3)}},
+		{"call", "max(x, 1)", &Call{Name: "max", Args: []Node{&Var{Name: "x"}, num(1)}}},
+		{"call no args", "f()", &Call{Name: "f"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.src)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tt.src, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse(%q) = %#v, want %#v", tt.src, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []string{"", "1+", "(1", "1)", "f(1,", "1 2", "1..2", "$", "max(,)"}
+	for _, src := range tests {
+		t.Run(src, func(t *testing.T) {
+			if _, err := Parse(src); !errors.Is(err, ErrSyntax) {
+				t.Errorf("Parse(%q) error = %v, want %v", src, err, ErrSyntax)
+			}
+		})
+	}
+}
+
+func TestParseLimits(t *testing.T) {
+	nested := func(open, close string, n int) string {
+		return strings.Repeat(open, n) + "1" + strings.Repeat(close, n)
+	}
+	ok := []string{
+		nested("(", ")", MaxDepth-1),
+		nested("f(", ")", MaxDepth-1),
+		strings.Repeat("-", MaxDepth-1) + "1",
+		strings.Repeat("1+", MaxLength/2-1) + "1",
+	}
+	for _, src := range ok {
+		if _, err := Parse(src); err != nil {
+			t.Errorf("Parse(%.20q...) error = %v", src, err)
+		}
+	}
+	tooDeep := []string{
+		nested("(", ")", MaxDepth),
+		nested("f(", ")", MaxDepth),
+		strings.Repeat("-", MaxDepth) + "1",
+		strings.Repeat("2^", MaxDepth) + "1",
+		nested("(", ")", 5_000_000),
+		strings.Repeat("1+", MaxLength/2) + "1",
+	}
+	for _, src := range tooDeep {
+		if _, err := Parse(src); !errors.Is(err, ErrSyntax) {
+			t.Errorf("Parse(%.20q...) error = %v, want %v", src, err, ErrSyntax)
+		}
+	}
+}
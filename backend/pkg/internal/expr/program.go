@@ -0,0 +1,300 @@
+package expr
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/igorgatis/sezzle/backend/pkg/internal/calculator"
+)
+
+// MaxCost bounds the instructions one evaluation runs, counting those of the
+// user-defined functions it calls, which may call each other many times.
+const MaxCost = 100_000
+
+type opcode uint8
+
+const (
+	opConst opcode = iota
+	opVar
+	opNeg
+	opAdd
+	opSub
+	opMul
+	opDiv
+	opPow
+	opCall
+)
+
+var binaryOps = map[byte]opcode{'+': opAdd, '-': opSub, '*': opMul, '/': opDiv, '^': opPow}
+
+type instr struct {
+	op  opcode
+	arg int // Index into consts, vars or funcs.
+	n   int // Argument count of opCall.
+}
+
+// Program is a compiled expression. It is immutable and safe for
+// concurrent use.
+type Program struct {
+	vars   []string
+	code   []instr
+	consts []float64
+	funcs  []Func
+	ops    *operators
+	depth  int
+	// cost is the number of instructions an evaluation runs.
+	cost int
+}
+
+// Compile parses src and compiles it into a Program whose free variables
+// are exactly vars, in that order. It fails with ErrTooComplex when an
+// evaluation would run more than MaxCost instructions.
+func (e *Env) Compile(src string, vars []string) (*Program, error) {
+	root, err := Parse(src)
+	if err != nil {
+		return nil, err
+	}
+	c := &compiler{
+		env:     e,
+		prog:    &Program{vars: vars, ops: e.ops},
+		varIdx:  make(map[string]int, len(vars)),
+		funcIdx: make(map[string]int),
+	}
+	for i, v := range vars {
+		if !IsIdent(v) {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidVariable, v)
+		}
+		if _, dup := c.varIdx[v]; dup {
+			return nil, fmt.Errorf("%w: %q declared twice", ErrInvalidVariable, v)
+		}
+		c.varIdx[v] = i
+	}
+	if err := c.compile(root); err != nil {
+		return nil, err
+	}
+	if c.prog.cost > MaxCost {
+		return nil, fmt.Errorf("%w: an evaluation runs %d instructions, limit is %d",
+			ErrTooComplex, c.prog.cost, MaxCost)
+	}
+	return c.prog, nil
+}
+
+type compiler struct {
+	env     *Env
+	prog    *Program
+	varIdx  map[string]int
+	funcIdx map[string]int
+	depth   int
+}
+
+func (c *compiler) emit(in instr, delta int) {
+	c.prog.code = append(c.prog.code, in)
+	c.prog.cost++
+	c.depth += delta
+	c.prog.depth = max(c.prog.depth, c.depth)
+}
+
+func (c *compiler) compile(n Node) error {
+	switch n := n.(type) {
+	case *Num:
+		c.prog.consts = append(c.prog.consts, n.Value)
+		c.emit(instr{op: opConst, arg: len(c.prog.consts) - 1}, 1)
+	case *Var:
+		if i, ok := c.varIdx[n.Name]; ok {
+			c.emit(instr{op: opVar, arg: i}, 1)
+			return nil
+		}
+		v, ok := c.env.consts[n.Name]
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrUnknownVariable, n.Name)
+		}
+		return c.compile(&Num{Value: v})
+	case *Unary:
+		if err := c.compile(n.X); err != nil {
+			return err
+		}
+		if n.Op == '-' {
+			c.emit(instr{op: opNeg}, 0)
+		}
+	case *Binary:
+		if p, q, ok := rationalExponent(n); ok {
+			return c.compile(&Call{Name: rationalPowerFunc, Args: []Node{
+				n.X, &Num{Value: float64(p)}, &Num{Value: float64(q)},
+			}})
+		}
+		if err := c.compile(n.X); err != nil {
+			return err
+		}
+		if err := c.compile(n.Y); err != nil {
+			return err
+		}
+		c.emit(instr{op: binaryOps[n.Op]}, -1)
+	case *Call:
+		f, ok := c.env.funcs[n.Name]
+		if n.Name == rationalPowerFunc {
+			f, ok = rationalPower, true
+		}
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrUnknownFunction, n.Name)
+		}
+		if err := checkArity(n.Name, f, len(n.Args)); err != nil {
+			return err
+		}
+		for _, arg := range n.Args {
+			if err := c.compile(arg); err != nil {
+				return err
+			}
+		}
+		i, ok := c.funcIdx[n.Name]
+		if !ok {
+			i = len(c.prog.funcs)
+			c.prog.funcs = append(c.prog.funcs, f)
+			c.funcIdx[n.Name] = i
+		}
+		c.prog.cost += f.Cost
+		c.emit(instr{op: opCall, arg: i, n: len(n.Args)}, 1-len(n.Args))
+	}
+	return nil
+}
+
+// rationalPowerFunc names the internal function raising to exact rational
+// exponents. It is not a valid identifier, so users cannot call it.
+const rationalPowerFunc = "^/"
+
+var rationalPower = Func{Arity: 3, Fn: func(x []float64) (float64, error) {
+	return calculator.RationalPower(x[0], int64(x[1]), int64(x[2]))
+}}
+
+// rationalExponent reports whether n raises to a literal fraction such as
+// x^(1/3), x^(-2/3) or x^-(2/3), which are evaluated exactly so that
+// negative bases have real odd roots.
+func rationalExponent(n *Binary) (p, q int64, ok bool) {
+	if n.Op != '^' {
+		return 0, 0, false
+	}
+	y, sign := n.Y, int64(1)
+	if u, isUnary := y.(*Unary); isUnary && u.Op == '-' {
+		y, sign = u.X, -1
+	}
+	frac, isFrac := y.(*Binary)
+	if !isFrac || frac.Op != '/' {
+		return 0, 0, false
+	}
+	numer := frac.X
+	if u, isUnary := numer.(*Unary); isUnary && u.Op == '-' {
+		numer, sign = u.X, -sign
+	}
+	p, okP := integerLiteral(numer)
+	q, okQ := integerLiteral(frac.Y)
+	return sign * p, q, okP && okQ
+}
+
+func integerLiteral(n Node) (int64, bool) {
+	num, ok := n.(*Num)
+	if !ok || num.Value != math.Trunc(num.Value) || math.Abs(num.Value) > 1<<53 {
+		return 0, false
+	}
+	return int64(num.Value), true
+}
+
+// Vars returns the variables the program was compiled with.
+func (p *Program) Vars() []string {
+	return p.vars
+}
+
+// Eval evaluates the program with args given in the order of Vars.
+func (p *Program) Eval(args []float64) (float64, error) {
+	if len(args) != len(p.vars) {
+		return 0, fmt.Errorf("%w: want %d values, got %d",
+			ErrArgumentCount, len(p.vars), len(args))
+	}
+	return p.run(make([]float64, 0, p.depth), args)
+}
+
+// EvalMap evaluates the program with variables taken from bindings.
+func (p *Program) EvalMap(bindings map[string]float64) (float64, error) {
+	args := make([]float64, len(p.vars))
+	if err := p.bind(bindings, args); err != nil {
+		return 0, err
+	}
+	return p.run(make([]float64, 0, p.depth), args)
+}
+
+// EvalBatch evaluates the program once per binding set, reusing buffers
+// across evaluations.
+func (p *Program) EvalBatch(bindings []map[string]float64) ([]float64, error) {
+	results := make([]float64, len(bindings))
+	args := make([]float64, len(p.vars))
+	stack := make([]float64, 0, p.depth)
+	for i, b := range bindings {
+		if err := p.bind(b, args); err != nil {
+			return nil, fmt.Errorf("bindings[%d]: %w", i, err)
+		}
+		r, err := p.run(stack, args)
+		if err != nil {
+			return nil, fmt.Errorf("bindings[%d]: %w", i, err)
+		}
+		results[i] = r
+	}
+	return results, nil
+}
+
+func (p *Program) bind(bindings map[string]float64, args []float64) error {
+	if len(bindings) > len(p.vars) {
+		for name := range bindings {
+			if !p.hasVar(name) {
+				return fmt.Errorf("%w: %s", ErrUnknownVariable, name)
+			}
+		}
+	}
+	for i, name := range p.vars {
+		v, ok := bindings[name]
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrMissingVariable, name)
+		}
+		args[i] = v
+	}
+	return nil
+}
+
+func (p *Program) hasVar(name string) bool {
+	for _, v := range p.vars {
+		if v == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Program) run(stack, args []float64) (float64, error) {
+	stack = stack[:0]
+	for _, in := range p.code {
+		switch in.op {
+		case opConst:
+			stack = append(stack, p.consts[in.arg])
+		case opVar:
+			stack = append(stack, args[in.arg])
+		case opNeg:
+			stack[len(stack)-1] = -stack[len(stack)-1]
+		case opCall:
+			base := len(stack) - in.n
+			r, err := p.funcs[in.arg].Fn(stack[base:])
+			if err != nil {
+				return 0, err
+			}
+			stack = append(stack[:base], r)
+		default:
+			r, err := p.ops[in.op-opAdd](stack[len(stack)-2:])
+			if err != nil {
+				return 0, err
+			}
+			stack = stack[:len(stack)-1]
+			stack[len(stack)-1] = r
+		}
+	}
+	r := stack[0]
+	if math.IsNaN(r) || math.IsInf(r, 0) {
+		return 0, ErrNotFinite
+	}
+	return r, nil
+}
@@ -0,0 +1,219 @@
+package expr
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/igorgatis/sezzle/backend/pkg/internal/calculator"
+)
+
+func newTestEnv() *Env {
+	return NewEnv(calculator.DefaultRegistry(calculator.New()))
+}
+
+func TestCompileAndEval(t *testing.T) {
+	env := newTestEnv()
+	tests := []struct {
+		name      string
+		src       string
+		vars      []string
+		args      []float64
+		expected  float64
+		expectErr error
+	}{
+		{"constant", "1 + 2 * 3", nil, nil, 7, nil},
+		{"variables", "a*x^2 + b", []string{"a", "x", "b"}, []float64{2, 3, 1}, 19, nil},
+		{"unary", "-x + +x - -x", []string{"x"}, []float64{2}, 2, nil},
+		{"builtin constant", "2 * pi", nil, nil, 6.283185307179586, nil},
+		{"variable shadows constant", "e", []string{"e"}, []float64{7}, 7, nil},
+		{"functions", "max(0.3, x * 0.5) + min(x, 1, 2)", []string{"x"}, []float64{2}, 2, nil},
+		{"sqrt", "sqrt(x)", []string{"x"}, []float64{9}, 3, nil},
+		{"operation as function", "percentage(10, divide(x, 2))", []string{"x"}, []float64{400}, 20, nil},
+		{"rational exponent", "x^(1/3)", []string{"x"}, []float64{-8}, -2, nil},
+		{"negative rational exponent", "x^-(2/3)", []string{"x"}, []float64{-8}, 0.25, nil},
+		{"negative numerator exponent", "x^(-2/3)", []string{"x"}, []float64{-8}, 0.25, nil},
+		{"unreduced rational exponent", "x^(2/6)", []string{"x"}, []float64{-27}, -3, nil},
+		{"even rational exponent", "x^(1/2)", []string{"x"}, []float64{-4}, 0, calculator.ErrComplexResult},
+		{"float exponent", "x^0.5", []string{"x"}, []float64{-4}, 0, calculator.ErrComplexResult},
+		{"finance operation", "fv(0, n, 100, 1000)", []string{"n"}, []float64{10}, 2000, nil},
+		{"distribution", "uniform_inv(p, 0, 10) + binomial_cdf(1, 2, 0.5)", []string{"p"}, []float64{0.25}, 3.25, nil},
+		{"distribution parameter", "normal_cdf(0, 0, s)", []string{"s"}, []float64{0}, 0, calculator.ErrInvalidDistribution},
+		{"npv", "npv(r, -100, 200)", []string{"r"}, []float64{1}, 0, nil},
+		{"npv without flows", "npv(r)", []string{"r"}, []float64{1}, 0, ErrArgumentCount},
+		{"irr", "irr(-x, 2 * x)", []string{"x"}, []float64{100}, 1, nil},
+		{"irr without sign change", "irr(x, x)", []string{"x"}, []float64{100}, 0, calculator.ErrCashFlowSigns},
+		{"nth root", "root(x, 3)", []string{"x"}, []float64{-27}, -3, nil},
+		{"division by zero", "1 / x", []string{"x"}, []float64{0}, 0, calculator.ErrDivisionByZero},
+		{"negative sqrt", "sqrt(x)", []string{"x"}, []float64{-1}, 0, calculator.ErrNegativeSqrt},
+		{"overflow", "10^x", []string{"x"}, []float64{400}, 0, ErrNotFinite},
+		{"wrong arg count", "x", []string{"x"}, nil, 0, ErrArgumentCount},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := env.Compile(tt.src, tt.vars)
+			if err != nil {
+				t.Fatalf("Compile(%q) error = %v", tt.src, err)
+			}
+			result, err := p.Eval(tt.args)
+			if !errors.Is(err, tt.expectErr) {
+				t.Errorf("Eval(%v) error = %v, want %v", tt.args, err, tt.expectErr)
+			}
+			if result != tt.expected {
+				t.Errorf("Eval(%v) = %v, want %v", tt.args, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	env := newTestEnv()
+	tests := []struct {
+		name      string
+		src       string
+		vars      []string
+		expectErr error
+	}{
+		{"syntax", "1 +", nil, ErrSyntax},
+		{"undeclared variable", "x + y", []string{"x"}, ErrUnknownVariable},
+		{"unknown function", "foo(1)", nil, ErrUnknownFunction},
+		{"too many args", "sqrt(1, 2)", nil, ErrArgumentCount},
+		{"variadic without args", "max()", nil, ErrArgumentCount},
+		{"invalid variable", "1", []string{"1x"}, ErrInvalidVariable},
+		{"duplicate variable", "x", []string{"x", "x"}, ErrInvalidVariable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := env.Compile(tt.src, tt.vars); !errors.Is(err, tt.expectErr) {
+				t.Errorf("Compile(%q) error = %v, want %v", tt.src, err, tt.expectErr)
+			}
+		})
+	}
+}
+
+func TestEvalMap(t *testing.T) {
+	p, err := newTestEnv().Compile("x - y", []string{"x", "y"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name      string
+		bindings  map[string]float64
+		expected  float64
+		expectErr error
+	}{
+		{"happy path", map[string]float64{"x": 5, "y": 3}, 2, nil},
+		{"missing variable", map[string]float64{"x": 5}, 0, ErrMissingVariable},
+		{"unknown variable", map[string]float64{"x": 5, "y": 3, "z": 1}, 0, ErrUnknownVariable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := p.EvalMap(tt.bindings)
+			if !errors.Is(err, tt.expectErr) {
+				t.Errorf("EvalMap(%v) error = %v, want %v", tt.bindings, err, tt.expectErr)
+			}
+			if result != tt.expected {
+				t.Errorf("EvalMap(%v) = %v, want %v", tt.bindings, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestEvalBatch(t *testing.T) {
+	p, err := newTestEnv().Compile("1 / x", []string{"x"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	results, err := p.EvalBatch([]map[string]float64{{"x": 1}, {"x": 2}, {"x": 4}})
+	if err != nil {
+		t.Fatalf("EvalBatch error = %v", err)
+	}
+	if want := []float64{1, 0.5, 0.25}; len(results) != 3 ||
+		results[0] != want[0] || results[1] != want[1] || results[2] != want[2] {
+		t.Errorf("EvalBatch = %v, want %v", results, want)
+	}
+
+	_, err = p.EvalBatch([]map[string]float64{{"x": 1}, {"x": 0}})
+	if !errors.Is(err, calculator.ErrDivisionByZero) {
+		t.Errorf("EvalBatch error = %v, want %v", err, calculator.ErrDivisionByZero)
+	}
+}
+
+func TestWithAngleUnit(t *testing.T) {
+	tests := []struct {
+		unit     calculator.AngleUnit
+		src      string
+		expected float64
+	}{
+		{calculator.Radians, "sin(pi/2)", 1},
+		{calculator.Degrees, "sin(30) + cos(60)", 1},
+		{calculator.Degrees, "asin(1)", 90},
+		{calculator.Gradians, "tan(50)", 1},
+		{calculator.Degrees, "ln(e) + log(8, 2) + factorial(3)", 10},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.unit)+" "+tt.src, func(t *testing.T) {
+			env := newTestEnv().WithAngleUnit(tt.unit)
+			result, err := env.Evaluate(tt.src, nil)
+			if err != nil || result != tt.expected {
+				t.Errorf("Evaluate(%q) = %v, %v, want %v", tt.src, result, err, tt.expected)
+			}
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	env := newTestEnv()
+	result, err := env.Evaluate("x * y", map[string]float64{"x": 3, "y": 4})
+	if err != nil || result != 12 {
+		t.Errorf("Evaluate = %v, %v, want 12, nil", result, err)
+	}
+	if _, err := env.Evaluate("x *", nil); !errors.Is(err, ErrSyntax) {
+		t.Errorf("Evaluate error = %v, want %v", err, ErrSyntax)
+	}
+}
+
+const benchExpr = "principal * (1 + rate / 12) ^ (12 * years) - max(fee, principal * 0.01)"
+
+var benchBindings = map[string]float64{
+	"principal": 10000, "rate": 0.05, "years": 10, "fee": 25,
+}
+
+func BenchmarkParseAndEval(b *testing.B) {
+	env := newTestEnv()
+	for b.Loop() {
+		if _, err := env.Evaluate(benchExpr, benchBindings); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCompiledEvalMap(b *testing.B) {
+	p, err := newTestEnv().Compile(benchExpr,
+		[]string{"principal", "rate", "years", "fee"})
+	if err != nil {
+		b.Fatal(err)
+	}
+	for b.Loop() {
+		if _, err := p.EvalMap(benchBindings); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCompiledEvalBatch(b *testing.B) {
+	p, err := newTestEnv().Compile(benchExpr,
+		[]string{"principal", "rate", "years", "fee"})
+	if err != nil {
+		b.Fatal(err)
+	}
+	bindings := make([]map[string]float64, 1000)
+	for i := range bindings {
+		bindings[i] = benchBindings
+	}
+	for b.Loop() {
+		if _, err := p.EvalBatch(bindings); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N*len(bindings)), "ns/eval")
+}
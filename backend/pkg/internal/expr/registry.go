@@ -0,0 +1,137 @@
+package expr
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/igorgatis/sezzle/backend/pkg/internal/calculator"
+	"github.com/igorgatis/sezzle/backend/pkg/internal/storage"
+)
+
+var (
+	ErrInvalidScope  = errors.New("invalid scope")
+	ErrFunctionInUse = errors.New("function in use")
+)
+
+// Registry keeps user-defined functions per scope (tenant or session) in a
+// storage.Store. Definitions are validated against the whole scope before
+// being stored.
+type Registry struct {
+	mu    sync.Mutex
+	base  *Env
+	store storage.Store[Definition]
+	// linked holds the environments of scopes with definitions by angle
+	// unit, until the scope changes.
+	linked map[string]map[calculator.AngleUnit]*Env
+}
+
+func NewRegistry(base *Env, store storage.Store[Definition]) *Registry {
+	return &Registry{base: base, store: store, linked: make(map[string]map[calculator.AngleUnit]*Env)}
+}
+
+// Env returns the base environment, with angles in unit, extended with the
+// functions of scope. Environments are linked once per version of a scope.
+func (r *Registry) Env(scope string, unit calculator.AngleUnit) (*Env, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if env, ok := r.linked[scope][unit]; ok {
+		return env, nil
+	}
+	defs, err := r.List(scope)
+	if err != nil {
+		return nil, err
+	}
+	env, err := r.base.WithAngleUnit(unit).With(defs)
+	if err != nil || len(defs) == 0 {
+		return env, err
+	}
+	if r.linked[scope] == nil {
+		r.linked[scope] = make(map[calculator.AngleUnit]*Env)
+	}
+	r.linked[scope][unit] = env
+	return env, nil
+}
+
+func (r *Registry) List(scope string) ([]Definition, error) {
+	if err := checkScope(scope); err != nil {
+		return nil, err
+	}
+	keys, err := r.store.List(scope + "/")
+	if err != nil {
+		return nil, err
+	}
+	defs := make([]Definition, 0, len(keys))
+	for _, k := range keys {
+		d, err := r.store.Get(k)
+		if err != nil {
+			return nil, err
+		}
+		defs = append(defs, d)
+	}
+	return defs, nil
+}
+
+func (r *Registry) Get(scope, name string) (Definition, error) {
+	if err := checkScope(scope); err != nil {
+		return Definition{}, err
+	}
+	return r.store.Get(scope + "/" + name)
+}
+
+// Put creates or replaces a definition. It reports whether it was created.
+func (r *Registry) Put(scope string, def Definition) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	defs, err := r.List(scope)
+	if err != nil {
+		return false, err
+	}
+	created := true
+	for i, d := range defs {
+		if d.Name == def.Name {
+			defs[i] = def
+			created = false
+		}
+	}
+	if created {
+		defs = append(defs, def)
+	}
+	if _, err := r.base.With(defs); err != nil {
+		return false, err
+	}
+	delete(r.linked, scope)
+	return created, r.store.Put(scope+"/"+def.Name, def)
+}
+
+// Delete removes a definition unless other definitions of scope call it.
+func (r *Registry) Delete(scope, name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	defs, err := r.List(scope)
+	if err != nil {
+		return err
+	}
+	rest := make([]Definition, 0, len(defs))
+	for _, d := range defs {
+		if d.Name != name {
+			rest = append(rest, d)
+		}
+	}
+	if len(rest) == len(defs) {
+		return storage.ErrNotFound
+	}
+	if _, err := r.base.With(rest); errors.Is(err, ErrUnknownFunction) {
+		return fmt.Errorf("%w: %w", ErrFunctionInUse, err)
+	}
+	delete(r.linked, scope)
+	return r.store.Delete(scope + "/" + name)
+}
+
+func checkScope(scope string) error {
+	if scope == "" || len(scope) > 64 || strings.Contains(scope, "/") {
+		return fmt.Errorf("%w: %q", ErrInvalidScope, scope)
+	}
+	return nil
+}
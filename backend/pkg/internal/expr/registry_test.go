@@ -0,0 +1,86 @@
+package expr
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/igorgatis/sezzle/backend/pkg/internal/calculator"
+	"github.com/igorgatis/sezzle/backend/pkg/internal/storage"
+)
+
+func TestRegistry(t *testing.T) {
+	r := NewRegistry(newTestEnv(), storage.NewMemory[Definition]())
+
+	fee := Definition{Name: "fee", Params: []string{"x"}, Body: "max(0.3, x * 0.5)"}
+	total := Definition{Name: "total", Params: []string{"x"}, Body: "x + fee(x)"}
+	for _, d := range []Definition{fee, total} {
+		if created, err := r.Put("acme", d); !created || err != nil {
+			t.Fatalf("Put(%s) = %v, %v, want true, nil", d.Name, created, err)
+		}
+	}
+
+	fee.Body = "x"
+	if created, err := r.Put("acme", fee); created || err != nil {
+		t.Errorf("Put(updated fee) = %v, %v, want false, nil", created, err)
+	}
+	if got, err := r.Get("acme", "fee"); err != nil || got.Body != "x" {
+		t.Errorf("Get(fee) = %+v, %v", got, err)
+	}
+	if _, err := r.Put("acme", Definition{Name: "fee", Params: []string{"x"}, Body: "total(x)"}); !errors.Is(err, ErrRecursion) {
+		t.Errorf("Put(recursive fee) error = %v, want %v", err, ErrRecursion)
+	}
+
+	env, err := r.Env("acme", calculator.Radians)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result, err := env.Evaluate("total(2)", nil); err != nil || result != 4 {
+		t.Errorf("Evaluate(total(2)) = %v, %v, want 4", result, err)
+	}
+	if again, err := r.Env("acme", calculator.Radians); err != nil || again != env {
+		t.Errorf("Env(unchanged scope) = %p, %v, want the linked %p", again, err, env)
+	}
+	fee.Body = "2 * x"
+	if _, err := r.Put("acme", fee); err != nil {
+		t.Fatal(err)
+	}
+	if env, err = r.Env("acme", calculator.Radians); err != nil {
+		t.Fatal(err)
+	}
+	if result, err := env.Evaluate("total(2)", nil); err != nil || result != 6 {
+		t.Errorf("Evaluate(total(2)) after Put = %v, %v, want 6", result, err)
+	}
+	if _, err := r.Get("other", "fee"); !errors.Is(err, storage.ErrNotFound) {
+		t.Errorf("Get(other scope) error = %v, want %v", err, storage.ErrNotFound)
+	}
+	if env, err := r.Env("other", calculator.Radians); err != nil {
+		t.Error(err)
+	} else if _, err := env.Evaluate("fee(1)", nil); !errors.Is(err, ErrUnknownFunction) {
+		t.Errorf("Evaluate in other scope error = %v, want %v", err, ErrUnknownFunction)
+	}
+
+	if err := r.Delete("acme", "fee"); !errors.Is(err, ErrFunctionInUse) {
+		t.Errorf("Delete(fee) error = %v, want %v", err, ErrFunctionInUse)
+	}
+	if err := r.Delete("acme", "total"); err != nil {
+		t.Errorf("Delete(total) error = %v", err)
+	}
+	if err := r.Delete("acme", "total"); !errors.Is(err, storage.ErrNotFound) {
+		t.Errorf("Delete(total) twice error = %v, want %v", err, storage.ErrNotFound)
+	}
+	if defs, err := r.List("acme"); err != nil || len(defs) != 1 || defs[0].Name != "fee" {
+		t.Errorf("List = %+v, %v", defs, err)
+	}
+}
+
+func TestRegistryInvalidScope(t *testing.T) {
+	r := NewRegistry(newTestEnv(), storage.NewMemory[Definition]())
+	for _, scope := range []string{"", "a/b"} {
+		if _, err := r.Env(scope, calculator.Radians); !errors.Is(err, ErrInvalidScope) {
+			t.Errorf("Env(%q) error = %v, want %v", scope, err, ErrInvalidScope)
+		}
+		if _, err := r.Put(scope, Definition{Name: "f", Body: "1"}); !errors.Is(err, ErrInvalidScope) {
+			t.Errorf("Put(%q) error = %v, want %v", scope, err, ErrInvalidScope)
+		}
+	}
+}
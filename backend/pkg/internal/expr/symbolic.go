@@ -0,0 +1,746 @@
+package expr
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"slices"
+	"strings"
+)
+
+var (
+	ErrNoDerivative = errors.New("no derivative rule")
+	ErrTooLarge     = errors.New("expression too large")
+)
+
+// maxSimplifyPasses bounds the passes of Simplify, which usually reaches a
+// fixed point after one or two.
+const maxSimplifyPasses = 8
+
+// chainRules give the derivative of one-argument functions at u, angles
+// being in radians.
+var chainRules = map[string]func(u Node) Node{
+	"sin":   func(u Node) Node { return call("cos", u) },
+	"cos":   func(u Node) Node { return neg(call("sin", u)) },
+	"tan":   func(u Node) Node { return div(num(1), pow(call("cos", u), num(2))) },
+	"asin":  func(u Node) Node { return div(num(1), call("sqrt", sub(num(1), pow(u, num(2))))) },
+	"acos":  func(u Node) Node { return neg(div(num(1), call("sqrt", sub(num(1), pow(u, num(2)))))) },
+	"atan":  func(u Node) Node { return div(num(1), add(num(1), pow(u, num(2)))) },
+	"sinh":  func(u Node) Node { return call("cosh", u) },
+	"cosh":  func(u Node) Node { return call("sinh", u) },
+	"tanh":  func(u Node) Node { return div(num(1), pow(call("cosh", u), num(2))) },
+	"asinh": func(u Node) Node { return div(num(1), call("sqrt", add(pow(u, num(2)), num(1)))) },
+	"acosh": func(u Node) Node { return div(num(1), call("sqrt", sub(pow(u, num(2)), num(1)))) },
+	"atanh": func(u Node) Node { return div(num(1), sub(num(1), pow(u, num(2)))) },
+	"exp":   func(u Node) Node { return call("exp", u) },
+	"ln":    func(u Node) Node { return div(num(1), u) },
+	"log10": func(u Node) Node { return div(num(1), mul(u, call("ln", num(10)))) },
+	"sqrt":  func(u Node) Node { return div(num(1), mul(num(2), call("sqrt", u))) },
+	"abs":   func(u Node) Node { return div(u, call("abs", u)) },
+}
+
+// operatorFuncs are the functions equivalent to an operator.
+var operatorFuncs = map[string]byte{
+	"add": '+', "subtract": '-', "multiply": '*', "divide": '/', "power": '^',
+}
+
+// Derivative returns the derivative of n with respect to the variable x,
+// unsimplified. Functions of angles are taken in radians. It fails with
+// ErrNoDerivative on functions of x without a differentiation rule, such as
+// user-defined ones.
+func Derivative(n Node, x string) (Node, error) {
+	if !dependsOn(n, x) {
+		return num(0), nil
+	}
+	switch n := n.(type) {
+	case *Var:
+		return num(1), nil
+	case *Unary:
+		dx, err := Derivative(n.X, x)
+		if err != nil || n.Op == '+' {
+			return dx, err
+		}
+		return neg(dx), nil
+	case *Binary:
+		return binaryDerivative(n, x)
+	case *Call:
+		return callDerivative(n, x)
+	}
+	return nil, fmt.Errorf("%w: %T", ErrNoDerivative, n)
+}
+
+func binaryDerivative(n *Binary, x string) (Node, error) {
+	dx, err := Derivative(n.X, x)
+	if err != nil {
+		return nil, err
+	}
+	dy, err := Derivative(n.Y, x)
+	if err != nil {
+		return nil, err
+	}
+	u, v := n.X, n.Y
+	switch n.Op {
+	case '+', '-':
+		return &Binary{Op: n.Op, X: dx, Y: dy}, nil
+	case '*':
+		return add(mul(dx, v), mul(u, dy)), nil
+	case '/':
+		return div(sub(mul(dx, v), mul(u, dy)), pow(v, num(2))), nil
+	}
+	switch {
+	case !dependsOn(v, x):
+		return mul(mul(v, pow(u, sub(v, num(1)))), dx), nil
+	case !dependsOn(u, x):
+		return mul(mul(n, call("ln", u)), dy), nil
+	}
+	return mul(n, add(mul(dy, call("ln", u)), div(mul(v, dx), u))), nil
+}
+
+func callDerivative(n *Call, x string) (Node, error) {
+	if op, ok := operatorFuncs[n.Name]; ok && len(n.Args) == 2 {
+		return Derivative(&Binary{Op: op, X: n.Args[0], Y: n.Args[1]}, x)
+	}
+	switch {
+	case n.Name == "log" && len(n.Args) == 2:
+		return Derivative(div(call("ln", n.Args[0]), call("ln", n.Args[1])), x)
+	case n.Name == "root" && len(n.Args) == 2:
+		// root(u, v)' = root(u, v) * (u'/(v*u) - v'*ln(u)/v^2), which keeps
+		// the real odd roots of negative u.
+		u, v := n.Args[0], n.Args[1]
+		du, err := Derivative(u, x)
+		if err != nil {
+			return nil, err
+		}
+		dv, err := Derivative(v, x)
+		if err != nil {
+			return nil, err
+		}
+		return mul(n, sub(div(du, mul(v, u)), div(mul(dv, call("ln", u)), pow(v, num(2))))), nil
+	}
+	rule, ok := chainRules[n.Name]
+	if !ok || len(n.Args) != 1 {
+		return nil, fmt.Errorf("%w for %s", ErrNoDerivative, n.Name)
+	}
+	du, err := Derivative(n.Args[0], x)
+	if err != nil {
+		return nil, err
+	}
+	return mul(rule(n.Args[0]), du), nil
+}
+
+// Check compiles n in e with its variables, other than constants, free,
+// as evaluating its text would.
+func (e *Env) Check(n Node) error {
+	var vars []string
+	walk(n, func(n Node) {
+		v, ok := n.(*Var)
+		if !ok || slices.Contains(vars, v.Name) {
+			return
+		}
+		if _, isConst := e.consts[v.Name]; !isConst {
+			vars = append(vars, v.Name)
+		}
+	})
+	_, err := e.Compile(Format(n), vars)
+	return err
+}
+
+// Size returns the number of nodes of n.
+func Size(n Node) int {
+	size := 0
+	walk(n, func(Node) { size++ })
+	return size
+}
+
+func dependsOn(n Node, x string) bool {
+	found := false
+	walk(n, func(n Node) {
+		if v, ok := n.(*Var); ok && v.Name == x {
+			found = true
+		}
+	})
+	return found
+}
+
+func num(v float64) Node                  { return &Num{Value: v} }
+func neg(x Node) Node                     { return &Unary{Op: '-', X: x} }
+func add(x, y Node) Node                  { return &Binary{Op: '+', X: x, Y: y} }
+func sub(x, y Node) Node                  { return &Binary{Op: '-', X: x, Y: y} }
+func mul(x, y Node) Node                  { return &Binary{Op: '*', X: x, Y: y} }
+func div(x, y Node) Node                  { return &Binary{Op: '/', X: x, Y: y} }
+func pow(x, y Node) Node                  { return &Binary{Op: '^', X: x, Y: y} }
+func call(name string, args ...Node) Node { return &Call{Name: name, Args: args} }
+
+// Simplify rewrites n into an equivalent and usually smaller expression:
+// constants are folded, exactly for integers and their fractions, like
+// terms and powers of the same base are combined, and identities such as
+// x+0, x*1, x*0, x^1 and x^0 are eliminated. Like terms cancel without
+// regard to domains, so x/x simplifies to 1.
+func Simplify(n Node) Node {
+	text := Format(n)
+	for range maxSimplifyPasses {
+		n = simplify(n)
+		next := Format(n)
+		if next == text {
+			break
+		}
+		text = next
+	}
+	return n
+}
+
+func simplify(n Node) Node {
+	switch n := n.(type) {
+	case *Unary:
+		x := simplify(n.X)
+		if n.Op == '+' {
+			return x
+		}
+		return negate(x)
+	case *Binary:
+		x, y := simplify(n.X), simplify(n.Y)
+		switch n.Op {
+		case '+', '-':
+			var s sum
+			s.add(x, 1)
+			s.add(y, map[byte]int{'+': 1, '-': -1}[n.Op])
+			return s.node()
+		case '/':
+			if c, ok := constantOf(y); ok && c.isZero() {
+				return div(x, y)
+			}
+		case '^':
+			return power(x, y)
+		}
+		p := newProduct()
+		p.add(x, false)
+		p.add(y, n.Op == '/')
+		return p.node()
+	case *Call:
+		c := &Call{Name: n.Name, Args: make([]Node, len(n.Args))}
+		for i, arg := range n.Args {
+			c.Args[i] = simplify(arg)
+		}
+		return simplifyCall(c)
+	}
+	return n
+}
+
+func negate(x Node) Node {
+	if u, ok := x.(*Unary); ok && u.Op == '-' {
+		return u.X
+	}
+	if c, ok := constantOf(x); ok {
+		return c.neg().node()
+	}
+	if b, ok := x.(*Binary); ok && (b.Op == '+' || b.Op == '-') {
+		var s sum
+		s.add(x, -1)
+		return s.node()
+	}
+	p := newProduct()
+	p.add(x, false)
+	p.coef = p.coef.neg()
+	return p.node()
+}
+
+// integerValues holds the arguments where functions take exact integer
+// values, which simplifyCall evaluates.
+var integerValues = map[string]map[int64]int64{
+	"sin": {0: 0}, "cos": {0: 1}, "tan": {0: 0},
+	"asin": {0: 0}, "acos": {1: 0}, "atan": {0: 0},
+	"sinh": {0: 0}, "cosh": {0: 1}, "tanh": {0: 0},
+	"asinh": {0: 0}, "acosh": {1: 0}, "atanh": {0: 0},
+	"exp": {0: 1}, "ln": {1: 0}, "log10": {1: 0, 10: 1},
+	"sqrt": {0: 0, 1: 1},
+}
+
+// simplifyCall evaluates functions where their value is an exact integer
+// and cancels ln(e), ln(exp(u)) and the absolute value of constants.
+func simplifyCall(c *Call) Node {
+	if len(c.Args) != 1 {
+		return c
+	}
+	arg := c.Args[0]
+	if inner, ok := arg.(*Call); ok && c.Name == "ln" && inner.Name == "exp" && len(inner.Args) == 1 {
+		return inner.Args[0]
+	}
+	if v, ok := arg.(*Var); ok && v.Name == "e" && c.Name == "ln" {
+		return num(1)
+	}
+	k, ok := constantOf(arg)
+	if !ok {
+		return c
+	}
+	if c.Name == "abs" {
+		return k.abs().node()
+	}
+	if k.r != nil && k.r.IsInt() && k.r.Num().IsInt64() {
+		if v, ok := integerValues[c.Name][k.r.Num().Int64()]; ok {
+			return num(float64(v))
+		}
+	}
+	return c
+}
+
+// power simplifies x^y, x and y being simplified.
+func power(x, y Node) Node {
+	ey, yConst := constantOf(y)
+	switch {
+	case yConst && ey.isZero():
+		return num(1)
+	case yConst && ey.isOne():
+		return x
+	}
+	if ex, ok := constantOf(x); ok {
+		switch {
+		case ex.isOne():
+			return num(1)
+		case ex.isZero() && yConst && ey.sign() > 0:
+			return num(0)
+		case yConst:
+			if r, ok := ex.pow(ey); ok {
+				return r.node()
+			}
+		}
+	}
+	// (u*v)^n is u^n*v^n and (u/v)^n is u^n/v^n for integers n.
+	if inner, ok := x.(*Binary); ok && (inner.Op == '*' || inner.Op == '/') && yConst && ey.isInteger() {
+		return simplify(&Binary{Op: inner.Op, X: power(inner.X, y), Y: power(inner.Y, y)})
+	}
+	// (u^a)^n is u^(a*n) for integers n.
+	if inner, ok := x.(*Binary); ok && inner.Op == '^' && yConst && ey.isInteger() {
+		return power(inner.X, simplify(mul(inner.Y, y)))
+	}
+	return pow(x, y)
+}
+
+// sum collects terms as coefficients of distinct non-constant parts, in
+// order of appearance, and a constant.
+type sum struct {
+	constant constant
+	terms    []term
+}
+
+type term struct {
+	coef constant
+	node Node
+	key  string
+}
+
+func (s *sum) add(n Node, sign int) {
+	switch n := n.(type) {
+	case *Binary:
+		if n.Op == '+' || n.Op == '-' {
+			s.add(n.X, sign)
+			if n.Op == '-' {
+				sign = -sign
+			}
+			s.add(n.Y, sign)
+			return
+		}
+	case *Unary:
+		if n.Op == '-' {
+			sign = -sign
+		}
+		s.add(n.X, sign)
+		return
+	}
+	// Constants whose sum overflows are kept as terms.
+	if c, ok := constantOf(n); ok {
+		if total, ok := s.constant.add(c.scale(sign)); ok {
+			s.constant = total
+			return
+		}
+	}
+	p := newProduct()
+	p.add(n, false)
+	coef := p.coef.scale(sign)
+	p.coef = exactInt(1)
+	rest, key := p.node(), p.key()
+	for i := range s.terms {
+		if s.terms[i].key != key {
+			continue
+		}
+		if total, ok := s.terms[i].coef.add(coef); ok {
+			s.terms[i].coef = total
+			return
+		}
+	}
+	s.terms = append(s.terms, term{coef: coef, node: rest, key: key})
+}
+
+func (s *sum) node() Node {
+	var out Node
+	push := func(n Node) {
+		if out == nil {
+			out = n
+		} else if x, ok := leadingNeg(n); ok {
+			out = sub(out, x)
+		} else {
+			out = add(out, n)
+		}
+	}
+	for _, t := range s.terms {
+		if t.coef.isZero() {
+			continue
+		}
+		p := newProduct()
+		p.add(t.node, false)
+		p.coef = t.coef
+		push(p.node())
+	}
+	if !s.constant.isZero() || out == nil {
+		push(s.constant.node())
+	}
+	return out
+}
+
+// product collects factors as a constant coefficient and exponents of
+// distinct bases, in order of appearance.
+type product struct {
+	coef    constant
+	factors []factor
+}
+
+func newProduct() *product { return &product{coef: exactInt(1)} }
+
+type factor struct {
+	base, exp Node
+	key       string
+}
+
+func (p *product) add(n Node, inverse bool) {
+	switch n := n.(type) {
+	case *Binary:
+		switch n.Op {
+		case '*', '/':
+			p.add(n.X, inverse)
+			p.add(n.Y, inverse != (n.Op == '/'))
+			return
+		case '^':
+			if _, ok := constantOf(n); !ok {
+				p.addFactor(n.X, n.Y, inverse)
+				return
+			}
+		}
+	case *Unary:
+		if n.Op == '-' {
+			p.coef = p.coef.neg()
+		}
+		p.add(n.X, inverse)
+		return
+	}
+	// Constants whose product overflows are kept as factors.
+	if c, ok := constantOf(n); ok && (!inverse || !c.isZero()) {
+		if inverse {
+			c, ok = c.inv()
+		}
+		if ok {
+			if coef, ok := p.coef.mul(c); ok {
+				p.coef = coef
+				return
+			}
+		}
+	}
+	p.addFactor(n, num(1), inverse)
+}
+
+func (p *product) addFactor(base, exp Node, inverse bool) {
+	if inverse {
+		exp = negate(exp)
+	}
+	key := Format(base)
+	for i := range p.factors {
+		if p.factors[i].key == key {
+			p.factors[i].exp = simplify(add(p.factors[i].exp, exp))
+			return
+		}
+	}
+	p.factors = append(p.factors, factor{base: base, exp: exp, key: key})
+}
+
+// node writes the product as a numerator over a denominator, which holds
+// the factors of negative constant exponents and the denominator of the
+// coefficient, negated when the coefficient is negative.
+func (p *product) node() Node {
+	if p.coef.isZero() {
+		return num(0)
+	}
+	var numer, denom Node
+	push := func(to *Node, n Node) {
+		if *to == nil {
+			*to = n
+		} else {
+			*to = mul(*to, n)
+		}
+	}
+	coefNum, coefDen := p.coef.abs().split()
+	if coefNum != nil {
+		push(&numer, coefNum)
+	}
+	if coefDen != nil {
+		push(&denom, coefDen)
+	}
+	for _, f := range p.factors {
+		e, ok := constantOf(f.exp)
+		if ok && e.sign() < 0 {
+			push(&denom, power(f.base, e.neg().node()))
+		} else {
+			push(&numer, power(f.base, f.exp))
+		}
+	}
+	if numer == nil {
+		numer = num(1)
+	}
+	if p.coef.sign() < 0 {
+		numer = negateFirst(numer)
+	}
+	if denom != nil {
+		return div(numer, denom)
+	}
+	return numer
+}
+
+// key identifies the factors regardless of their order.
+func (p *product) key() string {
+	keys := make([]string, len(p.factors))
+	for i, f := range p.factors {
+		keys[i] = f.key + "^" + Format(f.exp)
+	}
+	slices.Sort(keys)
+	return strings.Join(keys, "*")
+}
+
+// negateFirst negates the first factor of a product, which prints as a
+// leading minus sign.
+func negateFirst(n Node) Node {
+	if m, ok := n.(*Binary); ok && m.Op == '*' {
+		return mul(negateFirst(m.X), m.Y)
+	}
+	return neg(n)
+}
+
+// leadingNeg strips the minus sign leading a product or quotient.
+func leadingNeg(n Node) (Node, bool) {
+	switch n := n.(type) {
+	case *Unary:
+		return n.X, n.Op == '-'
+	case *Binary:
+		if n.Op == '*' || n.Op == '/' {
+			if x, ok := leadingNeg(n.X); ok {
+				return &Binary{Op: n.Op, X: x, Y: n.Y}, true
+			}
+		}
+	}
+	return n, false
+}
+
+// constant is a folded constant, exact when r is not nil.
+type constant struct {
+	r *big.Rat
+	f float64
+}
+
+// maxExact bounds the numerators and denominators of exact constants,
+// beyond which they become floats.
+const maxExact = 1 << 53
+
+func exactInt(v int64) constant { return constant{r: big.NewRat(v, 1), f: float64(v)} }
+
+func exact(r *big.Rat) constant {
+	f, _ := r.Float64()
+	if r.Num().CmpAbs(big.NewInt(maxExact)) > 0 || r.Denom().Cmp(big.NewInt(maxExact)) > 0 {
+		return constant{f: f}
+	}
+	return constant{r: r, f: f}
+}
+
+func inexact(f float64) (constant, bool) {
+	return constant{f: f}.finite()
+}
+
+// finite returns c and whether it is finite, which folding requires so that
+// simplified expressions can be evaluated again.
+func (c constant) finite() (constant, bool) {
+	return c, !math.IsNaN(c.f) && !math.IsInf(c.f, 0)
+}
+
+// constantOf folds n when it involves numbers only. Folding stops at
+// operations that fail, such as division by zero.
+func constantOf(n Node) (constant, bool) {
+	switch n := n.(type) {
+	case *Num:
+		if n.Value == math.Trunc(n.Value) && math.Abs(n.Value) <= maxExact {
+			return exactInt(int64(n.Value)), true
+		}
+		return inexact(n.Value)
+	case *Unary:
+		c, ok := constantOf(n.X)
+		if n.Op == '-' {
+			c = c.neg()
+		}
+		return c, ok
+	case *Binary:
+		x, ok := constantOf(n.X)
+		if !ok {
+			return constant{}, false
+		}
+		y, ok := constantOf(n.Y)
+		if !ok {
+			return constant{}, false
+		}
+		switch n.Op {
+		case '+':
+			return x.add(y)
+		case '-':
+			return x.add(y.neg())
+		case '*':
+			return x.mul(y)
+		case '/':
+			if y.isZero() {
+				return constant{}, false
+			}
+			inv, ok := y.inv()
+			if !ok {
+				return constant{}, false
+			}
+			return x.mul(inv)
+		case '^':
+			return x.pow(y)
+		}
+	}
+	return constant{}, false
+}
+
+func (c constant) isZero() bool { return c.f == 0 }
+func (c constant) isOne() bool  { return c.f == 1 && (c.r == nil || c.r.Cmp(big.NewRat(1, 1)) == 0) }
+
+func (c constant) isInteger() bool {
+	if c.r != nil {
+		return c.r.IsInt()
+	}
+	return c.f == math.Trunc(c.f)
+}
+
+func (c constant) sign() int {
+	if c.r != nil {
+		return c.r.Sign()
+	}
+	switch {
+	case c.f < 0:
+		return -1
+	case c.f > 0:
+		return 1
+	}
+	return 0
+}
+
+func (c constant) neg() constant {
+	if c.r != nil {
+		return constant{r: new(big.Rat).Neg(c.r), f: -c.f}
+	}
+	return constant{f: -c.f}
+}
+
+func (c constant) abs() constant {
+	if c.sign() < 0 {
+		return c.neg()
+	}
+	return c
+}
+
+func (c constant) scale(sign int) constant {
+	if sign < 0 {
+		return c.neg()
+	}
+	return c
+}
+
+// add folds c+d, failing when the sum is not finite, as do mul and inv.
+func (c constant) add(d constant) (constant, bool) {
+	// The zero constant is the identity whether exact or not.
+	if c.r == nil && c.f == 0 {
+		return d, true
+	}
+	if d.r == nil && d.f == 0 {
+		return c, true
+	}
+	if c.r != nil && d.r != nil {
+		return exact(new(big.Rat).Add(c.r, d.r)).finite()
+	}
+	return inexact(c.f + d.f)
+}
+
+func (c constant) mul(d constant) (constant, bool) {
+	if c.r != nil && d.r != nil {
+		return exact(new(big.Rat).Mul(c.r, d.r)).finite()
+	}
+	return inexact(c.f * d.f)
+}
+
+// inv is 1/c for c other than 0.
+func (c constant) inv() (constant, bool) {
+	if c.r != nil {
+		return exact(new(big.Rat).Inv(c.r)).finite()
+	}
+	return inexact(1 / c.f)
+}
+
+// pow folds c^d, exactly for exact c and integer d. Roots of exact
+// constants are kept as written, as are powers that are not real or
+// finite.
+func (c constant) pow(d constant) (constant, bool) {
+	switch {
+	case c.isZero() && d.sign() <= 0:
+		return constant{}, false
+	case c.r != nil && d.r != nil && d.r.IsInt() && d.r.Num().IsInt64() && math.Abs(d.f) <= 64:
+		base, n := c.r, d.r.Num().Int64()
+		if n < 0 {
+			base, n = new(big.Rat).Inv(base), -n
+		}
+		r := big.NewRat(1, 1)
+		for range n {
+			r.Mul(r, base)
+		}
+		return exact(r).finite()
+	case !d.isInteger() && (c.r != nil || c.f < 0):
+		return constant{}, false
+	}
+	return inexact(math.Pow(c.f, d.f))
+}
+
+// node writes c as a number or a fraction of integers, negated when
+// negative.
+func (c constant) node() Node {
+	n, d := c.abs().split()
+	if n == nil {
+		n = num(1)
+	}
+	if c.sign() < 0 {
+		n = neg(n)
+	}
+	if d != nil {
+		return div(n, d)
+	}
+	return n
+}
+
+// split returns the numerator and denominator of a non-negative c, nil
+// when they are 1.
+func (c constant) split() (numer, denom Node) {
+	if c.r == nil {
+		if c.f == 1 {
+			return nil, nil
+		}
+		return num(c.f), nil
+	}
+	if !c.r.Num().IsInt64() || c.r.Num().Int64() != 1 {
+		numer = num(float64(c.r.Num().Int64()))
+	}
+	if !c.r.IsInt() {
+		denom = num(float64(c.r.Denom().Int64()))
+	}
+	return numer, denom
+}
@@ -0,0 +1,210 @@
+package expr
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestDerivative(t *testing.T) {
+	tests := []struct {
+		src, expected string
+	}{
+		{"5", "0"},
+		{"y^2", "0"},
+		{"x", "1"},
+		{"x^2*sin(x)", "2*x*sin(x) + x^2*cos(x)"},
+		{"x^3 + 2*x^2 - 5*x + 1", "3*x^2 + 4*x - 5"},
+		{"-x^2", "-2*x"},
+		{"1/x", "-1/x^2"},
+		{"(x + 1)/(x - 1)", "-2/(x - 1)^2"},
+		{"x^(1/3)", "1/(3*x^(2/3))"},
+		{"sqrt(x)", "1/(2*sqrt(x))"},
+		{"root(x, 3)", "root(x, 3)/(3*x)"},
+		{"exp(2*x)", "2*exp(2*x)"},
+		{"e^x", "e^x"},
+		{"2^x", "2^x*ln(2)"},
+		{"x^x", "x^x*(ln(x) + 1)"},
+		{"ln(x^2 + 1)", "2*x/(x^2 + 1)"},
+		{"log(x, 2)", "1/(ln(2)*x)"},
+		{"log10(x)", "1/(x*ln(10))"},
+		{"sin(x)^2 + cos(x)^2", "0"},
+		{"tan(x)", "1/cos(x)^2"},
+		{"atan(x)", "1/(x^2 + 1)"},
+		{"pi*x^2", "2*pi*x"},
+		{"power(x, 3) - multiply(2, x)", "3*x^2 - 2"},
+		{"floor(y)*x", "floor(y)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.src, func(t *testing.T) {
+			n, err := Parse(tt.src)
+			if err != nil {
+				t.Fatal(err)
+			}
+			d, err := Derivative(n, "x")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := Format(Simplify(d)); got != tt.expected {
+				t.Errorf("d/dx %s = %s, want %s", tt.src, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDerivativeErrors(t *testing.T) {
+	for _, src := range []string{"floor(x)", "fee(x)", "min(x, 1)", "sin(x, 1)"} {
+		n, err := Parse(src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Derivative(n, "x"); !errors.Is(err, ErrNoDerivative) {
+			t.Errorf("d/dx %s error = %v, want %v", src, err, ErrNoDerivative)
+		}
+	}
+}
+
+// TestDerivativeEvaluates checks that simplified derivatives compile in the
+// environment and agree with central differences.
+func TestDerivativeEvaluates(t *testing.T) {
+	env := newTestEnv()
+	srcs := []string{
+		"x^2*sin(x)", "x^(1/3)", "x^(-2/3)", "root(x, 5)", "exp(-x^2/2)", "ln(x^2 + 1)",
+		"x^x", "atan(x/2)", "asin(x/3)", "acos(x/4)", "cosh(x)*tanh(x)", "asinh(x) + atanh(x/9)",
+		"abs(x - 10)", "(3*x - 1)/(x^2 + 1)^2", "log(x, 3) + log10(x)", "sqrt(x^2 + 1)",
+	}
+	for _, src := range srcs {
+		t.Run(src, func(t *testing.T) {
+			n, err := Parse(src)
+			if err != nil {
+				t.Fatal(err)
+			}
+			d, err := Derivative(n, "x")
+			if err != nil {
+				t.Fatal(err)
+			}
+			f, err := env.Compile(src, []string{"x"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			df, err := env.Compile(Format(Simplify(d)), []string{"x"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, x := range []float64{-2.5, 0.7, 1.3, 2} {
+				if x < 0 && (src == "x^x" || src == "log(x, 3) + log10(x)" || src == "acos(x/4)" && x < -4) {
+					continue
+				}
+				const h = 1e-5
+				hi, err1 := f.Eval([]float64{x + h})
+				lo, err2 := f.Eval([]float64{x - h})
+				got, err3 := df.Eval([]float64{x})
+				if err := errors.Join(err1, err2, err3); err != nil {
+					t.Fatalf("at %g: %v", x, err)
+				}
+				if want := (hi - lo) / (2 * h); math.Abs(got-want) > 1e-6*math.Max(1, math.Abs(want)) {
+					t.Errorf("at %g: %s = %v, want %v", x, Format(Simplify(d)), got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestSimplify(t *testing.T) {
+	tests := []struct {
+		src, expected string
+	}{
+		{"1 + 2*3", "7"},
+		{"1/3 + 1/6", "1/2"},
+		{"2/4", "1/2"},
+		{"-(1/3) - 1", "-4/3"},
+		{"0.5 + 0.25", "0.75"},
+		{"2^10", "1024"},
+		{"2^-2", "1/4"},
+		{"1/0", "1/0"},
+		{"x + 0", "x"},
+		{"0 - x", "-x"},
+		{"x*1", "x"},
+		{"x*0 + y", "y"},
+		{"x/1", "x"},
+		{"x^1", "x"},
+		{"x^0", "1"},
+		{"1^x", "1"},
+		{"--x", "x"},
+		{"+x", "x"},
+		{"x + x", "2*x"},
+		{"3*x - x - 2*x", "0"},
+		{"2*x*y + y*x", "3*x*y"},
+		{"x/3 + x/6", "x/2"},
+		{"x*x*x", "x^3"},
+		{"x^2*x^-3", "1/x"},
+		{"x^a*x^b", "x^(a + b)"},
+		{"(x^2)^3", "x^6"},
+		{"(2*x)^2", "4*x^2"},
+		{"(x/y)^-1", "y/x"},
+		{"x^(1/2)*x^(1/2)", "x"},
+		{"1 + x - 1", "x"},
+		{"-(x - y)", "-x + y"},
+		{"-(a*b)", "-a*b"},
+		{"sin(0) + cos(0) + ln(1) + exp(0) + ln(e)", "3"},
+		{"ln(exp(x))", "x"},
+		{"abs(-3)", "3"},
+		{"sqrt(2)", "sqrt(2)"},
+		{"2^(1/2)", "2^(1/2)"},
+		{"max(x + x, 1)", "max(2*x, 1)"},
+		// Constants are not folded to infinities, which do not parse.
+		{"1e308*10", "1e+308*10"},
+		{"x*1e308*10", "1e+308*x*10"},
+		{"1e308 + 1e308", "1e+308 + 1e+308"},
+		{"1e308*x + 1e308*x", "1e+308*x + 1e+308*x"},
+		{"1/1e-320", "1/1e-320"},
+		{"10^400", "10^400"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.src, func(t *testing.T) {
+			n, err := Parse(tt.src)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := Format(Simplify(n))
+			if got != tt.expected {
+				t.Errorf("Simplify(%s) = %s, want %s", tt.src, got, tt.expected)
+			}
+			if _, err := Parse(got); err != nil {
+				t.Errorf("Parse(Simplify(%s)) error = %v", tt.src, err)
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	env := newTestEnv()
+	tests := []struct {
+		src       string
+		expectErr error
+	}{
+		{"a*x^2 + b + pi*e", nil},
+		{"sin(x)^(1/3)", nil},
+		{"fee(x)", ErrUnknownFunction},
+		{"sqrt(x, y)", ErrArgumentCount},
+	}
+	for _, tt := range tests {
+		n, err := Parse(tt.src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := env.Check(n); !errors.Is(err, tt.expectErr) {
+			t.Errorf("Check(%s) = %v, want %v", tt.src, err, tt.expectErr)
+		}
+	}
+}
+
+func TestSize(t *testing.T) {
+	n, err := Parse("-x^2 + max(1, y)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := Size(n); got != 8 {
+		t.Errorf("Size = %d, want 8", got)
+	}
+}
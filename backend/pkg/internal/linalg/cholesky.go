@@ -0,0 +1,46 @@
+package linalg
+
+import (
+	"fmt"
+	"math"
+)
+
+// Cholesky returns the lower triangular L with a = L Lᵀ of a symmetric
+// positive definite matrix a. It is direct and takes no iterations.
+func Cholesky(a Matrix) (Matrix, error) {
+	if err := checkSymmetric(a); err != nil {
+		return Matrix{}, err
+	}
+	n := a.rows
+	l := Zeros(n, n)
+	for j := range n {
+		lj := l.data[j*n : j*n+j]
+		d := a.At(j, j) - dot(lj, lj)
+		if !(d > 0) {
+			return Matrix{}, fmt.Errorf("%w: pivot %d is %g", ErrNotPositiveDefinite, j, d)
+		}
+		ljj := math.Sqrt(d)
+		l.set(j, j, ljj)
+		for i := j + 1; i < n; i++ {
+			l.set(i, j, (a.At(i, j)-dot(l.data[i*n:i*n+j], lj))/ljj)
+		}
+	}
+	return l, nil
+}
+
+// checkSymmetric fails unless a is square and equal to its transpose up to
+// rounding.
+func checkSymmetric(a Matrix) error {
+	if a.rows != a.cols {
+		return fmt.Errorf("%w: got %s", ErrNotSquare, a.dims())
+	}
+	tol := 100 * eps * a.maxAbs()
+	for i := range a.rows {
+		for j := range i {
+			if math.Abs(a.At(i, j)-a.At(j, i)) > tol {
+				return fmt.Errorf("%w: elements [%d][%d] and [%d][%d] differ", ErrNotSymmetric, i, j, j, i)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package linalg
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCholesky(t *testing.T) {
+	a := mustNew(t, [][]float64{{4, 12, -16}, {12, 37, -43}, {-16, -43, 98}})
+	l, err := Cholesky(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertMatrix(t, l, [][]float64{{2, 0, 0}, {6, 1, 0}, {-8, 5, 3}}, 1e-14)
+
+	r := randomSymmetric(1, 30)
+	l, err = Cholesky(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertProduct(t, r, 1e-14, l, l.Transpose())
+
+	tests := []struct {
+		name      string
+		rows      [][]float64
+		expectErr error
+	}{
+		{"not square", [][]float64{{1, 2}}, ErrNotSquare},
+		{"not symmetric", [][]float64{{1, 2}, {3, 4}}, ErrNotSymmetric},
+		{"indefinite", [][]float64{{1, 2}, {2, 1}}, ErrNotPositiveDefinite},
+		{"semidefinite", [][]float64{{1, 1}, {1, 1}}, ErrNotPositiveDefinite},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Cholesky(mustNew(t, tt.rows)); !errors.Is(err, tt.expectErr) {
+				t.Errorf("Cholesky error = %v, want %v", err, tt.expectErr)
+			}
+		})
+	}
+}
+
+func BenchmarkCholesky200(b *testing.B) {
+	a := randomSymmetric(1, 200)
+	for b.Loop() {
+		if _, err := Cholesky(a); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
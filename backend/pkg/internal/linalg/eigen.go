@@ -0,0 +1,267 @@
+package linalg
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// MaxIterations is the largest iteration limit a caller may give the
+// iterative decompositions.
+const MaxIterations = 100_000
+
+// Eigen is the decomposition A = V diag(Values) Vᵀ of a symmetric matrix
+// A. Values are in increasing order and the columns of Vectors are the
+// matching orthonormal eigenvectors.
+type Eigen struct {
+	Values  Vector
+	Vectors Matrix
+	// Iterations is the number of implicit QL iterations taken.
+	Iterations int
+}
+
+// SymmetricEigen computes the eigenvalues and eigenvectors of the
+// symmetric matrix a. It reduces a to tridiagonal form with Householder
+// reflections and diagonalizes that with the implicit QL method, failing
+// with ErrNoConvergence after maxIterations QL iterations, or 30 per row
+// when 0, with calculator.ErrOverflow when eigenvalues are not finite and
+// with ErrTimeout when ctx is done, which it checks for every row of the
+// reduction and every QL iteration.
+func SymmetricEigen(ctx context.Context, a Matrix, maxIterations int) (Eigen, error) {
+	if err := checkSymmetric(a); err != nil {
+		return Eigen{}, err
+	}
+	n := a.rows
+	limit, err := iterationLimit(maxIterations, 30*n)
+	if err != nil {
+		return Eigen{}, err
+	}
+	// a is scaled by a power of two, exactly, so that the reduction cannot
+	// overflow, and the eigenvalues scaled back.
+	v := a.clone()
+	_, scale := math.Frexp(a.maxAbs())
+	for i, x := range v.data {
+		v.data[i] = math.Ldexp(x, -scale)
+	}
+	d, e := make([]float64, n), make([]float64, n)
+	if err := tridiagonalize(ctx, v, d, e); err != nil {
+		return Eigen{}, err
+	}
+	vt := v.Transpose()
+	iterations, err := diagonalize(ctx, vt, d, e, limit)
+	if err != nil {
+		return Eigen{}, err
+	}
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(i, j int) int { return cmp.Compare(d[i], d[j]) })
+	eig := Eigen{Values: make(Vector, n), Vectors: Zeros(n, n), Iterations: iterations}
+	for k, i := range order {
+		eig.Values[k] = math.Ldexp(d[i], scale)
+		for r := range n {
+			eig.Vectors.set(r, k, vt.At(i, r))
+		}
+	}
+	normalizeSigns(eig.Vectors)
+	if _, err := eig.Values.checked(); err != nil {
+		return Eigen{}, err
+	}
+	return eig, nil
+}
+
+func iterationLimit(limit, def int) (int, error) {
+	switch {
+	case limit == 0:
+		return def, nil
+	case limit < 0 || limit > MaxIterations:
+		return 0, fmt.Errorf("%w: %d, want 1 to %d", ErrInvalidLimit, limit, MaxIterations)
+	}
+	return limit, nil
+}
+
+// tridiagonalize reduces the symmetric matrix v to a tridiagonal one with
+// diagonal d and subdiagonal e[1:], replacing v with the orthogonal
+// transformation. This is tred2 from EISPACK, by way of JAMA.
+func tridiagonalize(ctx context.Context, v Matrix, d, e []float64) error {
+	n := v.rows
+	for j := range n {
+		d[j] = v.At(n-1, j)
+	}
+	for i := n - 1; i > 0; i-- {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("%w: reducing to tridiagonal form", ErrTimeout)
+		}
+		scale, h := 0.0, 0.0
+		for k := range i {
+			scale += math.Abs(d[k])
+		}
+		if scale == 0 {
+			e[i] = d[i-1]
+			for j := range i {
+				d[j] = v.At(i-1, j)
+				v.set(i, j, 0)
+				v.set(j, i, 0)
+			}
+			d[i] = h
+			continue
+		}
+		for k := range i {
+			d[k] /= scale
+			h += d[k] * d[k]
+		}
+		f := d[i-1]
+		g := math.Sqrt(h)
+		if f > 0 {
+			g = -g
+		}
+		e[i] = scale * g
+		h -= f * g
+		d[i-1] = f - g
+		clear(e[:i])
+		for j := range i {
+			f = d[j]
+			v.set(j, i, f)
+			g = e[j] + v.At(j, j)*f
+			for k := j + 1; k < i; k++ {
+				g += v.At(k, j) * d[k]
+				e[k] += v.At(k, j) * f
+			}
+			e[j] = g
+		}
+		f = 0
+		for j := range i {
+			e[j] /= h
+			f += e[j] * d[j]
+		}
+		hh := f / (h + h)
+		for j := range i {
+			e[j] -= hh * d[j]
+		}
+		for j := range i {
+			f, g = d[j], e[j]
+			for k := j; k < i; k++ {
+				v.set(k, j, v.At(k, j)-(f*e[k]+g*d[k]))
+			}
+			d[j] = v.At(i-1, j)
+			v.set(i, j, 0)
+		}
+		d[i] = h
+	}
+	// Accumulate the transformations.
+	for i := range n - 1 {
+		v.set(n-1, i, v.At(i, i))
+		v.set(i, i, 1)
+		if h := d[i+1]; h != 0 {
+			for k := 0; k <= i; k++ {
+				d[k] = v.At(k, i+1) / h
+			}
+			for j := 0; j <= i; j++ {
+				g := 0.0
+				for k := 0; k <= i; k++ {
+					g += v.At(k, i+1) * v.At(k, j)
+				}
+				for k := 0; k <= i; k++ {
+					v.set(k, j, v.At(k, j)-g*d[k])
+				}
+			}
+		}
+		for k := 0; k <= i; k++ {
+			v.set(k, i+1, 0)
+		}
+	}
+	for j := range n {
+		d[j] = v.At(n-1, j)
+		v.set(n-1, j, 0)
+	}
+	v.set(n-1, n-1, 1)
+	e[0] = 0
+	return nil
+}
+
+// diagonalize finds the eigenvalues d of the tridiagonal matrix from
+// tridiagonalize with the implicit QL method, rotating the rows of vt,
+// which hold the transformation by rows. This is tql2 from EISPACK, by way
+// of JAMA. It returns the number of iterations taken.
+func diagonalize(ctx context.Context, vt Matrix, d, e []float64, limit int) (int, error) {
+	n := vt.rows
+	copy(e, e[1:])
+	e[n-1] = 0
+	f, tst1 := 0.0, 0.0
+	iterations := 0
+	for l := range n {
+		tst1 = math.Max(tst1, math.Abs(d[l])+math.Abs(e[l]))
+		m := l
+		for m < n-1 && math.Abs(e[m]) > eps*tst1 {
+			m++
+		}
+		for m > l && math.Abs(e[l]) > eps*tst1 {
+			if iterations == limit {
+				return 0, fmt.Errorf("%w: after %d iterations", ErrNoConvergence, limit)
+			}
+			if err := ctx.Err(); err != nil {
+				return 0, fmt.Errorf("%w: after %d iterations", ErrTimeout, iterations)
+			}
+			iterations++
+			g := d[l]
+			p := (d[l+1] - g) / (2 * e[l])
+			r := math.Hypot(p, 1)
+			if p < 0 {
+				r = -r
+			}
+			d[l] = e[l] / (p + r)
+			d[l+1] = e[l] * (p + r)
+			dl1 := d[l+1]
+			h := g - d[l]
+			for i := l + 2; i < n; i++ {
+				d[i] -= h
+			}
+			f += h
+			p = d[m]
+			c, c2, c3 := 1.0, 1.0, 1.0
+			el1 := e[l+1]
+			s, s2 := 0.0, 0.0
+			for i := m - 1; i >= l; i-- {
+				c3, c2, s2 = c2, c, s
+				g = c * e[i]
+				h = c * p
+				r = math.Hypot(p, e[i])
+				e[i+1] = s * r
+				s, c = e[i]/r, p/r
+				p = c*d[i] - s*g
+				d[i+1] = h + s*(c*g+s*d[i])
+				ri, rj := vt.data[i*n:(i+1)*n], vt.data[(i+1)*n:(i+2)*n]
+				for k := range ri {
+					ri[k], rj[k] = c*ri[k]-s*rj[k], s*ri[k]+c*rj[k]
+				}
+			}
+			p = -s * s2 * c3 * el1 * e[l] / dl1
+			e[l] = s * p
+			d[l] = c * p
+		}
+		d[l] += f
+		e[l] = 0
+	}
+	return iterations, nil
+}
+
+// normalizeSigns flips columns of m so that their largest element is
+// positive, making vectors that are only defined up to sign deterministic.
+func normalizeSigns(m Matrix) {
+	for j := range m.cols {
+		big := 0.0
+		for i := range m.rows {
+			if math.Abs(m.At(i, j)) > math.Abs(big) {
+				big = m.At(i, j)
+			}
+		}
+		if big < 0 {
+			for i := range m.rows {
+				m.set(i, j, -m.At(i, j))
+			}
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package linalg
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/igorgatis/sezzle/backend/pkg/internal/calculator"
+)
+
+func TestSymmetricEigen(t *testing.T) {
+	e, err := SymmetricEigen(context.Background(), mustNew(t, [][]float64{{2, 1}, {1, 2}}), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(e.Values[0]-1) > 1e-15 || math.Abs(e.Values[1]-3) > 1e-15 {
+		t.Errorf("values = %v, want [1 3]", e.Values)
+	}
+	r := math.Sqrt2 / 2
+	assertMatrix(t, e.Vectors, [][]float64{{r, r}, {-r, r}}, 1e-15)
+	if e.Iterations < 1 {
+		t.Errorf("iterations = %d", e.Iterations)
+	}
+
+	if e, _ := SymmetricEigen(context.Background(), Identity(3), 0); e.Iterations != 0 {
+		t.Errorf("diagonal matrix took %d iterations", e.Iterations)
+	}
+
+	for _, n := range []int{1, 5, 40} {
+		a := randomSymmetric(uint64(n), n)
+		e, err := SymmetricEigen(context.Background(), a, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 1; i < n; i++ {
+			if e.Values[i] < e.Values[i-1] {
+				t.Fatalf("values %v not increasing", e.Values)
+			}
+		}
+		d := Zeros(n, n)
+		for i, v := range e.Values {
+			d.set(i, i, v)
+		}
+		assertOrthonormal(t, e.Vectors, 1e-13)
+		assertProduct(t, a, 1e-13, e.Vectors, d, e.Vectors.Transpose())
+	}
+
+	// Large elements are scaled so that the reduction does not overflow.
+	e, err = SymmetricEigen(context.Background(), mustNew(t, [][]float64{{1e307, 1e307}, {1e307, 1e307}}), 0)
+	if err != nil || math.Abs(e.Values[0]) > 1e-14*2e307 || math.Abs(e.Values[1]-2e307) > 1e-14*2e307 {
+		t.Errorf("large SymmetricEigen = %v, %v, want [0 2e307]", e.Values, err)
+	}
+
+	tests := []struct {
+		name      string
+		a         Matrix
+		limit     int
+		expectErr error
+	}{
+		{"not symmetric", mustNew(t, [][]float64{{1, 2}, {3, 4}}), 0, ErrNotSymmetric},
+		{"not square", mustNew(t, [][]float64{{1, 2}}), 0, ErrNotSquare},
+		{"iteration limit", randomSymmetric(1, 20), 1, ErrNoConvergence},
+		{"negative limit", Identity(2), -1, ErrInvalidLimit},
+		{"limit too large", Identity(2), MaxIterations + 1, ErrInvalidLimit},
+		{"overflow", mustNew(t, [][]float64{{1e308, 1e308}, {1e308, 1e308}}), 0, calculator.ErrOverflow},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := SymmetricEigen(context.Background(), tt.a, tt.limit); !errors.Is(err, tt.expectErr) {
+				t.Errorf("SymmetricEigen error = %v, want %v", err, tt.expectErr)
+			}
+		})
+	}
+}
+
+func TestSymmetricEigenTimeout(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := SymmetricEigen(ctx, randomSymmetric(1, 20), 0); !errors.Is(err, ErrTimeout) {
+		t.Errorf("SymmetricEigen error = %v, want %v", err, ErrTimeout)
+	}
+}
+
+func BenchmarkSymmetricEigen200(b *testing.B) {
+	a := randomSymmetric(1, 200)
+	for b.Loop() {
+		e, err := SymmetricEigen(context.Background(), a, 0)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.ReportMetric(float64(e.Iterations), "iterations")
+	}
+}
@@ -0,0 +1,117 @@
+package linalg
+
+import (
+	"fmt"
+	"math"
+)
+
+// LU is the factorization P A = L U of a square matrix A with partial
+// pivoting. L has a unit diagonal and shares storage with U.
+type LU struct {
+	lu   Matrix
+	perm []int
+	sign float64
+	// tol is the pivot magnitude at or below which A is singular.
+	tol float64
+}
+
+func Factorize(a Matrix) (LU, error) {
+	if a.rows != a.cols {
+		return LU{}, fmt.Errorf("%w: got %s", ErrNotSquare, a.dims())
+	}
+	n := a.rows
+	f := LU{lu: a.clone(), perm: make([]int, n), sign: 1, tol: float64(n) * eps * a.maxAbs()}
+	for i := range f.perm {
+		f.perm[i] = i
+	}
+	m := f.lu
+	for k := range n {
+		p := k
+		for i := k + 1; i < n; i++ {
+			if math.Abs(m.At(i, k)) > math.Abs(m.At(p, k)) {
+				p = i
+			}
+		}
+		if p != k {
+			m.swapRows(p, k)
+			f.perm[p], f.perm[k] = f.perm[k], f.perm[p]
+			f.sign = -f.sign
+		}
+		pivot := m.At(k, k)
+		if pivot == 0 {
+			continue
+		}
+		for i := k + 1; i < n; i++ {
+			l := m.At(i, k) / pivot
+			m.set(i, k, l)
+			if l == 0 {
+				continue
+			}
+			for j := k + 1; j < n; j++ {
+				m.set(i, j, m.At(i, j)-l*m.At(k, j))
+			}
+		}
+	}
+	return f, nil
+}
+
+// Singular tells whether a pivot is negligible relative to the largest
+// element of A.
+func (f LU) Singular() bool {
+	for i := range f.lu.rows {
+		if math.Abs(f.lu.At(i, i)) <= f.tol {
+			return true
+		}
+	}
+	return false
+}
+
+func (f LU) Det() float64 {
+	d := f.sign
+	for i := range f.lu.rows {
+		d *= f.lu.At(i, i)
+	}
+	return d
+}
+
+// Solve returns x such that A x = b, failing with ErrSingular when A is
+// singular.
+func (f LU) Solve(b Vector) (Vector, error) {
+	n := f.lu.rows
+	if len(b) != n {
+		return nil, fmt.Errorf("%w: %s system with %d right-hand side values", ErrDimensionMismatch, f.lu.dims(), len(b))
+	}
+	if f.Singular() {
+		return nil, ErrSingular
+	}
+	x := make(Vector, n)
+	for i, p := range f.perm {
+		x[i] = b[p]
+	}
+	m := f.lu
+	for i := range n {
+		x[i] -= dot(m.data[i*n:i*n+i], x[:i])
+	}
+	for i := n - 1; i >= 0; i-- {
+		x[i] = (x[i] - dot(m.data[i*n+i+1:(i+1)*n], x[i+1:])) / m.At(i, i)
+	}
+	return x.checked()
+}
+
+func (f LU) Inverse() (Matrix, error) {
+	n := f.lu.rows
+	inv := Zeros(n, n)
+	e := make(Vector, n)
+	for j := range n {
+		clear(e)
+		e[j] = 1
+		col, err := f.Solve(e)
+		if err != nil {
+			return Matrix{}, err
+		}
+		for i, v := range col {
+			inv.set(i, j, v)
+		}
+	}
+	return inv, nil
+}
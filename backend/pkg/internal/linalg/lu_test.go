@@ -0,0 +1,64 @@
+package linalg
+
+import (
+	"errors"
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestSolve(t *testing.T) {
+	tests := []struct {
+		name      string
+		a         [][]float64
+		b         Vector
+		expected  Vector
+		expectErr error
+	}{
+		{"2×2", [][]float64{{2, 1}, {1, 3}}, Vector{3, 5}, Vector{0.8, 1.4}, nil},
+		{"zero pivot", [][]float64{{0, 2}, {3, 0}}, Vector{4, 9}, Vector{3, 2}, nil},
+		{"3×3", [][]float64{{2, 1, -1}, {-3, -1, 2}, {-2, 1, 2}}, Vector{8, -11, -3}, Vector{2, 3, -1}, nil},
+		{"singular", [][]float64{{1, 2}, {2, 4}}, Vector{1, 2}, nil, ErrSingular},
+		{"nearly singular", [][]float64{{1, 1}, {1, 1 + 1e-17}}, Vector{1, 2}, nil, ErrSingular},
+		{"not square", [][]float64{{1, 2}}, Vector{1}, nil, ErrNotSquare},
+		{"wrong length", [][]float64{{1, 0}, {0, 1}}, Vector{1, 2, 3}, nil, ErrDimensionMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x, err := Solve(mustNew(t, tt.a), tt.b)
+			if !errors.Is(err, tt.expectErr) {
+				t.Fatalf("Solve error = %v, want %v", err, tt.expectErr)
+			}
+			for i := range tt.expected {
+				if math.Abs(x[i]-tt.expected[i]) > 1e-14 {
+					t.Fatalf("Solve = %v, want %v", x, tt.expected)
+				}
+			}
+		})
+	}
+}
+
+// TestSolveResidual checks that solving a random, well-conditioned system
+// leaves a small residual.
+func TestSolveResidual(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	n := 50
+	a := Zeros(n, n)
+	for i := range a.data {
+		a.data[i] = r.NormFloat64()
+	}
+	b := make(Vector, n)
+	for i := range b {
+		b[i] = r.NormFloat64()
+	}
+	x, err := Solve(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ax, _ := a.MulVec(x)
+	for i := range b {
+		if math.Abs(ax[i]-b[i]) > 1e-10 {
+			t.Fatalf("residual %d = %v", i, ax[i]-b[i])
+		}
+	}
+}
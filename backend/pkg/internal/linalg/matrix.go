@@ -0,0 +1,252 @@
+// Package linalg implements dense matrix and vector algebra.
+package linalg
+
+import (
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/igorgatis/sezzle/backend/pkg/internal/calculator"
+)
+
+// MaxDim bounds the rows and columns of a matrix and the length of a
+// vector.
+const MaxDim = 1000
+
+var (
+	ErrInvalidMatrix       = &calculator.Error{Code: "invalid_matrix", Message: "invalid matrix"}
+	ErrDimensionMismatch   = &calculator.Error{Code: "dimension_mismatch", Message: "dimensions do not match"}
+	ErrNotSquare           = &calculator.Error{Code: "not_square", Message: "matrix must be square"}
+	ErrSingular            = &calculator.Error{Code: "singular_matrix", Message: "matrix is singular"}
+	ErrNotSymmetric        = &calculator.Error{Code: "not_symmetric", Message: "matrix must be symmetric"}
+	ErrNotPositiveDefinite = &calculator.Error{Code: "not_positive_definite", Message: "matrix is not positive definite"}
+	// ErrNoConvergence shares its code with calculator.ErrNoConvergence.
+	ErrNoConvergence = &calculator.Error{Code: "no_convergence", Message: "decomposition did not converge"}
+	ErrInvalidLimit  = &calculator.Error{Code: "invalid_iteration_limit", Message: "invalid iteration limit"}
+	// ErrTimeout shares its code with numeric.ErrTimeout.
+	ErrTimeout = &calculator.Error{Code: "timeout", Message: "computation timed out"}
+)
+
+const eps = 2.220446049250313e-16
+
+// Matrix is a dense matrix stored in row-major order. Operations return
+// new matrices and never modify their operands.
+type Matrix struct {
+	rows, cols int
+	data       []float64
+}
+
+// New returns the matrix with the given rows, which must be non-empty, of
+// equal length and finite.
+func New(rows [][]float64) (Matrix, error) {
+	if len(rows) == 0 || len(rows[0]) == 0 {
+		return Matrix{}, fmt.Errorf("%w: no elements", ErrInvalidMatrix)
+	}
+	// The shape is validated before allocating, as it comes from requests.
+	n, cols := len(rows), len(rows[0])
+	if n > MaxDim || cols > MaxDim {
+		return Matrix{}, fmt.Errorf("%w: %d×%d, at most %d×%d", ErrInvalidMatrix, n, cols, MaxDim, MaxDim)
+	}
+	for i, r := range rows {
+		if len(r) != cols {
+			return Matrix{}, fmt.Errorf("%w: row %d has %d elements, want %d", ErrInvalidMatrix, i, len(r), cols)
+		}
+		for j, v := range r {
+			if math.IsInf(v, 0) || math.IsNaN(v) {
+				return Matrix{}, fmt.Errorf("%w: element [%d][%d] is not finite", ErrInvalidMatrix, i, j)
+			}
+		}
+	}
+	m := Zeros(n, cols)
+	for i, r := range rows {
+		copy(m.data[i*cols:], r)
+	}
+	return m, nil
+}
+
+func Zeros(rows, cols int) Matrix {
+	return Matrix{rows: rows, cols: cols, data: make([]float64, rows*cols)}
+}
+
+func Identity(n int) Matrix {
+	m := Zeros(n, n)
+	for i := range n {
+		m.data[i*n+i] = 1
+	}
+	return m
+}
+
+func (m Matrix) Rows() int { return m.rows }
+func (m Matrix) Cols() int { return m.cols }
+
+func (m Matrix) At(i, j int) float64 {
+	return m.data[i*m.cols+j]
+}
+
+func (m Matrix) set(i, j int, v float64) {
+	m.data[i*m.cols+j] = v
+}
+
+// ToRows returns the elements of m as a slice of rows.
+func (m Matrix) ToRows() [][]float64 {
+	rows := make([][]float64, m.rows)
+	for i := range rows {
+		rows[i] = slices.Clone(m.data[i*m.cols : (i+1)*m.cols])
+	}
+	return rows
+}
+
+func (m Matrix) clone() Matrix {
+	return Matrix{rows: m.rows, cols: m.cols, data: slices.Clone(m.data)}
+}
+
+func (m Matrix) dims() string {
+	return fmt.Sprintf("%d×%d", m.rows, m.cols)
+}
+
+// checked fails with calculator.ErrOverflow when m has non-finite elements.
+func (m Matrix) checked() (Matrix, error) {
+	for _, v := range m.data {
+		if math.IsInf(v, 0) || math.IsNaN(v) {
+			return Matrix{}, calculator.ErrOverflow
+		}
+	}
+	return m, nil
+}
+
+// maxAbs returns the largest magnitude among the elements of m.
+func (m Matrix) maxAbs() float64 {
+	a := 0.0
+	for _, v := range m.data {
+		a = math.Max(a, math.Abs(v))
+	}
+	return a
+}
+
+func (a Matrix) Add(b Matrix) (Matrix, error) {
+	if a.rows != b.rows || a.cols != b.cols {
+		return Matrix{}, fmt.Errorf("%w: cannot add %s and %s", ErrDimensionMismatch, a.dims(), b.dims())
+	}
+	c := a.clone()
+	for i, v := range b.data {
+		c.data[i] += v
+	}
+	return c.checked()
+}
+
+func (a Matrix) Sub(b Matrix) (Matrix, error) {
+	if a.rows != b.rows || a.cols != b.cols {
+		return Matrix{}, fmt.Errorf("%w: cannot subtract %s from %s", ErrDimensionMismatch, b.dims(), a.dims())
+	}
+	c := a.clone()
+	for i, v := range b.data {
+		c.data[i] -= v
+	}
+	return c.checked()
+}
+
+func (a Matrix) Mul(b Matrix) (Matrix, error) {
+	if a.cols != b.rows {
+		return Matrix{}, fmt.Errorf("%w: cannot multiply %s by %s", ErrDimensionMismatch, a.dims(), b.dims())
+	}
+	c := Zeros(a.rows, b.cols)
+	for i := range a.rows {
+		row := c.data[i*c.cols : (i+1)*c.cols]
+		for k := range a.cols {
+			aik := a.data[i*a.cols+k]
+			if aik == 0 {
+				continue
+			}
+			for j, bkj := range b.data[k*b.cols : (k+1)*b.cols] {
+				row[j] += aik * bkj
+			}
+		}
+	}
+	return c.checked()
+}
+
+// MulVec returns the product of a and the column vector x.
+func (a Matrix) MulVec(x Vector) (Vector, error) {
+	if a.cols != len(x) {
+		return nil, fmt.Errorf("%w: cannot multiply %s by a vector of %d", ErrDimensionMismatch, a.dims(), len(x))
+	}
+	y := make(Vector, a.rows)
+	for i := range a.rows {
+		y[i] = dot(a.data[i*a.cols:(i+1)*a.cols], x)
+	}
+	return y.checked()
+}
+
+func (m Matrix) Transpose() Matrix {
+	t := Zeros(m.cols, m.rows)
+	for i := range m.rows {
+		for j := range m.cols {
+			t.set(j, i, m.At(i, j))
+		}
+	}
+	return t
+}
+
+func (m Matrix) Det() (float64, error) {
+	f, err := Factorize(m)
+	if err != nil {
+		return 0, err
+	}
+	// Adding 0 turns a -0 determinant into 0.
+	return finite(f.Det() + 0)
+}
+
+func (m Matrix) Inverse() (Matrix, error) {
+	f, err := Factorize(m)
+	if err != nil {
+		return Matrix{}, err
+	}
+	return f.Inverse()
+}
+
+// Rank returns the number of linearly independent rows of m, counting
+// pivots larger than a tolerance relative to the largest element.
+func (m Matrix) Rank() int {
+	a := m.clone()
+	tol := float64(max(a.rows, a.cols)) * eps * a.maxAbs()
+	rank := 0
+	for col := 0; col < a.cols && rank < a.rows; col++ {
+		p := rank
+		for i := rank + 1; i < a.rows; i++ {
+			if math.Abs(a.At(i, col)) > math.Abs(a.At(p, col)) {
+				p = i
+			}
+		}
+		if math.Abs(a.At(p, col)) <= tol {
+			continue
+		}
+		a.swapRows(p, rank)
+		for i := rank + 1; i < a.rows; i++ {
+			f := a.At(i, col) / a.At(rank, col)
+			for j := col; j < a.cols; j++ {
+				a.set(i, j, a.At(i, j)-f*a.At(rank, j))
+			}
+		}
+		rank++
+	}
+	return rank
+}
+
+func (m Matrix) swapRows(i, j int) {
+	if i == j {
+		return
+	}
+	ri, rj := m.data[i*m.cols:(i+1)*m.cols], m.data[j*m.cols:(j+1)*m.cols]
+	for k := range ri {
+		ri[k], rj[k] = rj[k], ri[k]
+	}
+}
+
+// Solve returns x such that a x = b.
+func Solve(a Matrix, b Vector) (Vector, error) {
+	f, err := Factorize(a)
+	if err != nil {
+		return nil, err
+	}
+	return f.Solve(b)
+}
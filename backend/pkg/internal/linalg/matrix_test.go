@@ -0,0 +1,217 @@
+package linalg
+
+import (
+	"errors"
+	"math"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/igorgatis/sezzle/backend/pkg/internal/calculator"
+)
+
+func mustNew(t testing.TB, rows [][]float64) Matrix {
+	t.Helper()
+	m, err := New(rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func assertMatrix(t *testing.T, got Matrix, want [][]float64, tol float64) {
+	t.Helper()
+	if got.Rows() != len(want) || got.Cols() != len(want[0]) {
+		t.Fatalf("got %s matrix %v, want %v", got.dims(), got.ToRows(), want)
+	}
+	for i, row := range want {
+		for j, v := range row {
+			if math.Abs(got.At(i, j)-v) > tol {
+				t.Fatalf("got %v, want %v", got.ToRows(), want)
+			}
+		}
+	}
+}
+
+func randomMatrix(seed uint64, rows, cols int) Matrix {
+	r := rand.New(rand.NewPCG(seed, seed))
+	m := Zeros(rows, cols)
+	for i := range m.data {
+		m.data[i] = r.NormFloat64()
+	}
+	return m
+}
+
+// randomSymmetric returns a random symmetric positive definite matrix.
+func randomSymmetric(seed uint64, n int) Matrix {
+	a := randomMatrix(seed, n, n)
+	m, _ := a.Transpose().Mul(a)
+	for i := range n {
+		m.set(i, i, m.At(i, i)+float64(n))
+	}
+	return m
+}
+
+// assertProduct checks that the product of factors is within tol of want,
+// relative to the largest element of want.
+func assertProduct(t *testing.T, want Matrix, tol float64, factors ...Matrix) {
+	t.Helper()
+	got := factors[0]
+	for _, f := range factors[1:] {
+		var err error
+		if got, err = got.Mul(f); err != nil {
+			t.Fatal(err)
+		}
+	}
+	assertMatrix(t, got, want.ToRows(), tol*math.Max(1, want.maxAbs()))
+}
+
+// assertOrthonormal checks that the columns of m are orthonormal.
+func assertOrthonormal(t *testing.T, m Matrix, tol float64) {
+	t.Helper()
+	assertProduct(t, Identity(m.cols), tol, m.Transpose(), m)
+}
+
+func TestNew(t *testing.T) {
+	tooLarge := make([][]float64, MaxDim+1)
+	for i := range tooLarge {
+		tooLarge[i] = []float64{1}
+	}
+	// Many empty rows after a full one must fail before allocating their
+	// product.
+	tall := make([][]float64, 1_000_000)
+	tall[0] = make([]float64, MaxDim)
+	tests := []struct {
+		name string
+		rows [][]float64
+	}{
+		{"empty", [][]float64{}},
+		{"empty row", [][]float64{{}}},
+		{"ragged", [][]float64{{1, 2}, {3}}},
+		{"infinite", [][]float64{{1, math.Inf(1)}}},
+		{"too large", tooLarge},
+		{"too tall", tall},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New(tt.rows); !errors.Is(err, ErrInvalidMatrix) {
+				t.Errorf("New error = %v, want %v", err, ErrInvalidMatrix)
+			}
+		})
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	a := mustNew(t, [][]float64{{1, 2, 3}, {4, 5, 6}})
+	b := mustNew(t, [][]float64{{7, 8}, {9, 10}, {11, 12}})
+
+	sum, err := a.Add(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertMatrix(t, sum, [][]float64{{2, 4, 6}, {8, 10, 12}}, 0)
+	diff, _ := sum.Sub(a)
+	assertMatrix(t, diff, a.ToRows(), 0)
+	prod, err := a.Mul(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertMatrix(t, prod, [][]float64{{58, 64}, {139, 154}}, 0)
+	assertMatrix(t, a.Transpose(), [][]float64{{1, 4}, {2, 5}, {3, 6}}, 0)
+	y, err := a.MulVec(Vector{1, 0, -1})
+	if err != nil || y[0] != -2 || y[1] != -2 {
+		t.Errorf("MulVec = %v, %v", y, err)
+	}
+
+	if _, err := a.Add(b); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("Add error = %v", err)
+	}
+	if _, err := a.Mul(a); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("Mul error = %v", err)
+	}
+	if _, err := a.MulVec(Vector{1}); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("MulVec error = %v", err)
+	}
+	big := mustNew(t, [][]float64{{1e200}})
+	if _, err := big.Mul(big); !errors.Is(err, calculator.ErrOverflow) {
+		t.Errorf("Mul overflow error = %v", err)
+	}
+}
+
+func TestDet(t *testing.T) {
+	tests := []struct {
+		name      string
+		rows      [][]float64
+		expected  float64
+		expectErr error
+	}{
+		{"1×1", [][]float64{{-3}}, -3, nil},
+		{"2×2", [][]float64{{4, 6}, {3, 8}}, 14, nil},
+		{"needs pivoting", [][]float64{{0, 1}, {1, 0}}, -1, nil},
+		{"3×3", [][]float64{{6, 1, 1}, {4, -2, 5}, {2, 8, 7}}, -306, nil},
+		{"singular", [][]float64{{1, 2}, {2, 4}}, 0, nil},
+		{"not square", [][]float64{{1, 2}}, 0, ErrNotSquare},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mustNew(t, tt.rows).Det()
+			if !errors.Is(err, tt.expectErr) {
+				t.Fatalf("Det error = %v, want %v", err, tt.expectErr)
+			}
+			if math.Abs(got-tt.expected) > 1e-12*math.Max(1, math.Abs(tt.expected)) {
+				t.Errorf("Det = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestInverse(t *testing.T) {
+	a := mustNew(t, [][]float64{{4, 7}, {2, 6}})
+	inv, err := a.Inverse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertMatrix(t, inv, [][]float64{{0.6, -0.7}, {-0.2, 0.4}}, 1e-15)
+
+	// The Hilbert matrix is badly conditioned but not singular.
+	h := Zeros(6, 6)
+	for i := range 6 {
+		for j := range 6 {
+			h.set(i, j, 1/float64(i+j+1))
+		}
+	}
+	hinv, err := h.Inverse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, _ := h.Mul(hinv)
+	assertMatrix(t, id, Identity(6).ToRows(), 1e-8)
+
+	for _, rows := range [][][]float64{
+		{{1, 2}, {2, 4}},
+		{{0, 0}, {0, 0}},
+		{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}},
+	} {
+		if _, err := mustNew(t, rows).Inverse(); !errors.Is(err, ErrSingular) {
+			t.Errorf("Inverse(%v) error = %v, want %v", rows, err, ErrSingular)
+		}
+	}
+}
+
+func TestRank(t *testing.T) {
+	tests := []struct {
+		rows [][]float64
+		rank int
+	}{
+		{[][]float64{{0, 0}, {0, 0}}, 0},
+		{[][]float64{{1, 2}, {2, 4}}, 1},
+		{[][]float64{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}}, 2},
+		{[][]float64{{1, 0}, {0, 1}, {1, 1}}, 2},
+		{[][]float64{{1, 2, 3, 4}}, 1},
+		{[][]float64{{0, 1, 2}, {0, 2, 4}, {1, 0, 0}}, 2},
+	}
+	for _, tt := range tests {
+		if got := mustNew(t, tt.rows).Rank(); got != tt.rank {
+			t.Errorf("Rank(%v) = %d, want %d", tt.rows, got, tt.rank)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package linalg
+
+import "math"
+
+// QR is the thin factorization A = Q R of an m×n matrix A, where k is
+// min(m, n), Q is m×k with orthonormal columns and R is k×n upper
+// triangular.
+type QR struct {
+	Q, R Matrix
+}
+
+// FactorizeQR computes the QR factorization of a with Householder
+// reflections. It is direct and fails only with calculator.ErrOverflow when
+// R does not fit in a float64.
+func FactorizeQR(a Matrix) (QR, error) {
+	m, n := a.rows, a.cols
+	k := min(m, n)
+	// The reflections act on columns, which are the contiguous rows of
+	// the transposes.
+	rt := a.Transpose()
+	// vs[j] is the Householder vector acting on elements j and below.
+	vs := make([][]float64, k)
+	for j := range k {
+		x := rt.data[j*m+j : (j+1)*m]
+		xn := norm(x)
+		if xn == 0 {
+			continue
+		}
+		// v is scaled by the norm of x, to a length between √2 and 2, so
+		// that reflecting cannot overflow where R does not.
+		v := make([]float64, len(x))
+		for i, xi := range x {
+			v[i] = xi / xn
+		}
+		v[0] += math.Copysign(1, x[0])
+		vs[j] = v
+		for col := j; col < n; col++ {
+			reflect(v, rt.data[col*m+j:(col+1)*m])
+		}
+	}
+	qt := Zeros(k, m)
+	for i := range k {
+		qt.set(i, i, 1)
+	}
+	for j := k - 1; j >= 0; j-- {
+		if vs[j] == nil {
+			continue
+		}
+		for col := range k {
+			reflect(vs[j], qt.data[col*m+j:(col+1)*m])
+		}
+	}
+	r := Zeros(k, n)
+	for i := range k {
+		for j := i; j < n; j++ {
+			r.set(i, j, rt.At(j, i))
+		}
+	}
+	if _, err := r.checked(); err != nil {
+		return QR{}, err
+	}
+	return QR{Q: qt.Transpose(), R: r}, nil
+}
+
+// reflect applies the reflection I - 2 v vᵀ / (vᵀ v) to x.
+func reflect(v, x []float64) {
+	s := dot(v, x) * (2 / dot(v, v))
+	for i, vi := range v {
+		x[i] -= s * vi
+	}
+}
@@ -0,0 +1,55 @@
+package linalg
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/igorgatis/sezzle/backend/pkg/internal/calculator"
+)
+
+func TestQR(t *testing.T) {
+	tests := []struct {
+		name string
+		a    Matrix
+	}{
+		{"square", mustNew(t, [][]float64{{12, -51, 4}, {6, 167, -68}, {-4, 24, -41}})},
+		{"tall", randomMatrix(1, 7, 3)},
+		{"wide", randomMatrix(2, 3, 7)},
+		{"rank deficient", mustNew(t, [][]float64{{1, 2}, {2, 4}, {3, 6}})},
+		{"zero column", mustNew(t, [][]float64{{0, 1}, {0, 2}})},
+		{"large", mustNew(t, [][]float64{{1e200, 0}, {1e200, 1}})},
+		{"small", mustNew(t, [][]float64{{1e-200, 0}, {1e-200, 1e-200}})},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := FactorizeQR(tt.a)
+			if err != nil {
+				t.Fatal(err)
+			}
+			k := min(tt.a.rows, tt.a.cols)
+			if f.Q.rows != tt.a.rows || f.Q.cols != k || f.R.rows != k || f.R.cols != tt.a.cols {
+				t.Fatalf("Q is %s and R is %s", f.Q.dims(), f.R.dims())
+			}
+			for i := range k {
+				for j := range i {
+					if f.R.At(i, j) != 0 {
+						t.Fatalf("R = %v is not upper triangular", f.R.ToRows())
+					}
+				}
+			}
+			assertOrthonormal(t, f.Q, 1e-14)
+			assertProduct(t, tt.a, 1e-14, f.Q, f.R)
+		})
+	}
+
+	if _, err := FactorizeQR(mustNew(t, [][]float64{{1.5e308}, {1.5e308}})); !errors.Is(err, calculator.ErrOverflow) {
+		t.Errorf("overflow error = %v", err)
+	}
+}
+
+func BenchmarkQR200(b *testing.B) {
+	a := randomMatrix(1, 200, 200)
+	for b.Loop() {
+		FactorizeQR(a)
+	}
+}
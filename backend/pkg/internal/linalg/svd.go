@@ -0,0 +1,164 @@
+package linalg
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// DefaultMaxSweeps is the sweep limit of FactorizeSVD when none is given.
+// Random 200×200 matrices take about 13.
+const DefaultMaxSweeps = 100
+
+// SVD is the thin singular value decomposition A = U diag(Values) Vᵀ of an
+// m×n matrix A, where k is min(m, n), U is m×k and V is n×k, both with
+// orthonormal columns. Values are non-negative and in decreasing order.
+type SVD struct {
+	U      Matrix
+	Values Vector
+	V      Matrix
+	// Sweeps is the number of Jacobi sweeps taken.
+	Sweeps int
+}
+
+// FactorizeSVD computes the singular value decomposition of a with the
+// one-sided Jacobi method, failing with ErrNoConvergence after maxSweeps
+// sweeps, or DefaultMaxSweeps when 0, and with ErrTimeout when ctx is done,
+// which it checks for every column of a sweep as a sweep costs O(mn²).
+func FactorizeSVD(ctx context.Context, a Matrix, maxSweeps int) (SVD, error) {
+	maxSweeps, err := iterationLimit(maxSweeps, DefaultMaxSweeps)
+	if err != nil {
+		return SVD{}, err
+	}
+	if a.rows < a.cols {
+		s, err := FactorizeSVD(ctx, a.Transpose(), maxSweeps)
+		s.U, s.V = s.V, s.U
+		return s, err
+	}
+	m, n := a.rows, a.cols
+	// The rows of ut and vt are the columns of U and V, which the sweeps
+	// rotate in pairs until they are orthogonal. ut is scaled by a power of
+	// two, exactly, so that the dot products of its rows cannot overflow.
+	ut, vt := a.Transpose(), Identity(n)
+	_, e := math.Frexp(a.maxAbs())
+	for i, v := range ut.data {
+		ut.data[i] = math.Ldexp(v, -e)
+	}
+	tol := float64(m) * eps
+	sweeps := 0
+	for rotated := true; rotated; sweeps++ {
+		if sweeps == maxSweeps {
+			return SVD{}, fmt.Errorf("%w: after %d sweeps", ErrNoConvergence, maxSweeps)
+		}
+		rotated = false
+		for p := range n {
+			if err := ctx.Err(); err != nil {
+				return SVD{}, fmt.Errorf("%w: after %d sweeps", ErrTimeout, sweeps)
+			}
+			up := ut.data[p*m : (p+1)*m]
+			for q := p + 1; q < n; q++ {
+				uq := ut.data[q*m : (q+1)*m]
+				alpha, beta, gamma := dot(up, up), dot(uq, uq), dot(up, uq)
+				if math.Abs(gamma) <= tol*math.Sqrt(alpha)*math.Sqrt(beta) {
+					continue
+				}
+				rotated = true
+				c, s := jacobiRotation(alpha, beta, gamma)
+				rotateRows(ut, p, q, c, s)
+				rotateRows(vt, p, q, c, s)
+			}
+		}
+	}
+	values := make(Vector, n)
+	for j := range n {
+		values[j] = norm(ut.data[j*m : (j+1)*m])
+	}
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(i, j int) int { return cmp.Compare(values[j], values[i]) })
+	s := SVD{U: Zeros(m, n), Values: make(Vector, n), V: Zeros(n, n), Sweeps: sweeps}
+	for k, j := range order {
+		s.Values[k] = values[j]
+		for i := range m {
+			if values[j] > 0 {
+				s.U.set(i, k, ut.At(j, i)/values[j])
+			}
+		}
+		for i := range n {
+			s.V.set(i, k, vt.At(j, i))
+		}
+	}
+	completeBasis(s.U, s.Values)
+	for k, v := range s.Values {
+		s.Values[k] = math.Ldexp(v, e)
+	}
+	if _, err := s.Values.checked(); err != nil {
+		return SVD{}, err
+	}
+	return s, nil
+}
+
+// jacobiRotation returns the rotation [c s; -s c] that makes the columns
+// with dot products alpha, beta with themselves and gamma with each other
+// orthogonal, choosing the smaller angle.
+func jacobiRotation(alpha, beta, gamma float64) (c, s float64) {
+	zeta := (beta - alpha) / (2 * gamma)
+	t := math.Copysign(1, zeta) / (math.Abs(zeta) + math.Hypot(1, zeta))
+	c = 1 / math.Hypot(1, t)
+	return c, c * t
+}
+
+// rotateRows applies the rotation from jacobiRotation to rows p and q of m.
+func rotateRows(m Matrix, p, q int, c, s float64) {
+	rp, rq := m.data[p*m.cols:(p+1)*m.cols], m.data[q*m.cols:(q+1)*m.cols]
+	for k := range rp {
+		rp[k], rq[k] = c*rp[k]-s*rq[k], s*rp[k]+c*rq[k]
+	}
+}
+
+// completeBasis replaces the zero columns of u, those of zero singular
+// values, with unit vectors orthogonal to the other columns: the standard
+// basis vectors that keep the most of their length once projected out of
+// them.
+func completeBasis(u Matrix, values Vector) {
+	col, best := make([]float64, u.rows), make([]float64, u.rows)
+	for j, v := range values {
+		if v > 0 {
+			continue
+		}
+		bestNorm := 0.0
+		for e := range u.rows {
+			clear(col)
+			col[e] = 1
+			// Projecting twice keeps the result orthogonal despite
+			// rounding.
+			orthogonalize(u, col)
+			orthogonalize(u, col)
+			if norm := math.Sqrt(dot(col, col)); norm > bestNorm {
+				bestNorm = norm
+				copy(best, col)
+			}
+		}
+		for i := range u.rows {
+			u.set(i, j, best[i]/bestNorm)
+		}
+	}
+}
+
+// orthogonalize subtracts from x its projection onto the columns of u,
+// which are orthonormal or zero.
+func orthogonalize(u Matrix, x []float64) {
+	for k := range u.cols {
+		d := 0.0
+		for i := range u.rows {
+			d += u.At(i, k) * x[i]
+		}
+		for i := range u.rows {
+			x[i] -= d * u.At(i, k)
+		}
+	}
+}
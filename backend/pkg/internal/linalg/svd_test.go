@@ -0,0 +1,81 @@
+package linalg
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/igorgatis/sezzle/backend/pkg/internal/calculator"
+)
+
+func TestSVD(t *testing.T) {
+	tests := []struct {
+		name   string
+		a      Matrix
+		values Vector
+	}{
+		{"diagonal", mustNew(t, [][]float64{{3, 0}, {0, -5}}), Vector{5, 3}},
+		{"wide", mustNew(t, [][]float64{{3, 2, 2}, {2, 3, -2}}), Vector{5, 3}},
+		{"rank one", mustNew(t, [][]float64{{1, 2}, {2, 4}, {3, 6}}), Vector{math.Sqrt(70), 0}},
+		{"zero", Zeros(3, 2), Vector{0, 0}},
+		{"large", mustNew(t, [][]float64{{1e200, 0}, {0, -3e200}}), Vector{3e200, 1e200}},
+		{"small", mustNew(t, [][]float64{{1e-200, 0}, {0, -3e-200}}), Vector{3e-200, 1e-200}},
+		{"tall random", randomMatrix(1, 30, 10), nil},
+		{"wide random", randomMatrix(2, 10, 30), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := FactorizeSVD(context.Background(), tt.a, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i, v := range tt.values {
+				if math.Abs(s.Values[i]-v) > 1e-14*math.Max(1, v) {
+					t.Fatalf("values = %v, want %v", s.Values, tt.values)
+				}
+			}
+			for i := 1; i < len(s.Values); i++ {
+				if s.Values[i] > s.Values[i-1] || s.Values[i] < 0 {
+					t.Fatalf("values %v not decreasing and non-negative", s.Values)
+				}
+			}
+			d := Zeros(len(s.Values), len(s.Values))
+			for i, v := range s.Values {
+				d.set(i, i, v)
+			}
+			assertOrthonormal(t, s.U, 1e-13)
+			assertOrthonormal(t, s.V, 1e-13)
+			assertProduct(t, tt.a, 1e-13, s.U, d, s.V.Transpose())
+		})
+	}
+
+	if _, err := FactorizeSVD(context.Background(), randomMatrix(1, 20, 20), 1); !errors.Is(err, ErrNoConvergence) {
+		t.Errorf("sweep limit error = %v", err)
+	}
+	if _, err := FactorizeSVD(context.Background(), Identity(2), -1); !errors.Is(err, ErrInvalidLimit) {
+		t.Errorf("negative limit error = %v", err)
+	}
+	if _, err := FactorizeSVD(context.Background(), mustNew(t, [][]float64{{1.5e308}, {1.5e308}}), 0); !errors.Is(err, calculator.ErrOverflow) {
+		t.Errorf("overflow error = %v", err)
+	}
+}
+
+func TestSVDTimeout(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := FactorizeSVD(ctx, randomMatrix(1, 20, 20), 0); !errors.Is(err, ErrTimeout) {
+		t.Errorf("FactorizeSVD error = %v, want %v", err, ErrTimeout)
+	}
+}
+
+func BenchmarkSVD200(b *testing.B) {
+	a := randomMatrix(1, 200, 200)
+	for b.Loop() {
+		s, err := FactorizeSVD(context.Background(), a, 0)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.ReportMetric(float64(s.Sweeps), "sweeps")
+	}
+}
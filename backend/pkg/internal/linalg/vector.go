@@ -0,0 +1,83 @@
+package linalg
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/igorgatis/sezzle/backend/pkg/internal/calculator"
+)
+
+type Vector []float64
+
+// NewVector returns x as a vector, which must be non-empty and finite.
+func NewVector(x []float64) (Vector, error) {
+	if len(x) == 0 || len(x) > MaxDim {
+		return nil, fmt.Errorf("%w: vector of %d elements, want 1 to %d", ErrInvalidMatrix, len(x), MaxDim)
+	}
+	for i, v := range x {
+		if math.IsInf(v, 0) || math.IsNaN(v) {
+			return nil, fmt.Errorf("%w: element [%d] is not finite", ErrInvalidMatrix, i)
+		}
+	}
+	return Vector(x), nil
+}
+
+func (x Vector) checked() (Vector, error) {
+	for _, v := range x {
+		if _, err := finite(v); err != nil {
+			return nil, err
+		}
+	}
+	return x, nil
+}
+
+func Dot(a, b Vector) (float64, error) {
+	if len(a) != len(b) {
+		return 0, fmt.Errorf("%w: vectors of %d and %d elements", ErrDimensionMismatch, len(a), len(b))
+	}
+	return finite(dot(a, b))
+}
+
+// Cross returns the cross product of two 3-dimensional vectors.
+func Cross(a, b Vector) (Vector, error) {
+	if len(a) != 3 || len(b) != 3 {
+		return nil, fmt.Errorf("%w: cross product needs 3 elements, got %d and %d", ErrDimensionMismatch, len(a), len(b))
+	}
+	return Vector{
+		a[1]*b[2] - a[2]*b[1],
+		a[2]*b[0] - a[0]*b[2],
+		a[0]*b[1] - a[1]*b[0],
+	}.checked()
+}
+
+func dot(a, b []float64) float64 {
+	s := 0.0
+	for i, v := range a {
+		s += v * b[i]
+	}
+	return s
+}
+
+// norm returns the Euclidean norm of x, scaling its elements so that their
+// squares neither overflow nor underflow.
+func norm(x []float64) float64 {
+	scale := 0.0
+	for _, v := range x {
+		scale = math.Max(scale, math.Abs(v))
+	}
+	if scale == 0 {
+		return 0
+	}
+	s := 0.0
+	for _, v := range x {
+		s += (v / scale) * (v / scale)
+	}
+	return scale * math.Sqrt(s)
+}
+
+func finite(x float64) (float64, error) {
+	if math.IsInf(x, 0) || math.IsNaN(x) {
+		return 0, calculator.ErrOverflow
+	}
+	return x, nil
+}
@@ -0,0 +1,32 @@
+package linalg
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestVector(t *testing.T) {
+	if _, err := NewVector(nil); !errors.Is(err, ErrInvalidMatrix) {
+		t.Errorf("NewVector(nil) error = %v", err)
+	}
+	if _, err := NewVector([]float64{math.NaN()}); !errors.Is(err, ErrInvalidMatrix) {
+		t.Errorf("NewVector(NaN) error = %v", err)
+	}
+	if d, err := Dot(Vector{1, 2, 3}, Vector{4, -5, 6}); err != nil || d != 12 {
+		t.Errorf("Dot = %v, %v", d, err)
+	}
+	if _, err := Dot(Vector{1}, Vector{1, 2}); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("Dot error = %v", err)
+	}
+	if c, err := Cross(Vector{1, 0, 0}, Vector{0, 1, 0}); err != nil || !slices.Equal(c, Vector{0, 0, 1}) {
+		t.Errorf("Cross = %v, %v", c, err)
+	}
+	if c, err := Cross(Vector{2, 3, 4}, Vector{5, 6, 7}); err != nil || !slices.Equal(c, Vector{-3, 6, -3}) {
+		t.Errorf("Cross = %v, %v", c, err)
+	}
+	if _, err := Cross(Vector{1, 2}, Vector{3, 4}); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("Cross error = %v", err)
+	}
+}
@@ -0,0 +1,83 @@
+// Package money implements exact arithmetic on amounts of ISO 4217
+// currencies.
+package money
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/igorgatis/sezzle/backend/pkg/internal/calculator"
+)
+
+var ErrUnknownCurrency = &calculator.Error{Code: "unknown_currency", Message: "unknown currency"}
+
+// Currency is an ISO 4217 currency. MinorUnits is the number of decimal
+// places of its amounts, e.g. 2 for USD and 0 for JPY.
+type Currency struct {
+	Code       string
+	Name       string
+	MinorUnits int
+}
+
+var currencies = []Currency{
+	{"AED", "UAE Dirham", 2},
+	{"ARS", "Argentine Peso", 2},
+	{"AUD", "Australian Dollar", 2},
+	{"BHD", "Bahraini Dinar", 3},
+	{"BRL", "Brazilian Real", 2},
+	{"CAD", "Canadian Dollar", 2},
+	{"CHF", "Swiss Franc", 2},
+	{"CLP", "Chilean Peso", 0},
+	{"CNY", "Yuan Renminbi", 2},
+	{"COP", "Colombian Peso", 2},
+	{"CZK", "Czech Koruna", 2},
+	{"DKK", "Danish Krone", 2},
+	{"EUR", "Euro", 2},
+	{"GBP", "Pound Sterling", 2},
+	{"HKD", "Hong Kong Dollar", 2},
+	{"HUF", "Forint", 2},
+	{"IDR", "Rupiah", 2},
+	{"ILS", "New Israeli Sheqel", 2},
+	{"INR", "Indian Rupee", 2},
+	{"ISK", "Iceland Krona", 0},
+	{"JOD", "Jordanian Dinar", 3},
+	{"JPY", "Yen", 0},
+	{"KRW", "Won", 0},
+	{"KWD", "Kuwaiti Dinar", 3},
+	{"MXN", "Mexican Peso", 2},
+	{"MYR", "Malaysian Ringgit", 2},
+	{"NOK", "Norwegian Krone", 2},
+	{"NZD", "New Zealand Dollar", 2},
+	{"OMR", "Rial Omani", 3},
+	{"PHP", "Philippine Peso", 2},
+	{"PLN", "Zloty", 2},
+	{"SAR", "Saudi Riyal", 2},
+	{"SEK", "Swedish Krona", 2},
+	{"SGD", "Singapore Dollar", 2},
+	{"THB", "Baht", 2},
+	{"TND", "Tunisian Dinar", 3},
+	{"TRY", "Turkish Lira", 2},
+	{"TWD", "New Taiwan Dollar", 2},
+	{"UAH", "Hryvnia", 2},
+	{"USD", "US Dollar", 2},
+	{"VND", "Dong", 0},
+	{"ZAR", "Rand", 2},
+}
+
+// Currencies returns the supported currencies sorted by code.
+func Currencies() []Currency {
+	return slices.Clone(currencies)
+}
+
+// LookupCurrency returns the currency with the given code, ignoring case.
+func LookupCurrency(code string) (Currency, error) {
+	upper := strings.ToUpper(code)
+	i, ok := slices.BinarySearchFunc(currencies, upper, func(c Currency, code string) int {
+		return strings.Compare(c.Code, code)
+	})
+	if !ok {
+		return Currency{}, fmt.Errorf("%w: %q", ErrUnknownCurrency, code)
+	}
+	return currencies[i], nil
+}
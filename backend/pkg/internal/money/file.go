@@ -0,0 +1,119 @@
+package money
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// FileRates is a RatesProvider reading rates from a JSON or CSV file, told
+// apart by extension. Reload rereads the file; lookups keep using the
+// previous rates until the new ones are fully loaded, and for good if they
+// are invalid.
+//
+// JSON files hold an array of {"from", "to", "rate", "date"} objects. CSV
+// files have a from,to,rate,date header. Rates are decimal strings and
+// dates are YYYY-MM-DD.
+type FileRates struct {
+	path  string
+	base  Currency
+	table atomic.Pointer[RateTable]
+}
+
+func NewFileRates(path string, base Currency) (*FileRates, error) {
+	f := &FileRates{path: path, base: base}
+	if err := f.Reload(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *FileRates) Rate(from, to Currency, at time.Time) (Rate, error) {
+	return f.table.Load().Rate(from, to, at)
+}
+
+// Reload rereads the file, keeping the current rates on error.
+func (f *FileRates) Reload() error {
+	file, err := os.Open(f.path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	var records []rateRecord
+	switch strings.ToLower(filepath.Ext(f.path)) {
+	case ".json":
+		err = json.NewDecoder(file).Decode(&records)
+	case ".csv":
+		records, err = readCSV(file)
+	default:
+		return fmt.Errorf("%w: unsupported file %s", ErrInvalidRates, f.path)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrInvalidRates, f.path, err)
+	}
+	rates := make([]Rate, len(records))
+	for i, rec := range records {
+		if rates[i], err = rec.rate(); err != nil {
+			return fmt.Errorf("%s: rate %d: %w", f.path, i+1, err)
+		}
+	}
+	table, err := NewRateTable(f.base, rates)
+	if err != nil {
+		return fmt.Errorf("%s: %w", f.path, err)
+	}
+	f.table.Store(table)
+	return nil
+}
+
+// Len returns the number of rates currently loaded.
+func (f *FileRates) Len() int {
+	return f.table.Load().Len()
+}
+
+type rateRecord struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Rate string `json:"rate"`
+	Date string `json:"date"`
+}
+
+func (rec rateRecord) rate() (Rate, error) {
+	from, err := LookupCurrency(rec.From)
+	if err != nil {
+		return Rate{}, err
+	}
+	to, err := LookupCurrency(rec.To)
+	if err != nil {
+		return Rate{}, err
+	}
+	rate, err := ParseDecimal(rec.Rate)
+	if err != nil {
+		return Rate{}, err
+	}
+	date, err := time.Parse(DateLayout, rec.Date)
+	if err != nil {
+		return Rate{}, fmt.Errorf("%w: invalid date %q", ErrInvalidRates, rec.Date)
+	}
+	return Rate{From: from, To: to, Rate: rate, Date: date}, nil
+}
+
+func readCSV(r io.Reader) ([]rateRecord, error) {
+	rows, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 || strings.Join(rows[0], ",") != "from,to,rate,date" {
+		return nil, fmt.Errorf("header must be from,to,rate,date")
+	}
+	records := make([]rateRecord, len(rows)-1)
+	for i, row := range rows[1:] {
+		records[i] = rateRecord{From: row[0], To: row[1], Rate: row[2], Date: row[3]}
+	}
+	return records, nil
+}
@@ -0,0 +1,181 @@
+package money
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/igorgatis/sezzle/backend/pkg/internal/calculator"
+)
+
+// MaxParts bounds the number of parts of an allocation.
+const MaxParts = 1000
+
+var (
+	ErrInvalidAmount    = &calculator.Error{Code: "invalid_amount", Message: "invalid amount"}
+	ErrAmountPrecision  = &calculator.Error{Code: "amount_precision", Message: "amount has more decimal places than the currency allows"}
+	ErrCurrencyMismatch = &calculator.Error{Code: "currency_mismatch", Message: "amounts are in different currencies"}
+	ErrAmountOverflow   = &calculator.Error{Code: "amount_overflow", Message: "amount out of range"}
+	ErrInvalidDecimal   = &calculator.Error{Code: "invalid_decimal", Message: "invalid decimal number"}
+	ErrInvalidRatios    = &calculator.Error{Code: "invalid_ratios", Message: "ratios must not be negative and must have a positive sum"}
+	ErrInvalidParts     = &calculator.Error{Code: "invalid_parts", Message: "invalid number of parts"}
+)
+
+var decimalPattern = regexp.MustCompile(`^[+-]?[0-9]+(\.[0-9]+)?$`)
+
+// Money is an exact amount of a currency, held in minor units (e.g. cents).
+type Money struct {
+	Currency Currency
+	Minor    int64
+}
+
+// Parse parses a decimal amount such as "10.05" of cur. Amounts with more
+// decimal places than cur allows are rejected unless the extra ones are
+// zeros.
+func Parse(amount string, cur Currency) (Money, error) {
+	if !decimalPattern.MatchString(amount) {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, amount)
+	}
+	ip, frac, _ := strings.Cut(amount, ".")
+	if len(frac) > cur.MinorUnits {
+		if strings.Trim(frac[cur.MinorUnits:], "0") != "" {
+			return Money{}, fmt.Errorf("%w: %s %s has %d", ErrAmountPrecision, amount, cur.Code, cur.MinorUnits)
+		}
+		frac = frac[:cur.MinorUnits]
+	}
+	frac += strings.Repeat("0", cur.MinorUnits-len(frac))
+	n, _ := new(big.Int).SetString(ip+frac, 10)
+	return fromInt(n, cur)
+}
+
+// ParseDecimal parses a plain decimal number such as "1.075" exactly.
+func ParseDecimal(s string) (*big.Rat, error) {
+	if !decimalPattern.MatchString(s) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidDecimal, s)
+	}
+	r, _ := new(big.Rat).SetString(s)
+	return r, nil
+}
+
+func fromInt(n *big.Int, cur Currency) (Money, error) {
+	if !n.IsInt64() {
+		return Money{}, ErrAmountOverflow
+	}
+	return Money{Currency: cur, Minor: n.Int64()}, nil
+}
+
+// String formats m with exactly the minor units of its currency, e.g.
+// "-0.50".
+func (m Money) String() string {
+	s := new(big.Int).Abs(big.NewInt(m.Minor)).String()
+	if u := m.Currency.MinorUnits; u > 0 {
+		s = strings.Repeat("0", max(u+1-len(s), 0)) + s
+		s = s[:len(s)-u] + "." + s[len(s)-u:]
+	}
+	if m.Minor < 0 {
+		s = "-" + s
+	}
+	return s
+}
+
+// Rat returns the amount of m in major units.
+func (m Money) Rat() *big.Rat {
+	unit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(m.Currency.MinorUnits)), nil)
+	return new(big.Rat).SetFrac(big.NewInt(m.Minor), unit)
+}
+
+// FromRat rounds x, in major units of cur, to minor units with mode.
+func FromRat(x *big.Rat, cur Currency, mode calculator.RoundingMode) (Money, error) {
+	unit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(cur.MinorUnits)), nil)
+	return fromInt(mode.RoundRat(new(big.Rat).Mul(x, new(big.Rat).SetInt(unit))), cur)
+}
+
+func (m Money) check(o Money) error {
+	if m.Currency != o.Currency {
+		return fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency.Code, o.Currency.Code)
+	}
+	return nil
+}
+
+func (m Money) Add(o Money) (Money, error) {
+	if err := m.check(o); err != nil {
+		return Money{}, err
+	}
+	return fromInt(new(big.Int).Add(big.NewInt(m.Minor), big.NewInt(o.Minor)), m.Currency)
+}
+
+func (m Money) Sub(o Money) (Money, error) {
+	if err := m.check(o); err != nil {
+		return Money{}, err
+	}
+	return fromInt(new(big.Int).Sub(big.NewInt(m.Minor), big.NewInt(o.Minor)), m.Currency)
+}
+
+// Mul multiplies m by factor, rounding to minor units with mode.
+func (m Money) Mul(factor *big.Rat, mode calculator.RoundingMode) (Money, error) {
+	x := new(big.Rat).Mul(new(big.Rat).SetInt64(m.Minor), factor)
+	return fromInt(mode.RoundRat(x), m.Currency)
+}
+
+// Allocate splits m in proportion to ratios without losing minor units.
+// Each part gets its proportional share rounded toward zero; the minor
+// units left over go one each to the parts with the largest discarded
+// fractions, earlier parts first on ties.
+func (m Money) Allocate(ratios []*big.Rat) ([]Money, error) {
+	if len(ratios) == 0 || len(ratios) > MaxParts {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidParts, len(ratios))
+	}
+	sum := new(big.Rat)
+	for _, r := range ratios {
+		if r.Sign() < 0 {
+			return nil, ErrInvalidRatios
+		}
+		sum.Add(sum, r)
+	}
+	if sum.Sign() == 0 {
+		return nil, ErrInvalidRatios
+	}
+	total := big.NewInt(m.Minor)
+	total.Abs(total)
+	parts := make([]int64, len(ratios))
+	fracs := make([]*big.Rat, len(ratios))
+	left := new(big.Int).Set(total)
+	for i, r := range ratios {
+		share := new(big.Rat).Mul(new(big.Rat).SetInt(total), r)
+		share.Quo(share, sum)
+		q, rem := new(big.Int).QuoRem(share.Num(), share.Denom(), new(big.Int))
+		parts[i] = q.Int64()
+		fracs[i] = new(big.Rat).SetFrac(rem, share.Denom())
+		left.Sub(left, q)
+	}
+	order := make([]int, len(ratios))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int { return fracs[b].Cmp(fracs[a]) })
+	for _, i := range order[:left.Int64()] {
+		parts[i]++
+	}
+	out := make([]Money, len(parts))
+	for i, p := range parts {
+		if m.Minor < 0 {
+			p = -p
+		}
+		out[i] = Money{Currency: m.Currency, Minor: p}
+	}
+	return out, nil
+}
+
+// Split splits m in n parts as equal as possible, larger parts first.
+func (m Money) Split(n int) ([]Money, error) {
+	if n < 1 || n > MaxParts {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidParts, n)
+	}
+	ratios := make([]*big.Rat, n)
+	for i := range ratios {
+		ratios[i] = big.NewRat(1, 1)
+	}
+	return m.Allocate(ratios)
+}
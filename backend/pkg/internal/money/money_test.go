@@ -0,0 +1,168 @@
+package money
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/igorgatis/sezzle/backend/pkg/internal/calculator"
+)
+
+func mustCurrency(t *testing.T, code string) Currency {
+	t.Helper()
+	c, err := LookupCurrency(code)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func mustParse(t *testing.T, amount, code string) Money {
+	t.Helper()
+	m, err := Parse(amount, mustCurrency(t, code))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestLookupCurrency(t *testing.T) {
+	if c, err := LookupCurrency("kwd"); err != nil || c.MinorUnits != 3 {
+		t.Errorf("LookupCurrency(kwd) = %+v, %v", c, err)
+	}
+	if _, err := LookupCurrency("XXX"); !errors.Is(err, ErrUnknownCurrency) {
+		t.Errorf("LookupCurrency(XXX) error = %v", err)
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		amount, code string
+		expected     string
+		expectErr    error
+	}{
+		{"10.05", "USD", "10.05", nil},
+		{"10", "USD", "10.00", nil},
+		{"-0.5", "USD", "-0.50", nil},
+		{"10.000", "USD", "10.00", nil},
+		{"10.005", "USD", "", ErrAmountPrecision},
+		{"1000", "JPY", "1000", nil},
+		{"1000.5", "JPY", "", ErrAmountPrecision},
+		{"1.234", "KWD", "1.234", nil},
+		{"0.001", "KWD", "0.001", nil},
+		{"1e3", "USD", "", ErrInvalidAmount},
+		{"1.", "USD", "", ErrInvalidAmount},
+		{"", "USD", "", ErrInvalidAmount},
+		{"99999999999999999", "USD", "", ErrAmountOverflow},
+	}
+	for _, tt := range tests {
+		t.Run(tt.amount+" "+tt.code, func(t *testing.T) {
+			m, err := Parse(tt.amount, mustCurrency(t, tt.code))
+			if !errors.Is(err, tt.expectErr) {
+				t.Fatalf("Parse(%q) error = %v, want %v", tt.amount, err, tt.expectErr)
+			}
+			if err == nil && m.String() != tt.expected {
+				t.Errorf("Parse(%q) = %s, want %s", tt.amount, m, tt.expected)
+			}
+		})
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	a, b := mustParse(t, "10.10", "USD"), mustParse(t, "0.95", "USD")
+	if s, err := a.Add(b); err != nil || s.String() != "11.05" {
+		t.Errorf("Add = %s, %v", s, err)
+	}
+	if s, err := b.Sub(a); err != nil || s.String() != "-9.15" {
+		t.Errorf("Sub = %s, %v", s, err)
+	}
+	if _, err := a.Add(mustParse(t, "1", "EUR")); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Add(EUR) error = %v", err)
+	}
+	max := Money{Currency: a.Currency, Minor: 1<<63 - 1}
+	if _, err := max.Add(b); err != ErrAmountOverflow {
+		t.Errorf("overflowing Add error = %v", err)
+	}
+}
+
+func TestMul(t *testing.T) {
+	tests := []struct {
+		amount, code, factor string
+		mode                 calculator.RoundingMode
+		expected             string
+	}{
+		{"10.00", "USD", "1.075", calculator.HalfEven, "10.75"},
+		{"0.05", "USD", "0.5", calculator.HalfEven, "0.02"},
+		{"0.05", "USD", "0.5", calculator.HalfUp, "0.03"},
+		{"-0.05", "USD", "0.5", calculator.Floor, "-0.03"},
+		{"333", "JPY", "0.1", calculator.HalfUp, "33"},
+		{"1.000", "KWD", "0.3333", calculator.Up, "0.334"},
+	}
+	for _, tt := range tests {
+		f, err := ParseDecimal(tt.factor)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := mustParse(t, tt.amount, tt.code).Mul(f, tt.mode)
+		if err != nil || got.String() != tt.expected {
+			t.Errorf("%s %s × %s (%s) = %s, %v, want %s", tt.amount, tt.code, tt.factor, tt.mode, got, err, tt.expected)
+		}
+	}
+}
+
+func TestAllocate(t *testing.T) {
+	tests := []struct {
+		amount, code string
+		ratios       []int64
+		expected     []string
+		expectErr    error
+	}{
+		{"100.00", "USD", []int64{1, 1, 1}, []string{"33.34", "33.33", "33.33"}, nil},
+		{"0.05", "USD", []int64{3, 7}, []string{"0.02", "0.03"}, nil},
+		{"0.05", "USD", []int64{2, 8}, []string{"0.01", "0.04"}, nil},
+		{"0.05", "USD", []int64{3, 3, 3, 1}, []string{"0.02", "0.02", "0.01", "0.00"}, nil},
+		{"-100.00", "USD", []int64{1, 1, 1}, []string{"-33.34", "-33.33", "-33.33"}, nil},
+		{"10", "JPY", []int64{1, 0, 2}, []string{"3", "0", "7"}, nil},
+		{"1.000", "KWD", []int64{1, 2}, []string{"0.333", "0.667"}, nil},
+		{"1", "USD", []int64{0, 0}, nil, ErrInvalidRatios},
+		{"1", "USD", []int64{1, -1}, nil, ErrInvalidRatios},
+		{"1", "USD", nil, nil, ErrInvalidParts},
+	}
+	for _, tt := range tests {
+		ratios := make([]*big.Rat, len(tt.ratios))
+		for i, r := range tt.ratios {
+			ratios[i] = big.NewRat(r, 1)
+		}
+		m := mustParse(t, tt.amount, tt.code)
+		parts, err := m.Allocate(ratios)
+		if !errors.Is(err, tt.expectErr) {
+			t.Fatalf("Allocate(%s, %v) error = %v, want %v", m, tt.ratios, err, tt.expectErr)
+		}
+		var sum int64
+		for i, p := range parts {
+			sum += p.Minor
+			if p.String() != tt.expected[i] {
+				t.Errorf("Allocate(%s, %v)[%d] = %s, want %s", m, tt.ratios, i, p, tt.expected[i])
+			}
+		}
+		if err == nil && sum != m.Minor {
+			t.Errorf("Allocate(%s, %v) parts sum to %d minor units", m, tt.ratios, sum)
+		}
+	}
+}
+
+func TestSplit(t *testing.T) {
+	parts, err := mustParse(t, "10.00", "EUR").Split(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"3.34", "3.33", "3.33"}
+	for i, p := range parts {
+		if p.String() != want[i] {
+			t.Errorf("Split(3)[%d] = %s, want %s", i, p, want[i])
+		}
+	}
+	if _, err := mustParse(t, "1", "EUR").Split(0); !errors.Is(err, ErrInvalidParts) {
+		t.Errorf("Split(0) error = %v", err)
+	}
+}
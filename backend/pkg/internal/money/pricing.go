@@ -0,0 +1,243 @@
+package money
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/igorgatis/sezzle/backend/pkg/internal/calculator"
+)
+
+var ErrInvalidPercent = &calculator.Error{Code: "invalid_percent", Message: "percent out of range"}
+
+var hundred = big.NewRat(100, 1)
+
+// percent returns p% as a fraction.
+func percent(p *big.Rat) *big.Rat {
+	return new(big.Rat).Quo(p, hundred)
+}
+
+// FormatDecimal formats x with at most places decimal places, dropping
+// trailing zeros.
+func FormatDecimal(x *big.Rat, places int) string {
+	s := x.FloatString(places)
+	if strings.Contains(s, ".") {
+		s = strings.TrimSuffix(strings.TrimRight(s, "0"), ".")
+	}
+	if s == "-0" {
+		return "0"
+	}
+	return s
+}
+
+func checkPercent(p *big.Rat, limit int64) error {
+	if p.Sign() < 0 || (limit > 0 && p.Cmp(big.NewRat(limit, 1)) > 0) {
+		return fmt.Errorf("%w: %s", ErrInvalidPercent, FormatDecimal(p, 12))
+	}
+	return nil
+}
+
+// Tax is the breakdown of a taxed amount: Gross = Net + Tax.
+type Tax struct {
+	Net, Tax, Gross Money
+}
+
+// AddTax adds rate% of tax to the tax exclusive amount net.
+func AddTax(net Money, rate *big.Rat, mode calculator.RoundingMode) (Tax, error) {
+	if err := checkPercent(rate, 0); err != nil {
+		return Tax{}, err
+	}
+	tax, err := net.Mul(percent(rate), mode)
+	if err != nil {
+		return Tax{}, err
+	}
+	gross, err := net.Add(tax)
+	return Tax{Net: net, Tax: tax, Gross: gross}, err
+}
+
+// RemoveTax splits the tax inclusive amount gross into its net amount and
+// rate% of tax. The net amount is rounded and the tax takes the rest, so
+// both always add up to gross.
+func RemoveTax(gross Money, rate *big.Rat, mode calculator.RoundingMode) (Tax, error) {
+	if err := checkPercent(rate, 0); err != nil {
+		return Tax{}, err
+	}
+	divisor := new(big.Rat).Add(big.NewRat(1, 1), percent(rate))
+	net, err := gross.Mul(new(big.Rat).Inv(divisor), mode)
+	if err != nil {
+		return Tax{}, err
+	}
+	tax, err := gross.Sub(net)
+	return Tax{Net: net, Tax: tax, Gross: gross}, err
+}
+
+// Stacking tells how several discounts combine.
+type Stacking string
+
+const (
+	// Sequential applies each discount to the price left by the previous
+	// ones, so 10% and 20% take 28% off.
+	Sequential Stacking = "sequential"
+	// Additive adds the discounts up, so 10% and 20% take 30% off.
+	Additive Stacking = "additive"
+)
+
+var ErrInvalidStacking = &calculator.Error{Code: "invalid_stacking", Message: "invalid discount stacking"}
+
+// ParseStacking parses s, defaulting to Sequential when s is empty.
+func ParseStacking(s string) (Stacking, error) {
+	switch Stacking(s) {
+	case "":
+		return Sequential, nil
+	case Sequential, Additive:
+		return Stacking(s), nil
+	}
+	return "", fmt.Errorf("%w: %q", ErrInvalidStacking, s)
+}
+
+// DiscountStep is one discount taken off Before, leaving After.
+type DiscountStep struct {
+	Percent       *big.Rat
+	Before        Money
+	Amount, After Money
+}
+
+type Discount struct {
+	Price, Final Money
+	Total        Money
+	// Percent is the effective discount on Price.
+	Percent *big.Rat
+	Steps   []DiscountStep
+}
+
+// ApplyDiscounts takes percents, each between 0 and 100, off price. Each
+// discount amount is rounded to minor units with mode.
+func ApplyDiscounts(price Money, percents []*big.Rat, stacking Stacking, mode calculator.RoundingMode) (Discount, error) {
+	if len(percents) == 0 || len(percents) > MaxParts {
+		return Discount{}, fmt.Errorf("%w: %d discounts", ErrInvalidParts, len(percents))
+	}
+	for _, p := range percents {
+		if err := checkPercent(p, 100); err != nil {
+			return Discount{}, err
+		}
+	}
+	if stacking == Additive {
+		sum := new(big.Rat)
+		for _, p := range percents {
+			sum.Add(sum, p)
+		}
+		if err := checkPercent(sum, 100); err != nil {
+			return Discount{}, err
+		}
+		percents = []*big.Rat{sum}
+	}
+	d := Discount{Price: price, Final: price}
+	for _, p := range percents {
+		amount, err := d.Final.Mul(percent(p), mode)
+		if err != nil {
+			return Discount{}, err
+		}
+		after, err := d.Final.Sub(amount)
+		if err != nil {
+			return Discount{}, err
+		}
+		d.Steps = append(d.Steps, DiscountStep{Percent: p, Before: d.Final, Amount: amount, After: after})
+		d.Final = after
+	}
+	d.Total, _ = price.Sub(d.Final)
+	d.Percent = new(big.Rat)
+	if price.Minor != 0 {
+		d.Percent.Mul(new(big.Rat).Quo(d.Total.Rat(), price.Rat()), hundred)
+	}
+	return d, nil
+}
+
+// Pricing is the breakdown of a price set from a cost. Markup is the profit
+// as a percent of Cost, Margin as a percent of Price.
+type Pricing struct {
+	Cost, Price, Profit Money
+	Markup, Margin      *big.Rat
+}
+
+// Markup prices cost with a markup of p% of cost.
+func Markup(cost Money, p *big.Rat, mode calculator.RoundingMode) (Pricing, error) {
+	if err := checkPercent(p, 0); err != nil {
+		return Pricing{}, err
+	}
+	price, err := cost.Mul(new(big.Rat).Add(big.NewRat(1, 1), percent(p)), mode)
+	if err != nil {
+		return Pricing{}, err
+	}
+	return pricing(cost, price)
+}
+
+// Margin prices cost with a margin of p% of the price. p must be below
+// 100.
+func Margin(cost Money, p *big.Rat, mode calculator.RoundingMode) (Pricing, error) {
+	if err := checkPercent(p, 100); err != nil {
+		return Pricing{}, err
+	}
+	if p.Cmp(hundred) == 0 {
+		return Pricing{}, fmt.Errorf("%w: a 100%% margin has no price", ErrInvalidPercent)
+	}
+	price, err := cost.Mul(new(big.Rat).Inv(new(big.Rat).Sub(big.NewRat(1, 1), percent(p))), mode)
+	if err != nil {
+		return Pricing{}, err
+	}
+	return pricing(cost, price)
+}
+
+// pricing reports the actual markup and margin of price, which may differ
+// from the requested ones once price is rounded.
+func pricing(cost, price Money) (Pricing, error) {
+	profit, err := price.Sub(cost)
+	if err != nil {
+		return Pricing{}, err
+	}
+	p := Pricing{Cost: cost, Price: price, Profit: profit, Markup: new(big.Rat), Margin: new(big.Rat)}
+	if cost.Minor != 0 {
+		p.Markup.Mul(new(big.Rat).Quo(profit.Rat(), cost.Rat()), hundred)
+	}
+	if price.Minor != 0 {
+		p.Margin.Mul(new(big.Rat).Quo(profit.Rat(), price.Rat()), hundred)
+	}
+	return p, nil
+}
+
+// PercentChange returns the change from from to to and its percent of
+// from's magnitude, so going from -50 to -25 is a 50% increase.
+func PercentChange(from, to *big.Rat) (change, pct *big.Rat, err error) {
+	if from.Sign() == 0 {
+		return nil, nil, calculator.ErrDivisionByZero
+	}
+	change = new(big.Rat).Sub(to, from)
+	pct = new(big.Rat).Quo(change, new(big.Rat).Abs(from))
+	return change, pct.Mul(pct, hundred), nil
+}
+
+// Tip is the breakdown of a tipped bill split among people. Shares add up
+// to Total and differ by at most one minor unit.
+type Tip struct {
+	Bill, Tip, Total Money
+	Shares           []Money
+}
+
+// AddTip adds a tip of p% of bill and splits the total among people.
+func AddTip(bill Money, p *big.Rat, people int, mode calculator.RoundingMode) (Tip, error) {
+	if err := checkPercent(p, 0); err != nil {
+		return Tip{}, err
+	}
+	tip, err := bill.Mul(percent(p), mode)
+	if err != nil {
+		return Tip{}, err
+	}
+	total, err := bill.Add(tip)
+	if err != nil {
+		return Tip{}, err
+	}
+	shares, err := total.Split(people)
+	if err != nil {
+		return Tip{}, err
+	}
+	return Tip{Bill: bill, Tip: tip, Total: total, Shares: shares}, nil
+}
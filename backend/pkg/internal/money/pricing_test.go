@@ -0,0 +1,148 @@
+package money
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/igorgatis/sezzle/backend/pkg/internal/calculator"
+)
+
+func rat(s string) *big.Rat {
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		panic(s)
+	}
+	return r
+}
+
+func TestTax(t *testing.T) {
+	tests := []struct {
+		name            string
+		inclusive       bool
+		amount, rate    string
+		net, tax, gross string
+		expectErr       error
+	}{
+		{"exclusive", false, "19.99", "8.25", "19.99", "1.65", "21.64", nil},
+		{"inclusive", true, "21.64", "8.25", "19.99", "1.65", "21.64", nil},
+		{"inclusive remainder", true, "10.00", "7", "9.35", "0.65", "10.00", nil},
+		{"zero rate", false, "5.00", "0", "5.00", "0.00", "5.00", nil},
+		{"negative rate", false, "5.00", "-1", "", "", "", ErrInvalidPercent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fn := AddTax
+			if tt.inclusive {
+				fn = RemoveTax
+			}
+			got, err := fn(mustParse(t, tt.amount, "USD"), rat(tt.rate), calculator.HalfEven)
+			if !errors.Is(err, tt.expectErr) {
+				t.Fatalf("error = %v, want %v", err, tt.expectErr)
+			}
+			if err == nil && (got.Net.String() != tt.net || got.Tax.String() != tt.tax || got.Gross.String() != tt.gross) {
+				t.Errorf("got %s + %s = %s, want %s + %s = %s", got.Net, got.Tax, got.Gross, tt.net, tt.tax, tt.gross)
+			}
+		})
+	}
+}
+
+func TestApplyDiscounts(t *testing.T) {
+	tests := []struct {
+		name      string
+		price     string
+		percents  []string
+		stacking  Stacking
+		final     string
+		percent   string
+		expectErr error
+	}{
+		{"sequential", "100.00", []string{"10", "20"}, Sequential, "72.00", "28", nil},
+		{"additive", "100.00", []string{"10", "20"}, Additive, "70.00", "30", nil},
+		{"rounded steps", "9.99", []string{"15", "15"}, Sequential, "7.22", "27.727727727728", nil},
+		{"additive over 100", "10.00", []string{"60", "50"}, Additive, "", "", ErrInvalidPercent},
+		{"over 100", "10.00", []string{"101"}, Sequential, "", "", ErrInvalidPercent},
+		{"none", "10.00", nil, Sequential, "", "", ErrInvalidParts},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			percents := make([]*big.Rat, len(tt.percents))
+			for i, p := range tt.percents {
+				percents[i] = rat(p)
+			}
+			d, err := ApplyDiscounts(mustParse(t, tt.price, "USD"), percents, tt.stacking, calculator.HalfEven)
+			if !errors.Is(err, tt.expectErr) {
+				t.Fatalf("error = %v, want %v", err, tt.expectErr)
+			}
+			if err != nil {
+				return
+			}
+			if d.Final.String() != tt.final || FormatDecimal(d.Percent, 12) != tt.percent {
+				t.Errorf("got %s (%s%%), want %s (%s%%)", d.Final, FormatDecimal(d.Percent, 12), tt.final, tt.percent)
+			}
+			sum, _ := d.Final.Add(d.Total)
+			if sum != d.Price {
+				t.Errorf("final %s + total %s != price %s", d.Final, d.Total, d.Price)
+			}
+		})
+	}
+}
+
+func TestMarkupMargin(t *testing.T) {
+	cost := mustParse(t, "80.00", "USD")
+	p, err := Markup(cost, rat("25"), calculator.HalfEven)
+	if err != nil || p.Price.String() != "100.00" || p.Profit.String() != "20.00" ||
+		FormatDecimal(p.Markup, 4) != "25" || FormatDecimal(p.Margin, 4) != "20" {
+		t.Errorf("Markup(25%%) = %+v, %v", p, err)
+	}
+	p, err = Margin(cost, rat("25"), calculator.HalfEven)
+	if err != nil || p.Price.String() != "106.67" || FormatDecimal(p.Margin, 4) != "25.0023" {
+		t.Errorf("Margin(25%%) = %s margin %s, %v", p.Price, FormatDecimal(p.Margin, 4), err)
+	}
+	if _, err := Margin(cost, rat("100"), calculator.HalfEven); !errors.Is(err, ErrInvalidPercent) {
+		t.Errorf("Margin(100%%) error = %v", err)
+	}
+}
+
+func TestPercentChange(t *testing.T) {
+	tests := []struct {
+		from, to        string
+		change, percent string
+		expectErr       error
+	}{
+		{"80", "100", "20", "25", nil},
+		{"100", "80", "-20", "-20", nil},
+		{"-50", "-25", "25", "50", nil},
+		{"3", "4", "1", "33.333333333333", nil},
+		{"0", "1", "", "", calculator.ErrDivisionByZero},
+	}
+	for _, tt := range tests {
+		change, pct, err := PercentChange(rat(tt.from), rat(tt.to))
+		if !errors.Is(err, tt.expectErr) {
+			t.Errorf("PercentChange(%s, %s) error = %v, want %v", tt.from, tt.to, err, tt.expectErr)
+			continue
+		}
+		if err == nil && (FormatDecimal(change, 12) != tt.change || FormatDecimal(pct, 12) != tt.percent) {
+			t.Errorf("PercentChange(%s, %s) = %s, %s%%", tt.from, tt.to, FormatDecimal(change, 12), FormatDecimal(pct, 12))
+		}
+	}
+}
+
+func TestAddTip(t *testing.T) {
+	tip, err := AddTip(mustParse(t, "87.35", "USD"), rat("18"), 3, calculator.HalfEven)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tip.Tip.String() != "15.72" || tip.Total.String() != "103.07" {
+		t.Errorf("AddTip = %s tip, %s total", tip.Tip, tip.Total)
+	}
+	want := []string{"34.36", "34.36", "34.35"}
+	for i, s := range tip.Shares {
+		if s.String() != want[i] {
+			t.Errorf("share %d = %s, want %s", i, s, want[i])
+		}
+	}
+	if _, err := AddTip(tip.Bill, rat("18"), 0, calculator.HalfEven); !errors.Is(err, ErrInvalidParts) {
+		t.Errorf("AddTip(0 people) error = %v", err)
+	}
+}
@@ -0,0 +1,131 @@
+package money
+
+import (
+	"fmt"
+	"math/big"
+	"slices"
+	"time"
+
+	"github.com/igorgatis/sezzle/backend/pkg/internal/calculator"
+)
+
+// DateLayout is the layout of effective dates.
+const DateLayout = time.DateOnly
+
+var (
+	ErrRateNotFound = &calculator.Error{Code: "rate_not_found", Message: "no exchange rate available"}
+	ErrInvalidRates = &calculator.Error{Code: "invalid_rates", Message: "invalid exchange rates"}
+)
+
+// Rate is the price of one unit of From in units of To, effective from
+// Date. Via names the currency a triangulated rate goes through.
+type Rate struct {
+	From, To Currency
+	Rate     *big.Rat
+	Date     time.Time
+	Via      string
+}
+
+// RatesProvider returns exchange rates effective at a given time.
+type RatesProvider interface {
+	Rate(from, to Currency, at time.Time) (Rate, error)
+}
+
+type pair struct{ from, to string }
+
+// RateTable is an immutable RatesProvider over a list of rates. A pair is
+// looked up directly, then inverted, then triangulated through the base
+// currency.
+type RateTable struct {
+	base  string
+	rates map[pair][]Rate
+}
+
+// NewRateTable indexes rates, which must be positive and unique per pair
+// and date.
+func NewRateTable(base Currency, rates []Rate) (*RateTable, error) {
+	t := &RateTable{base: base.Code, rates: make(map[pair][]Rate)}
+	for _, r := range rates {
+		if r.Rate.Sign() <= 0 || r.From == r.To {
+			return nil, fmt.Errorf("%w: %s/%s %s", ErrInvalidRates, r.From.Code, r.To.Code, r.Rate.RatString())
+		}
+		p := pair{r.From.Code, r.To.Code}
+		t.rates[p] = append(t.rates[p], r)
+	}
+	for p, rs := range t.rates {
+		slices.SortFunc(rs, func(a, b Rate) int { return a.Date.Compare(b.Date) })
+		for i := 1; i < len(rs); i++ {
+			if rs[i].Date.Equal(rs[i-1].Date) {
+				return nil, fmt.Errorf("%w: duplicate %s/%s rate on %s",
+					ErrInvalidRates, p.from, p.to, rs[i].Date.Format(DateLayout))
+			}
+		}
+	}
+	return t, nil
+}
+
+// Len returns the number of rates in t.
+func (t *RateTable) Len() int {
+	n := 0
+	for _, rs := range t.rates {
+		n += len(rs)
+	}
+	return n
+}
+
+func (t *RateTable) Rate(from, to Currency, at time.Time) (Rate, error) {
+	if from == to {
+		return Rate{From: from, To: to, Rate: big.NewRat(1, 1), Date: at}, nil
+	}
+	if r, ok := t.pair(from, to, at); ok {
+		return r, nil
+	}
+	base, err := LookupCurrency(t.base)
+	if err == nil && from.Code != t.base && to.Code != t.base {
+		r1, ok1 := t.pair(from, base, at)
+		r2, ok2 := t.pair(base, to, at)
+		if ok1 && ok2 {
+			// A triangulated rate is only as recent as its older leg.
+			date := r1.Date
+			if r2.Date.Before(date) {
+				date = r2.Date
+			}
+			return Rate{From: from, To: to, Rate: new(big.Rat).Mul(r1.Rate, r2.Rate), Date: date, Via: t.base}, nil
+		}
+	}
+	return Rate{}, fmt.Errorf("%w: %s/%s on %s", ErrRateNotFound, from.Code, to.Code, at.Format(DateLayout))
+}
+
+// pair returns the latest rate from from to to effective at at, inverting
+// the rate of the opposite pair when there is no direct one.
+func (t *RateTable) pair(from, to Currency, at time.Time) (Rate, bool) {
+	if r, ok := latest(t.rates[pair{from.Code, to.Code}], at); ok {
+		return r, true
+	}
+	if r, ok := latest(t.rates[pair{to.Code, from.Code}], at); ok {
+		return Rate{From: from, To: to, Rate: new(big.Rat).Inv(r.Rate), Date: r.Date}, true
+	}
+	return Rate{}, false
+}
+
+func latest(rates []Rate, at time.Time) (Rate, bool) {
+	i, _ := slices.BinarySearchFunc(rates, at, func(r Rate, at time.Time) int {
+		if r.Date.After(at) {
+			return 1
+		}
+		return -1
+	})
+	if i == 0 {
+		return Rate{}, false
+	}
+	return rates[i-1], true
+}
+
+// Convert converts m at rate r, rounding to the minor units of r.To with
+// mode.
+func (m Money) Convert(r Rate, mode calculator.RoundingMode) (Money, error) {
+	if m.Currency != r.From {
+		return Money{}, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency.Code, r.From.Code)
+	}
+	return FromRat(new(big.Rat).Mul(m.Rat(), r.Rate), r.To, mode)
+}
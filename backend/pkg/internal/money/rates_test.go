@@ -0,0 +1,138 @@
+package money
+
+import (
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/igorgatis/sezzle/backend/pkg/internal/calculator"
+)
+
+func date(s string) time.Time {
+	d, err := time.Parse(DateLayout, s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+const ratesCSV = `from,to,rate,date
+USD,EUR,0.90,2026-01-01
+USD,EUR,0.92,2026-06-01
+USD,JPY,150,2026-01-01
+GBP,USD,1.25,2026-03-01
+`
+
+func TestRateTable(t *testing.T) {
+	f, err := NewFileRates(writeFile(t, "rates.csv", ratesCSV), mustCurrency(t, "USD"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		from, to, at string
+		rate, date   string
+		via          string
+		expectErr    error
+	}{
+		{"USD", "EUR", "2026-07-01", "23/25", "2026-06-01", "", nil},
+		{"USD", "EUR", "2026-05-31", "9/10", "2026-01-01", "", nil},
+		{"USD", "EUR", "2026-06-01", "23/25", "2026-06-01", "", nil},
+		{"USD", "EUR", "2025-12-31", "", "", "", ErrRateNotFound},
+		{"EUR", "USD", "2026-07-01", "25/23", "2026-06-01", "", nil},
+		{"GBP", "JPY", "2026-07-01", "375/2", "2026-01-01", "USD", nil},
+		{"EUR", "GBP", "2026-07-01", "20/23", "2026-03-01", "USD", nil},
+		{"GBP", "JPY", "2026-02-01", "", "", "", ErrRateNotFound},
+		{"EUR", "CHF", "2026-07-01", "", "", "", ErrRateNotFound},
+		{"JPY", "JPY", "2026-07-01", "1", "2026-07-01", "", nil},
+	}
+	for _, tt := range tests {
+		r, err := f.Rate(mustCurrency(t, tt.from), mustCurrency(t, tt.to), date(tt.at))
+		if !errors.Is(err, tt.expectErr) {
+			t.Errorf("Rate(%s, %s, %s) error = %v, want %v", tt.from, tt.to, tt.at, err, tt.expectErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		want, _ := new(big.Rat).SetString(tt.rate)
+		if r.Rate.Cmp(want) != 0 || r.Date.Format(DateLayout) != tt.date || r.Via != tt.via {
+			t.Errorf("Rate(%s, %s, %s) = %s on %s via %q, want %s on %s via %q", tt.from, tt.to, tt.at,
+				r.Rate.RatString(), r.Date.Format(DateLayout), r.Via, want.RatString(), tt.date, tt.via)
+		}
+	}
+}
+
+func TestFileRatesReload(t *testing.T) {
+	path := writeFile(t, "rates.json", `[{"from": "USD", "to": "EUR", "rate": "0.9", "date": "2026-01-01"}]`)
+	f, err := NewFileRates(path, mustCurrency(t, "USD"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	usd, eur := mustCurrency(t, "USD"), mustCurrency(t, "EUR")
+	at := date("2026-07-01")
+
+	if err := os.WriteFile(path, []byte(`[{"from": "USD", "to": "EUR", "rate": "0.95", "date": "2026-02-01"}]`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	if r, _ := f.Rate(usd, eur, at); r.Rate.Cmp(big.NewRat(19, 20)) != 0 {
+		t.Errorf("rate after reload = %s, want 19/20", r.Rate.RatString())
+	}
+
+	if err := os.WriteFile(path, []byte(`[{"from": "USD", "to": "EUR", "rate": "-1", "date": "2026-03-01"}]`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Reload(); !errors.Is(err, ErrInvalidRates) {
+		t.Errorf("Reload() error = %v, want %v", err, ErrInvalidRates)
+	}
+	if r, _ := f.Rate(usd, eur, at); r.Rate.Cmp(big.NewRat(19, 20)) != 0 {
+		t.Errorf("rate after failed reload = %s, want 19/20", r.Rate.RatString())
+	}
+}
+
+func TestFileRatesErrors(t *testing.T) {
+	tests := []struct {
+		name, file, content string
+		expectErr           error
+	}{
+		{"bad header", "r.csv", "a,b,c,d\nUSD,EUR,1,2026-01-01\n", ErrInvalidRates},
+		{"bad date", "r.csv", "from,to,rate,date\nUSD,EUR,1,01/02/2026\n", ErrInvalidRates},
+		{"unknown currency", "r.csv", "from,to,rate,date\nUSD,XYZ,1,2026-01-01\n", ErrUnknownCurrency},
+		{"zero rate", "r.json", `[{"from": "USD", "to": "EUR", "rate": "0", "date": "2026-01-01"}]`, ErrInvalidRates},
+		{"duplicate", "r.csv", "from,to,rate,date\nUSD,EUR,1,2026-01-01\nUSD,EUR,2,2026-01-01\n", ErrInvalidRates},
+		{"extension", "r.txt", "", ErrInvalidRates},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewFileRates(writeFile(t, tt.file, tt.content), mustCurrency(t, "USD"))
+			if !errors.Is(err, tt.expectErr) {
+				t.Errorf("NewFileRates() error = %v, want %v", err, tt.expectErr)
+			}
+		})
+	}
+}
+
+func TestConvert(t *testing.T) {
+	r := Rate{From: mustCurrency(t, "USD"), To: mustCurrency(t, "JPY"), Rate: big.NewRat(30025, 200)}
+	got, err := mustParse(t, "10.00", "USD").Convert(r, calculator.HalfEven)
+	if err != nil || got.String() != "1501" {
+		t.Errorf("Convert = %s, %v, want 1501", got, err)
+	}
+	if _, err := mustParse(t, "1", "EUR").Convert(r, calculator.HalfEven); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Convert(EUR) error = %v", err)
+	}
+}
@@ -0,0 +1,167 @@
+package numeric
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/igorgatis/sezzle/backend/pkg/internal/calculator"
+)
+
+const (
+	Central    Method = "central"
+	Richardson Method = "richardson"
+)
+
+// DerivativeMethods lists the methods of Differentiate.
+var DerivativeMethods = []Method{Central, Richardson}
+
+// MaxOrder is the highest derivative Differentiate computes.
+const MaxOrder = 2
+
+// Ridders' method starts from riddersStep times max(1, |x|) and shrinks
+// the step by riddersShrink up to riddersSteps times, stopping early once
+// the estimates grow worse by riddersSafe.
+const (
+	riddersStep   = 0.1
+	riddersSteps  = 10
+	riddersShrink = 1.4
+	riddersSafe   = 2.0
+)
+
+var (
+	ErrInvalidOrder = &calculator.Error{Code: "invalid_order", Message: "invalid derivative order"}
+	ErrInvalidStep  = &calculator.Error{Code: "invalid_step", Message: "step must be positive and finite"}
+)
+
+// Derivative is a derivative and an estimate of its absolute error.
+type Derivative struct {
+	Value, Error float64
+	Evaluations  int
+}
+
+// Differentiate computes the order-th derivative of f at x, order being 1
+// or 2. Central takes one central difference with step h, estimating its
+// error from a second difference with step 2h plus the rounding error of
+// both. Richardson is Ridders' method: central differences with steps
+// shrinking from h extrapolated to a zero step, with the error estimated
+// from the tableau. When step is 0, h defaults to a multiple of
+// max(1, |x|): about the cube root (first order) or fourth root (second
+// order) of the machine epsilon for Central, and 0.1 for Richardson.
+func Differentiate(f Func, x float64, order int, method Method, step float64) (Derivative, error) {
+	if method != Central && method != Richardson {
+		return Derivative{}, fmt.Errorf("%w: %q", ErrInvalidMethod, method)
+	}
+	if order < 1 || order > MaxOrder {
+		return Derivative{}, fmt.Errorf("%w: %d, want 1 to %d", ErrInvalidOrder, order, MaxOrder)
+	}
+	if step < 0 || math.IsNaN(step) || math.IsInf(step, 0) {
+		return Derivative{}, fmt.Errorf("%w: %g", ErrInvalidStep, step)
+	}
+	d := differ{f: f, x: x, order: order}
+	fx, err := d.eval(x)
+	if err != nil {
+		return Derivative{}, err
+	}
+	d.fx = fx
+	scale := math.Max(1, math.Abs(x))
+	var r Derivative
+	if method == Richardson {
+		if step == 0 {
+			step = riddersStep * scale
+		}
+		r, err = d.ridders(step)
+	} else {
+		if step == 0 {
+			step = math.Pow(eps, 1/float64(order+2)) * scale
+		}
+		r, err = d.central(step)
+	}
+	if err != nil {
+		return Derivative{}, err
+	}
+	if !finite(r.Value) || !finite(r.Error) {
+		return Derivative{}, fmt.Errorf("%w: derivative at %g", calculator.ErrOverflow, x)
+	}
+	r.Evaluations = d.evaluations
+	return r, nil
+}
+
+type differ struct {
+	f           Func
+	x, fx       float64
+	order       int
+	evaluations int
+	// fmax is the largest |f| seen, which bounds the rounding error of
+	// the differences.
+	fmax float64
+}
+
+func (d *differ) eval(x float64) (float64, error) {
+	d.evaluations++
+	y, err := eval(d.f, x)
+	d.fmax = math.Max(d.fmax, math.Abs(y))
+	return y, err
+}
+
+// difference is the central difference with step h, which is adjusted so
+// that x ± h are exact, and that step.
+func (d *differ) difference(h float64) (float64, float64, error) {
+	if h = (d.x + h) - d.x; h == 0 {
+		return 0, 0, fmt.Errorf("%w: step vanishes next to %g", ErrInvalidStep, d.x)
+	}
+	hi, err := d.eval(d.x + h)
+	if err != nil {
+		return 0, 0, err
+	}
+	lo, err := d.eval(d.x - h)
+	if err != nil {
+		return 0, 0, err
+	}
+	if d.order == 1 {
+		return (hi - lo) / (2 * h), h, nil
+	}
+	return (hi - 2*d.fx + lo) / (h * h), h, nil
+}
+
+func (d *differ) central(h float64) (Derivative, error) {
+	d1, h, err := d.difference(h)
+	if err != nil {
+		return Derivative{}, err
+	}
+	d2, _, err := d.difference(2 * h)
+	if err != nil {
+		return Derivative{}, err
+	}
+	// The truncation errors of the two steps are in the ratio 1:4.
+	rounding := 4 * eps * d.fmax / math.Pow(h, float64(d.order))
+	return Derivative{Value: d1, Error: math.Abs(d2-d1)/3 + rounding}, nil
+}
+
+// ridders is Ridders' method from Numerical Recipes' dfridr, extrapolating
+// with Neville's algorithm in the square of the step.
+func (d *differ) ridders(h float64) (Derivative, error) {
+	var a [riddersSteps][riddersSteps]float64
+	var err error
+	if a[0][0], h, err = d.difference(h); err != nil {
+		return Derivative{}, err
+	}
+	best := Derivative{Value: a[0][0], Error: math.Inf(1)}
+	for i := 1; i < riddersSteps; i++ {
+		if a[0][i], h, err = d.difference(h / riddersShrink); err != nil {
+			return Derivative{}, err
+		}
+		fac := riddersShrink * riddersShrink
+		for j := 1; j <= i; j++ {
+			a[j][i] = (a[j-1][i]*fac - a[j-1][i-1]) / (fac - 1)
+			fac *= riddersShrink * riddersShrink
+			e := math.Max(math.Abs(a[j][i]-a[j-1][i]), math.Abs(a[j][i]-a[j-1][i-1]))
+			if e <= best.Error {
+				best = Derivative{Value: a[j][i], Error: e}
+			}
+		}
+		if math.Abs(a[i][i]-a[i-1][i-1]) >= riddersSafe*best.Error {
+			break
+		}
+	}
+	return best, nil
+}
@@ -0,0 +1,91 @@
+package numeric
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/igorgatis/sezzle/backend/pkg/internal/calculator"
+)
+
+func TestDifferentiate(t *testing.T) {
+	tests := []struct {
+		name  string
+		f     Func
+		x     float64
+		order int
+		want  float64
+	}{
+		{"sin", pure(math.Sin), 1, 1, math.Cos(1)},
+		{"sin second", pure(math.Sin), 1, 2, -math.Sin(1)},
+		{"exp", pure(math.Exp), 2, 1, math.Exp(2)},
+		{"exp second", pure(math.Exp), 2, 2, math.Exp(2)},
+		{"cubic", pure(func(x float64) float64 { return x*x*x - 2*x }), 3, 1, 25},
+		{"large x", pure(math.Log), 1e6, 1, 1e-6},
+		{"kink", pure(math.Abs), 1, 1, 1},
+	}
+	// Central differences lose about a third of the digits, or half for
+	// second derivatives; extrapolation recovers most of them.
+	accuracy := map[Method][]float64{Central: {1e-9, 1e-5}, Richardson: {1e-12, 1e-9}}
+	for _, m := range DerivativeMethods {
+		for _, tt := range tests {
+			t.Run(string(m)+" "+tt.name, func(t *testing.T) {
+				d, err := Differentiate(tt.f, tt.x, tt.order, m, 0)
+				if err != nil {
+					t.Fatal(err)
+				}
+				got := math.Abs(d.Value - tt.want)
+				if got > accuracy[m][tt.order-1]*math.Max(1, math.Abs(tt.want)) {
+					t.Errorf("derivative = %v, want %v (error %g)", d.Value, tt.want, got)
+				}
+				if got > 10*d.Error+1e-15 {
+					t.Errorf("error %g, estimated %g", got, d.Error)
+				}
+				if d.Evaluations < 5 {
+					t.Errorf("%d evaluations", d.Evaluations)
+				}
+			})
+		}
+	}
+
+	d, err := Differentiate(pure(math.Sin), 0, 1, Central, 1e-3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := math.Sin(1e-3) / 1e-3; math.Abs(d.Value-want) > 1e-15 {
+		t.Errorf("derivative with step 1e-3 = %v, want %v", d.Value, want)
+	}
+}
+
+func TestDifferentiateErrors(t *testing.T) {
+	sqrt := func(x float64) (float64, error) {
+		if x < 0 {
+			return 0, calculator.ErrNegativeSqrt
+		}
+		return math.Sqrt(x), nil
+	}
+	tests := []struct {
+		name      string
+		x         float64
+		order     int
+		method    Method
+		step      float64
+		expectErr error
+	}{
+		{"unknown method", 1, 1, "forward", 0, ErrInvalidMethod},
+		{"order zero", 1, 0, Central, 0, ErrInvalidOrder},
+		{"order three", 1, 3, Central, 0, ErrInvalidOrder},
+		{"negative step", 1, 1, Central, -1, ErrInvalidStep},
+		{"vanishing step", 1e20, 1, Central, 1e-3, ErrInvalidStep},
+		{"outside domain", -1, 1, Central, 0, calculator.ErrNegativeSqrt},
+		{"domain edge", 0, 1, Richardson, 0, calculator.ErrNegativeSqrt},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Differentiate(sqrt, tt.x, tt.order, tt.method, tt.step)
+			if !errors.Is(err, tt.expectErr) {
+				t.Errorf("Differentiate error = %v, want %v", err, tt.expectErr)
+			}
+		})
+	}
+}
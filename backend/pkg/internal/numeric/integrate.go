@@ -0,0 +1,314 @@
+package numeric
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/igorgatis/sezzle/backend/pkg/internal/calculator"
+)
+
+const (
+	Simpson      Method = "simpson"
+	GaussKronrod Method = "gauss_kronrod"
+)
+
+// IntegrationMethods lists the methods of Integrate.
+var IntegrationMethods = []Method{Simpson, GaussKronrod}
+
+const (
+	DefaultIntegrationTolerance = 1e-10
+	DefaultMaxEvaluations       = 100_000
+	// MaxEvaluations bounds the evaluation limit callers may ask for.
+	MaxEvaluations = 1_000_000
+	// maxSimpsonDepth bounds the bisections of adaptive Simpson, beyond
+	// which the integrand is taken to be singular.
+	maxSimpsonDepth = 50
+	// maxStalledSplits is the number of bisections in a row that may fail
+	// to reduce the error estimate, as near poles, before Gauss-Kronrod
+	// gives up.
+	maxStalledSplits = 20
+)
+
+var (
+	ErrInvalidInterval   = &calculator.Error{Code: "invalid_interval", Message: "integration bounds must be finite"}
+	ErrEvaluationLimit   = &calculator.Error{Code: "invalid_evaluation_limit", Message: "invalid evaluation limit"}
+	ErrSingularIntegrand = &calculator.Error{Code: "singular_integrand", Message: "integrand is not finite or undefined"}
+	ErrDivergent         = &calculator.Error{Code: "divergent_integral", Message: "integral did not converge"}
+)
+
+// Quadrature configures Integrate.
+type Quadrature struct {
+	Method Method
+	// Tolerance is the absolute error to reach,
+	// DefaultIntegrationTolerance when 0. Targets below the rounding error
+	// of the result are raised to it.
+	Tolerance float64
+	// MaxEvaluations is DefaultMaxEvaluations when 0.
+	MaxEvaluations int
+}
+
+// Integral is a definite integral and an estimate of its absolute error.
+type Integral struct {
+	Value, Error float64
+	Evaluations  int
+	// Intervals is the number of subintervals the range ended up split
+	// into.
+	Intervals int
+}
+
+// Integrate computes the integral of f from a to b. Simpson evaluates f
+// at the bounds while Gauss-Kronrod does not, so only the latter handles
+// integrable singularities there. It fails with ErrSingularIntegrand when
+// f cannot be evaluated, with ErrDivergent when the error estimate does
+// not reach the tolerance within the evaluation limit, stops decreasing
+// as subintervals are split or needs them too small, and with ErrTimeout
+// when ctx is done first.
+func Integrate(ctx context.Context, f Func, a, b float64, q Quadrature) (Integral, error) {
+	if q.Method != Simpson && q.Method != GaussKronrod {
+		return Integral{}, fmt.Errorf("%w: %q", ErrInvalidMethod, q.Method)
+	}
+	if !finite(a) || !finite(b) {
+		return Integral{}, fmt.Errorf("%w: [%g, %g]", ErrInvalidInterval, a, b)
+	}
+	if q.Tolerance == 0 {
+		q.Tolerance = DefaultIntegrationTolerance
+	}
+	if !(q.Tolerance > 0) || math.IsInf(q.Tolerance, 0) {
+		return Integral{}, fmt.Errorf("%w: %g", ErrInvalidTolerance, q.Tolerance)
+	}
+	if q.MaxEvaluations == 0 {
+		q.MaxEvaluations = DefaultMaxEvaluations
+	}
+	if q.MaxEvaluations < 0 || q.MaxEvaluations > MaxEvaluations {
+		return Integral{}, fmt.Errorf("%w: %d, want 1 to %d", ErrEvaluationLimit, q.MaxEvaluations, MaxEvaluations)
+	}
+	in := integrator{ctx: ctx, f: f, tol: q.Tolerance, limit: q.MaxEvaluations}
+	if a == b {
+		return Integral{}, nil
+	}
+	sign := 1.0
+	if a > b {
+		a, b, sign = b, a, -1
+	}
+	integrate := in.gaussKronrod
+	if q.Method == Simpson {
+		integrate = in.simpson
+	}
+	r, err := integrate(a, b)
+	r.Value *= sign
+	return r, err
+}
+
+type integrator struct {
+	ctx         context.Context
+	f           Func
+	tol         float64
+	limit       int
+	evaluations int
+}
+
+func (in *integrator) eval(x float64) (float64, error) {
+	if in.evaluations == in.limit {
+		return 0, fmt.Errorf("%w: evaluation limit of %d reached", ErrDivergent, in.limit)
+	}
+	if in.evaluations%256 == 0 {
+		if err := in.ctx.Err(); err != nil {
+			return 0, fmt.Errorf("%w: after %d evaluations", ErrTimeout, in.evaluations)
+		}
+	}
+	in.evaluations++
+	y, err := eval(in.f, x)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrSingularIntegrand, err)
+	}
+	if !finite(y) {
+		return 0, fmt.Errorf("%w: f(%g) = %g", ErrSingularIntegrand, x, y)
+	}
+	return y, nil
+}
+
+// target is the error to reach for an integral near value.
+func (in *integrator) target(value float64) float64 {
+	return math.Max(in.tol, 50*eps*math.Abs(value))
+}
+
+func (in *integrator) simpson(a, b float64) (Integral, error) {
+	fa, err := in.eval(a)
+	if err != nil {
+		return Integral{}, err
+	}
+	fm, err := in.eval(a/2 + b/2)
+	if err != nil {
+		return Integral{}, err
+	}
+	fb, err := in.eval(b)
+	if err != nil {
+		return Integral{}, err
+	}
+	var r Integral
+	whole := (b - a) / 6 * (fa + 4*fm + fb)
+	if !finite(whole) {
+		return Integral{}, fmt.Errorf("%w: sum overflows near %g", ErrDivergent, a/2+b/2)
+	}
+	if err := in.simpsonStep(&r, a, b, fa, fm, fb, whole, in.tol, 0); err != nil {
+		return Integral{}, err
+	}
+	if !finite(r.Value) || !finite(r.Error) {
+		return Integral{}, fmt.Errorf("%w: sum overflows", ErrDivergent)
+	}
+	r.Evaluations = in.evaluations
+	return r, nil
+}
+
+// simpsonStep compares Simpson's rule on [a, b], whole, with its sum over
+// both halves, recursing into the halves with half the tolerance each
+// until they agree. Agreeing sums are combined by Richardson
+// extrapolation, their difference being 15 times the error of the halves.
+func (in *integrator) simpsonStep(r *Integral, a, b, fa, fm, fb, whole, tol float64, depth int) error {
+	m := a/2 + b/2
+	flm, err := in.eval(a/2 + m/2)
+	if err != nil {
+		return err
+	}
+	frm, err := in.eval(m/2 + b/2)
+	if err != nil {
+		return err
+	}
+	left := (m - a) / 6 * (fa + 4*flm + fm)
+	right := (b - m) / 6 * (fm + 4*frm + fb)
+	delta := left + right - whole
+	if !finite(left+right) || !finite(delta) {
+		return fmt.Errorf("%w: sum overflows near %g", ErrDivergent, m)
+	}
+	if math.Abs(delta) <= 15*math.Max(tol, 50*eps*math.Abs(left+right)) {
+		r.Value += left + right + delta/15
+		r.Error += math.Abs(delta) / 15
+		r.Intervals += 2
+		return nil
+	}
+	if depth == maxSimpsonDepth {
+		return fmt.Errorf("%w: error estimate %g near %g after %d bisections", ErrDivergent, math.Abs(delta)/15, m, depth)
+	}
+	if err := in.simpsonStep(r, a, m, fa, flm, fm, left, tol/2, depth+1); err != nil {
+		return err
+	}
+	return in.simpsonStep(r, m, b, fm, frm, fb, right, tol/2, depth+1)
+}
+
+// Nodes and weights of the 15-point Kronrod rule and the 7-point Gauss rule
+// embedded in it, from QUADPACK's qk15. Nodes are in decreasing order; the
+// Gauss rule uses xgk[1], xgk[3], xgk[5] and the center xgk[7].
+var (
+	xgk = [8]float64{
+		0.991455371120812639206854697526329,
+		0.949107912342758524526189684047851,
+		0.864864423359769072789712788640926,
+		0.741531185599394439863864773280788,
+		0.586087235467691130294144845693013,
+		0.405845151377397166906606412076961,
+		0.207784955007898467600689403773245,
+		0,
+	}
+	wgk = [8]float64{
+		0.022935322010529224963732008058970,
+		0.063092092629978553290700663189204,
+		0.104790010322250183839876322541518,
+		0.140653259715525918745189590510238,
+		0.169004726639267902826583426598550,
+		0.190350578064785409913256402421014,
+		0.204432940075298892414161999234649,
+		0.209482141084727828012999174891714,
+	}
+	wg = [4]float64{
+		0.129484966168869693270611432679082,
+		0.279705391489276667901467771423780,
+		0.381830050505118944950369775488975,
+		0.417959183673469387755102040816327,
+	}
+)
+
+type interval struct {
+	a, b, value, err float64
+}
+
+// kronrod applies the Gauss-Kronrod pair to [a, b], taking the difference
+// of the two rules as the error of the more accurate Kronrod one.
+func (in *integrator) kronrod(a, b float64) (interval, error) {
+	c, h := a/2+b/2, (b-a)/2
+	fc, err := in.eval(c)
+	if err != nil {
+		return interval{}, err
+	}
+	k, g := wgk[7]*fc, wg[3]*fc
+	for j := range 7 {
+		x := h * xgk[j]
+		f1, err := in.eval(c - x)
+		if err != nil {
+			return interval{}, err
+		}
+		f2, err := in.eval(c + x)
+		if err != nil {
+			return interval{}, err
+		}
+		k += wgk[j] * (f1 + f2)
+		if j%2 == 1 {
+			g += wg[j/2] * (f1 + f2)
+		}
+	}
+	return interval{a: a, b: b, value: k * h, err: math.Abs((k - g) * h)}, nil
+}
+
+// gaussKronrod bisects the subinterval of largest error estimate until the
+// total error is within the tolerance, like QUADPACK's qag.
+func (in *integrator) gaussKronrod(a, b float64) (Integral, error) {
+	first, err := in.kronrod(a, b)
+	if err != nil {
+		return Integral{}, err
+	}
+	intervals := []interval{first}
+	stalled := 0
+	for {
+		var r Integral
+		worst := 0
+		for i, iv := range intervals {
+			r.Value += iv.value
+			r.Error += iv.err
+			if iv.err > intervals[worst].err {
+				worst = i
+			}
+		}
+		if !finite(r.Value) || !finite(r.Error) {
+			return Integral{}, fmt.Errorf("%w: sum overflows near %g", ErrDivergent, intervals[worst].a)
+		}
+		if r.Error <= in.target(r.Value) {
+			r.Evaluations, r.Intervals = in.evaluations, len(intervals)
+			return r, nil
+		}
+		iv := intervals[worst]
+		m := iv.a/2 + iv.b/2
+		if iv.b-iv.a <= 4*eps*math.Abs(m) {
+			return Integral{}, fmt.Errorf("%w: error estimate %g near %g, which cannot be subdivided further",
+				ErrDivergent, r.Error, m)
+		}
+		if in.evaluations+30 > in.limit {
+			return Integral{}, fmt.Errorf("%w: error estimate %g when the evaluation limit of %d was reached",
+				ErrDivergent, r.Error, in.limit)
+		}
+		left, err := in.kronrod(iv.a, m)
+		if err != nil {
+			return Integral{}, err
+		}
+		right, err := in.kronrod(m, iv.b)
+		if err != nil {
+			return Integral{}, err
+		}
+		if left.err+right.err < iv.err {
+			stalled = 0
+		} else if stalled++; stalled == maxStalledSplits {
+			return Integral{}, fmt.Errorf("%w: error estimate %g is not decreasing near %g", ErrDivergent, r.Error, m)
+		}
+		intervals[worst] = left
+		intervals = append(intervals, right)
+	}
+}
@@ -0,0 +1,124 @@
+package numeric
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/igorgatis/sezzle/backend/pkg/internal/calculator"
+)
+
+func TestKronrodWeights(t *testing.T) {
+	k, g := wgk[7], wg[3]
+	for j := range 7 {
+		k += 2 * wgk[j]
+		if j%2 == 1 {
+			g += 2 * wg[j/2]
+		}
+	}
+	if math.Abs(k-2) > 1e-15 || math.Abs(g-2) > 1e-15 {
+		t.Errorf("weights sum to %v and %v, want 2", k, g)
+	}
+}
+
+func TestIntegrate(t *testing.T) {
+	tests := []struct {
+		name string
+		f    Func
+		a, b float64
+		want float64
+	}{
+		{"polynomial", pure(func(x float64) float64 { return 3*x*x - 2*x + 1 }), 0, 2, 6},
+		{"reversed", pure(func(x float64) float64 { return 3*x*x - 2*x + 1 }), 2, 0, -6},
+		{"empty", pure(math.Exp), 1, 1, 0},
+		{"sin", pure(math.Sin), 0, math.Pi, 2},
+		{"exp", pure(math.Exp), -1, 3, math.Exp(3) - math.Exp(-1)},
+		{"gaussian", pure(func(x float64) float64 { return math.Exp(-x * x) }), -5, 5, math.Sqrt(math.Pi) * math.Erf(5)},
+		{"oscillating", pure(func(x float64) float64 { return math.Cos(50 * x) }), 0, 1, math.Sin(50) / 50},
+		{"peak", pure(func(x float64) float64 { return 1 / (1e-4 + x*x) }), -1, 1, 2 * math.Atan(100) * 100},
+		{"kink", pure(math.Abs), -1, 2, 2.5},
+	}
+	for _, m := range IntegrationMethods {
+		for _, tt := range tests {
+			t.Run(string(m)+" "+tt.name, func(t *testing.T) {
+				r, err := Integrate(context.Background(), tt.f, tt.a, tt.b, Quadrature{Method: m})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got := math.Abs(r.Value - tt.want); got > DefaultIntegrationTolerance+1e-14*math.Abs(tt.want) {
+					t.Errorf("integral = %v, want %v (error %g)", r.Value, tt.want, got)
+				}
+				if got := math.Abs(r.Value - tt.want); got > 10*r.Error+1e-14*math.Abs(tt.want) {
+					t.Errorf("error %g, estimated %g", got, r.Error)
+				}
+				if tt.a != tt.b && (r.Evaluations < 5 || r.Intervals < 1) {
+					t.Errorf("%d evaluations in %d intervals", r.Evaluations, r.Intervals)
+				}
+			})
+		}
+	}
+}
+
+func TestIntegrateEndpointSingularity(t *testing.T) {
+	f := pure(func(x float64) float64 { return 1 / math.Sqrt(x) })
+	r, err := Integrate(context.Background(), f, 0, 1, Quadrature{Method: GaussKronrod})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(r.Value-2) > 1e-9 {
+		t.Errorf("integral = %v, want 2", r.Value)
+	}
+	if _, err := Integrate(context.Background(), f, 0, 1, Quadrature{Method: Simpson}); !errors.Is(err, ErrSingularIntegrand) {
+		t.Errorf("Simpson error = %v, want %v", err, ErrSingularIntegrand)
+	}
+}
+
+func TestIntegrateErrors(t *testing.T) {
+	inv := func(x float64) (float64, error) {
+		if x == 0 {
+			return 0, calculator.ErrDivisionByZero
+		}
+		return 1 / x, nil
+	}
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		f         Func
+		a, b      float64
+		q         Quadrature
+		expectErr error
+	}{
+		{"unknown method", nil, inv, 1, 2, Quadrature{Method: "trapezoid"}, ErrInvalidMethod},
+		{"infinite bound", nil, inv, 1, math.Inf(1), Quadrature{Method: Simpson}, ErrInvalidInterval},
+		{"negative tolerance", nil, inv, 1, 2, Quadrature{Method: Simpson, Tolerance: -1}, ErrInvalidTolerance},
+		{"limit too large", nil, inv, 1, 2, Quadrature{Method: Simpson, MaxEvaluations: MaxEvaluations + 1},
+			ErrEvaluationLimit},
+		{"simpson pole", nil, inv, -1, 1, Quadrature{Method: Simpson}, ErrSingularIntegrand},
+		{"simpson pole inside", nil, inv, -1, 2, Quadrature{Method: Simpson}, ErrDivergent},
+		{"simpson overflow", nil, pure(func(float64) float64 { return 1 }), -1.7e308, 1.7e308,
+			Quadrature{Method: Simpson}, ErrDivergent},
+		{"simpson sum overflow", nil, pure(func(float64) float64 { return 1e308 }), 0, 10,
+			Quadrature{Method: Simpson}, ErrDivergent},
+		{"kronrod divergent", nil, inv, 0, 1, Quadrature{Method: GaussKronrod}, ErrDivergent},
+		{"kronrod pole inside", nil, pure(func(x float64) float64 { return 1 / (x - 0.3) }), 0, 1,
+			Quadrature{Method: GaussKronrod}, ErrSingularIntegrand},
+		{"evaluation limit", nil, pure(math.Sin), 0, 100, Quadrature{Method: GaussKronrod, MaxEvaluations: 30},
+			ErrDivergent},
+		{"canceled", canceled, pure(math.Sin), 0, 1, Quadrature{Method: Simpson}, ErrTimeout},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := tt.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			_, err := Integrate(ctx, tt.f, tt.a, tt.b, tt.q)
+			if !errors.Is(err, tt.expectErr) {
+				t.Errorf("Integrate error = %v, want %v", err, tt.expectErr)
+			}
+		})
+	}
+}
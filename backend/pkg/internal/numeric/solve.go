@@ -0,0 +1,308 @@
+package numeric
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/igorgatis/sezzle/backend/pkg/internal/calculator"
+)
+
+const (
+	DefaultTolerance     = 1e-12
+	DefaultMaxIterations = 200
+	// MaxIterations bounds the iteration limit callers may ask for.
+	MaxIterations = 10000
+)
+
+// eps is the spacing of float64 values at 1. Convergence tests add a few
+// of them relative to x, since tolerances below that cannot be met.
+const eps = calculator.Epsilon
+
+var (
+	ErrInvalidMethod    = &calculator.Error{Code: "invalid_method", Message: "invalid method"}
+	ErrInvalidBracket   = &calculator.Error{Code: "invalid_bracket", Message: "invalid bracket"}
+	ErrMissingGuess     = &calculator.Error{Code: "missing_guess", Message: "an initial guess or bracket is required"}
+	ErrInvalidTolerance = &calculator.Error{Code: "invalid_tolerance", Message: "tolerance must be positive and finite"}
+	ErrInvalidLimit     = &calculator.Error{Code: "invalid_iteration_limit", Message: "invalid iteration limit"}
+	ErrTimeout          = &calculator.Error{Code: "timeout", Message: "computation timed out"}
+)
+
+// Func is a function of one variable whose evaluation may fail, such as a
+// compiled expression.
+type Func func(x float64) (float64, error)
+
+type Method string
+
+const (
+	Bisection Method = "bisection"
+	Newton    Method = "newton"
+	Secant    Method = "secant"
+	Brent     Method = "brent"
+)
+
+// RootMethods lists the methods of Solve.
+var RootMethods = []Method{Bisection, Newton, Secant, Brent}
+
+// Problem describes where and how to look for a root.
+type Problem struct {
+	Method Method
+	// Bracket holds two points where f has opposite signs. Bisection and
+	// Brent require it; the secant method starts from its ends and Newton
+	// from its midpoint when Guess is nil.
+	Bracket *[2]float64
+	// Guess is the starting point of Newton and the secant method.
+	Guess *float64
+	// Tolerance is the absolute error in x to reach, DefaultTolerance when
+	// 0.
+	Tolerance float64
+	// MaxIterations is DefaultMaxIterations when 0.
+	MaxIterations int
+}
+
+// Root is a solution of f(x) = 0.
+type Root struct {
+	X, FX      float64
+	Iterations int
+	// Tolerance bounds the error in X: the half-width of the final
+	// bracket for bisection and Brent, and the size of the last step for
+	// Newton and the secant method. It is 0 when f(X) is exactly 0.
+	Tolerance float64
+}
+
+// Solve finds a root of f with p.Method. It fails with
+// calculator.ErrNoConvergence when the iteration limit is reached or the
+// method breaks down, and with ErrTimeout when ctx is done first.
+func Solve(ctx context.Context, f Func, p Problem) (Root, error) {
+	if p.Tolerance == 0 {
+		p.Tolerance = DefaultTolerance
+	}
+	if !(p.Tolerance > 0) || math.IsInf(p.Tolerance, 0) {
+		return Root{}, fmt.Errorf("%w: %g", ErrInvalidTolerance, p.Tolerance)
+	}
+	if p.MaxIterations == 0 {
+		p.MaxIterations = DefaultMaxIterations
+	}
+	if p.MaxIterations < 0 || p.MaxIterations > MaxIterations {
+		return Root{}, fmt.Errorf("%w: %d, want 1 to %d", ErrInvalidLimit, p.MaxIterations, MaxIterations)
+	}
+	if p.Bracket != nil {
+		a, b := p.Bracket[0], p.Bracket[1]
+		if !finite(a) || !finite(b) || a == b {
+			return Root{}, fmt.Errorf("%w: [%g, %g] must be two distinct finite points", ErrInvalidBracket, a, b)
+		}
+	}
+	s := solver{ctx: ctx, f: f, tol: p.Tolerance, limit: p.MaxIterations}
+	switch p.Method {
+	case Bisection, Brent:
+		if p.Bracket == nil {
+			return Root{}, fmt.Errorf("%w: %s needs a bracket", ErrInvalidBracket, p.Method)
+		}
+		if p.Method == Bisection {
+			return s.bisection(p.Bracket[0], p.Bracket[1])
+		}
+		return s.brent(p.Bracket[0], p.Bracket[1])
+	case Newton:
+		switch {
+		case p.Guess != nil:
+			return s.newton(*p.Guess)
+		case p.Bracket != nil:
+			return s.newton(p.Bracket[0]/2 + p.Bracket[1]/2)
+		}
+	case Secant:
+		switch {
+		case p.Guess != nil:
+			x := *p.Guess
+			return s.secant(x, x+1e-4*math.Max(1, math.Abs(x)))
+		case p.Bracket != nil:
+			return s.secant(p.Bracket[0], p.Bracket[1])
+		}
+	default:
+		return Root{}, fmt.Errorf("%w: %q", ErrInvalidMethod, p.Method)
+	}
+	return Root{}, ErrMissingGuess
+}
+
+type solver struct {
+	ctx   context.Context
+	f     Func
+	tol   float64
+	limit int
+	// iterations counts the iterations started.
+	iterations int
+}
+
+// next starts an iteration, failing when the limit is reached or the
+// context is done.
+func (s *solver) next() error {
+	if s.iterations == s.limit {
+		return fmt.Errorf("%w: after %d iterations", calculator.ErrNoConvergence, s.limit)
+	}
+	if err := s.ctx.Err(); err != nil {
+		return fmt.Errorf("%w: after %d iterations", ErrTimeout, s.iterations)
+	}
+	s.iterations++
+	return nil
+}
+
+func (s *solver) eval(x float64) (float64, error) {
+	return eval(s.f, x)
+}
+
+// done reports whether a step of size d at x meets the tolerance.
+func (s *solver) done(d, x float64) bool {
+	return math.Abs(d) <= s.tol+4*eps*math.Abs(x)
+}
+
+func (s *solver) root(x, fx, tol float64) (Root, error) {
+	return Root{X: x, FX: fx, Iterations: s.iterations, Tolerance: math.Abs(tol)}, nil
+}
+
+// bracket evaluates f at the ends of [a, b], failing unless the signs
+// differ.
+func (s *solver) bracket(a, b float64) (fa, fb float64, err error) {
+	if fa, err = s.eval(a); err != nil {
+		return 0, 0, err
+	}
+	if fb, err = s.eval(b); err != nil {
+		return 0, 0, err
+	}
+	if fa != 0 && fb != 0 && (fa > 0) == (fb > 0) {
+		return 0, 0, fmt.Errorf("%w: f(%g) = %g and f(%g) = %g have the same sign", ErrInvalidBracket, a, fa, b, fb)
+	}
+	return fa, fb, nil
+}
+
+func (s *solver) bisection(a, b float64) (Root, error) {
+	fa, fb, err := s.bracket(a, b)
+	switch {
+	case err != nil:
+		return Root{}, err
+	case fa == 0:
+		return s.root(a, 0, 0)
+	case fb == 0:
+		return s.root(b, 0, 0)
+	}
+	for {
+		m := a/2 + b/2
+		if s.done((b-a)/2, m) || m == a || m == b {
+			fm, err := s.eval(m)
+			if err != nil {
+				return Root{}, err
+			}
+			return s.root(m, fm, (b-a)/2)
+		}
+		if err := s.next(); err != nil {
+			return Root{}, err
+		}
+		fm, err := s.eval(m)
+		switch {
+		case err != nil:
+			return Root{}, err
+		case fm == 0:
+			return s.root(m, 0, 0)
+		case (fm > 0) == (fa > 0):
+			a, fa = m, fm
+		default:
+			b = m
+		}
+	}
+}
+
+// brent is calculator.Brent, counting iterations and propagating
+// evaluation errors.
+func (s *solver) brent(a, b float64) (Root, error) {
+	fa, fb, err := s.bracket(a, b)
+	if err != nil {
+		return Root{}, err
+	}
+	x, fx, tol, err := calculator.Brent(s.eval, a, b, fa, fb, s.tol, s.next)
+	if err != nil {
+		return Root{}, err
+	}
+	return s.root(x, fx, tol)
+}
+
+// newton is Newton's method with the derivative estimated by central
+// differences.
+func (s *solver) newton(x float64) (Root, error) {
+	fx, err := s.eval(x)
+	if err != nil {
+		return Root{}, err
+	}
+	for fx != 0 {
+		if err := s.next(); err != nil {
+			return Root{}, err
+		}
+		h := math.Cbrt(eps) * math.Max(1, math.Abs(x))
+		hi, err := s.eval(x + h)
+		if err != nil {
+			return Root{}, err
+		}
+		lo, err := s.eval(x - h)
+		if err != nil {
+			return Root{}, err
+		}
+		df := (hi - lo) / (2 * h)
+		if df == 0 {
+			return Root{}, fmt.Errorf("%w: zero derivative at %g", calculator.ErrNoConvergence, x)
+		}
+		step := fx / df
+		if x -= step; !finite(x) {
+			return Root{}, fmt.Errorf("%w: diverged", calculator.ErrNoConvergence)
+		}
+		if fx, err = s.eval(x); err != nil {
+			return Root{}, err
+		}
+		if s.done(step, x) {
+			return s.root(x, fx, step)
+		}
+	}
+	return s.root(x, 0, 0)
+}
+
+func (s *solver) secant(x0, x1 float64) (Root, error) {
+	f0, err := s.eval(x0)
+	if err != nil {
+		return Root{}, err
+	}
+	if f0 == 0 {
+		return s.root(x0, 0, 0)
+	}
+	f1, err := s.eval(x1)
+	if err != nil {
+		return Root{}, err
+	}
+	for f1 != 0 {
+		if err := s.next(); err != nil {
+			return Root{}, err
+		}
+		if f1 == f0 {
+			return Root{}, fmt.Errorf("%w: f(%g) = f(%g)", calculator.ErrNoConvergence, x0, x1)
+		}
+		step := f1 * (x1 - x0) / (f1 - f0)
+		x0, f0 = x1, f1
+		if x1 -= step; !finite(x1) {
+			return Root{}, fmt.Errorf("%w: diverged", calculator.ErrNoConvergence)
+		}
+		if f1, err = s.eval(x1); err != nil {
+			return Root{}, err
+		}
+		if s.done(step, x1) {
+			return s.root(x1, f1, step)
+		}
+	}
+	return s.root(x1, 0, 0)
+}
+
+func eval(f Func, x float64) (float64, error) {
+	y, err := f(x)
+	if err != nil {
+		return 0, fmt.Errorf("f(%g): %w", x, err)
+	}
+	return y, nil
+}
+
+func finite(x float64) bool {
+	return !math.IsNaN(x) && !math.IsInf(x, 0)
+}
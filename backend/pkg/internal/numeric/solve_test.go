@@ -0,0 +1,140 @@
+package numeric
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/igorgatis/sezzle/backend/pkg/internal/calculator"
+)
+
+func pure(f func(float64) float64) Func {
+	return func(x float64) (float64, error) { return f(x), nil }
+}
+
+func bracket(a, b float64) *[2]float64 { return &[2]float64{a, b} }
+
+func guess(x float64) *float64 { return &x }
+
+func TestSolve(t *testing.T) {
+	cube := pure(func(x float64) float64 { return x*x*x - 2*x - 5 })
+	const cubeRoot = 2.0945514815423265
+	cos := pure(func(x float64) float64 { return math.Cos(x) - x })
+	const dottie = 0.7390851332151607
+	tests := []struct {
+		name string
+		f    Func
+		p    Problem
+		want float64
+	}{
+		{"bisection", cube, Problem{Method: Bisection, Bracket: bracket(2, 3)}, cubeRoot},
+		{"bisection reversed", cube, Problem{Method: Bisection, Bracket: bracket(3, 2)}, cubeRoot},
+		{"bisection large", pure(func(x float64) float64 { return x - 1e9 }),
+			Problem{Method: Bisection, Bracket: bracket(0, 2e9)}, 1e9},
+		{"brent", cube, Problem{Method: Brent, Bracket: bracket(2, 3)}, cubeRoot},
+		{"brent cos", cos, Problem{Method: Brent, Bracket: bracket(0, 1)}, dottie},
+		{"brent wide", pure(func(x float64) float64 { return x - 1 }),
+			Problem{Method: Brent, Bracket: bracket(-1.7e308, 1.7e308)}, 1},
+		{"newton", cube, Problem{Method: Newton, Guess: guess(2)}, cubeRoot},
+		{"newton bracket midpoint", cos, Problem{Method: Newton, Bracket: bracket(0, 1)}, dottie},
+		{"secant", cube, Problem{Method: Secant, Guess: guess(2)}, cubeRoot},
+		{"secant bracket", cos, Problem{Method: Secant, Bracket: bracket(0, 1)}, dottie},
+		{"exact end", cube, Problem{Method: Brent, Bracket: bracket(cubeRoot, 3)}, cubeRoot},
+		{"loose tolerance", cube, Problem{Method: Bisection, Bracket: bracket(2, 3), Tolerance: 1e-3}, cubeRoot},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := Solve(context.Background(), tt.f, tt.p)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tol := tt.p.Tolerance
+			if tol == 0 {
+				tol = DefaultTolerance
+			}
+			if math.Abs(r.X-tt.want) > tol*math.Max(1, tt.want) {
+				t.Errorf("root = %v, want %v", r.X, tt.want)
+			}
+			if r.Tolerance > tol*math.Max(1, tt.want) || math.Abs(r.X-tt.want) > 2*r.Tolerance+1e-15*math.Abs(tt.want) {
+				t.Errorf("tolerance = %g with root %v, want %v", r.Tolerance, r.X, tt.want)
+			}
+			if fx, _ := tt.f(r.X); fx != r.FX {
+				t.Errorf("f(x) = %v, want %v", r.FX, fx)
+			}
+		})
+	}
+}
+
+func TestSolveIterations(t *testing.T) {
+	cube := pure(func(x float64) float64 { return x*x*x - 2*x - 5 })
+	iterations := map[Method]int{}
+	for _, m := range RootMethods {
+		r, err := Solve(context.Background(), cube, Problem{Method: m, Bracket: bracket(2, 3)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		iterations[m] = r.Iterations
+	}
+	// Bisection halves the bracket until its half-width is within the
+	// tolerance; the others converge superlinearly.
+	if want := int(math.Ceil(math.Log2(0.5 / DefaultTolerance))); iterations[Bisection] != want {
+		t.Errorf("bisection took %d iterations, want %d", iterations[Bisection], want)
+	}
+	for _, m := range []Method{Newton, Secant, Brent} {
+		if iterations[m] < 1 || iterations[m] > 12 {
+			t.Errorf("%s took %d iterations", m, iterations[m])
+		}
+	}
+}
+
+func TestSolveErrors(t *testing.T) {
+	cube := pure(func(x float64) float64 { return x*x*x - 2*x - 5 })
+	errEval := &calculator.Error{Code: "test", Message: "test"}
+	failing := func(x float64) (float64, error) {
+		if x > 2.5 {
+			return 0, errEval
+		}
+		return x - 2.6, nil
+	}
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		f         Func
+		p         Problem
+		expectErr error
+	}{
+		{"unknown method", nil, cube, Problem{Method: "halley", Guess: guess(2)}, ErrInvalidMethod},
+		{"bisection without bracket", nil, cube, Problem{Method: Bisection, Guess: guess(2)}, ErrInvalidBracket},
+		{"brent same sign", nil, cube, Problem{Method: Brent, Bracket: bracket(3, 4)}, ErrInvalidBracket},
+		{"empty bracket", nil, cube, Problem{Method: Secant, Bracket: bracket(2, 2)}, ErrInvalidBracket},
+		{"newton without guess", nil, cube, Problem{Method: Newton}, ErrMissingGuess},
+		{"negative tolerance", nil, cube, Problem{Method: Newton, Guess: guess(2), Tolerance: -1}, ErrInvalidTolerance},
+		{"negative limit", nil, cube, Problem{Method: Newton, Guess: guess(2), MaxIterations: -1}, ErrInvalidLimit},
+		{"limit too large", nil, cube, Problem{Method: Newton, Guess: guess(2), MaxIterations: MaxIterations + 1},
+			ErrInvalidLimit},
+		{"iteration limit", nil, cube, Problem{Method: Bisection, Bracket: bracket(2, 3), MaxIterations: 5},
+			calculator.ErrNoConvergence},
+		{"no root", nil, pure(func(x float64) float64 { return x*x + 1 }), Problem{Method: Newton, Guess: guess(0)},
+			calculator.ErrNoConvergence},
+		{"flat secant", nil, pure(func(x float64) float64 { return 1 }), Problem{Method: Secant, Guess: guess(0)},
+			calculator.ErrNoConvergence},
+		{"diverges", nil, pure(math.Atan), Problem{Method: Newton, Guess: guess(1.5)},
+			calculator.ErrNoConvergence},
+		{"evaluation error", nil, failing, Problem{Method: Bisection, Bracket: bracket(0, 3)}, errEval},
+		{"canceled", canceled, cube, Problem{Method: Bisection, Bracket: bracket(2, 3)}, ErrTimeout},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := tt.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			if _, err := Solve(ctx, tt.f, tt.p); !errors.Is(err, tt.expectErr) {
+				t.Errorf("Solve error = %v, want %v", err, tt.expectErr)
+			}
+		})
+	}
+}
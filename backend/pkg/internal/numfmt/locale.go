@@ -0,0 +1,82 @@
+package numfmt
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/igorgatis/sezzle/backend/pkg/internal/calculator"
+)
+
+var ErrInvalidLocale = &calculator.Error{Code: "invalid_locale", Message: "unsupported locale"}
+
+// Locale holds the decimal separator and digit grouping of a locale.
+// Grouping lists group sizes from the decimal point; the last one repeats.
+type Locale struct {
+	Tag      string
+	Decimal  string
+	Group    string
+	Grouping []int
+}
+
+var locales = []Locale{
+	{"en-US", ".", ",", []int{3}},
+	{"en-GB", ".", ",", []int{3}},
+	{"en-IN", ".", ",", []int{3, 2}},
+	{"de-DE", ",", ".", []int{3}},
+	{"de-CH", ".", "’", []int{3}},
+	{"es-ES", ",", ".", []int{3}},
+	{"fr-FR", ",", "\u202f", []int{3}},
+	{"it-IT", ",", ".", []int{3}},
+	{"ja-JP", ".", ",", []int{3}},
+	{"nl-NL", ",", ".", []int{3}},
+	{"pt-BR", ",", ".", []int{3}},
+	{"ru-RU", ",", "\u00a0", []int{3}},
+	{"zh-CN", ".", ",", []int{3}},
+}
+
+// Locales returns the tags of the supported locales.
+func Locales() []string {
+	tags := make([]string, len(locales))
+	for i, l := range locales {
+		tags[i] = l.Tag
+	}
+	return tags
+}
+
+// ParseLocale looks up a locale by its BCP 47 tag, ignoring case and
+// accepting "_" for "-".
+func ParseLocale(tag string) (Locale, error) {
+	norm := strings.ReplaceAll(tag, "_", "-")
+	i := slices.IndexFunc(locales, func(l Locale) bool { return strings.EqualFold(l.Tag, norm) })
+	if i < 0 {
+		return Locale{}, fmt.Errorf("%w: %q", ErrInvalidLocale, tag)
+	}
+	return locales[i], nil
+}
+
+// Format localizes s, a number formatted with "." as decimal point and no
+// grouping, e.g. "1234567.89" is "1.234.567,89" in de-DE.
+func (l Locale) Format(s string) string {
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	ip, frac, hasFrac := strings.Cut(s, ".")
+	var groups []string
+	for i := 0; len(ip) > 0; i++ {
+		n := l.Grouping[min(i, len(l.Grouping)-1)]
+		if n >= len(ip) {
+			groups = append(groups, ip)
+			break
+		}
+		groups = append(groups, ip[len(ip)-n:])
+		ip = ip[:len(ip)-n]
+	}
+	slices.Reverse(groups)
+	out := sign + strings.Join(groups, l.Group)
+	if hasFrac {
+		out += l.Decimal + frac
+	}
+	return out
+}
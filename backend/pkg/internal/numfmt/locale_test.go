@@ -0,0 +1,34 @@
+package numfmt
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestLocaleFormat(t *testing.T) {
+	tests := []struct {
+		tag      string
+		in       string
+		expected string
+	}{
+		{"de-DE", "1234567.89", "1.234.567,89"},
+		{"en-US", "1234567.89", "1,234,567.89"},
+		{"en-IN", "1234567.89", "12,34,567.89"},
+		{"fr-FR", "-1234.5", "-1 234,5"},
+		{"de_ch", "1234", "1’234"},
+		{"de-DE", "123", "123"},
+		{"de-DE", "-0.5", "-0,5"},
+	}
+	for _, tt := range tests {
+		l, err := ParseLocale(tt.tag)
+		if err != nil {
+			t.Fatalf("ParseLocale(%q): %v", tt.tag, err)
+		}
+		if got := l.Format(tt.in); got != tt.expected {
+			t.Errorf("%s Format(%q) = %q, want %q", tt.tag, tt.in, got, tt.expected)
+		}
+	}
+	if _, err := ParseLocale("xx-XX"); !errors.Is(err, ErrInvalidLocale) {
+		t.Errorf("ParseLocale(xx-XX) error = %v", err)
+	}
+}
@@ -0,0 +1,137 @@
+// Package numfmt renders numbers in other bases and notations.
+package numfmt
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/igorgatis/sezzle/backend/pkg/internal/calculator"
+)
+
+// MaxPrecision bounds decimal places and significant figures.
+const MaxPrecision = 100
+
+var (
+	ErrInvalidBase      = &calculator.Error{Code: "invalid_base", Message: "base must be between 2 and 36"}
+	ErrInvalidPrecision = &calculator.Error{Code: "invalid_precision", Message: "precision out of range"}
+)
+
+// Base formats x in base 2 to 36 with lowercase digits, e.g. "-ff.8". The
+// fraction is rounded to as many significant digits as needed to identify
+// x, which is exact in bases that are powers of two. Infinities and NaN
+// fail with calculator.ErrOverflow.
+func Base(x float64, base int) (string, error) {
+	if base < 2 || base > 36 {
+		return "", ErrInvalidBase
+	}
+	if math.IsInf(x, 0) || math.IsNaN(x) {
+		return "", calculator.ErrOverflow
+	}
+	f := new(big.Float).SetPrec(2048).SetFloat64(math.Abs(x))
+	ip, _ := f.Int(nil)
+	frac := f.Sub(f, new(big.Float).SetInt(ip))
+	s := ""
+	if frac.Sign() != 0 {
+		b := new(big.Float).SetInt64(int64(base))
+		// Digits needed to tell float64 values apart, plus one.
+		k := int(math.Ceil(53/math.Log2(float64(base)))) + 1
+		if ip.Sign() != 0 {
+			k -= len(ip.Text(base))
+		} else {
+			one := big.NewFloat(1)
+			for t := new(big.Float).Copy(frac); t.Mul(t, b).Cmp(one) < 0; {
+				k++
+			}
+		}
+		if k > 0 {
+			scale := new(big.Int).Exp(big.NewInt(int64(base)), big.NewInt(int64(k)), nil)
+			frac.Mul(frac, new(big.Float).SetInt(scale))
+			digits, _ := frac.Add(frac, big.NewFloat(0.5)).Int(nil)
+			if digits.Cmp(scale) == 0 {
+				ip.Add(ip, big.NewInt(1))
+				digits.SetInt64(0)
+			}
+			s = strings.TrimRight(fmt.Sprintf("%0*s", k, digits.Text(base)), "0")
+		}
+	}
+	if s != "" {
+		s = "." + s
+	}
+	s = ip.Text(base) + s
+	if x < 0 {
+		s = "-" + s
+	}
+	return s, nil
+}
+
+// Scientific formats x as a mantissa in [1, 10) and an exponent, e.g.
+// "1.2345e6". The mantissa is the shortest that identifies x.
+func Scientific(x float64) string {
+	m, exp := decompose(x)
+	return m + "e" + strconv.Itoa(exp)
+}
+
+// Engineering is like Scientific with an exponent multiple of 3 and a
+// mantissa in [1, 1000), e.g. "1.2345e6" or "12.5e-3".
+func Engineering(x float64) string {
+	m, exp := decompose(x)
+	shift := ((exp % 3) + 3) % 3
+	return movepoint(m, shift) + "e" + strconv.Itoa(exp-shift)
+}
+
+// decompose returns the shortest mantissa of x in [1, 10) and its exponent.
+func decompose(x float64) (string, int) {
+	s := strconv.FormatFloat(x, 'e', -1, 64)
+	m, e, _ := strings.Cut(s, "e")
+	exp, _ := strconv.Atoi(e)
+	return m, exp
+}
+
+// movepoint moves the decimal point of the mantissa m, which has one digit
+// before the point, shift places right.
+func movepoint(m string, shift int) string {
+	sign := ""
+	if strings.HasPrefix(m, "-") {
+		sign, m = "-", m[1:]
+	}
+	digits := strings.Replace(m, ".", "", 1)
+	for len(digits) < shift+1 {
+		digits += "0"
+	}
+	ip, frac := digits[:shift+1], digits[shift+1:]
+	if frac == "" {
+		return sign + ip
+	}
+	return sign + ip + "." + frac
+}
+
+// Fixed formats x with the given number of decimal places.
+func Fixed(x float64, places int) (string, error) {
+	if places < 0 || places > MaxPrecision {
+		return "", ErrInvalidPrecision
+	}
+	return strconv.FormatFloat(x, 'f', places, 64), nil
+}
+
+// Significant formats x rounded to figures significant figures, without
+// exponent, e.g. 1234567.89 to 3 figures is "1230000".
+func Significant(x float64, figures int) (string, error) {
+	if figures < 1 || figures > MaxPrecision {
+		return "", ErrInvalidPrecision
+	}
+	s := strconv.FormatFloat(x, 'e', figures-1, 64)
+	m, e, _ := strings.Cut(s, "e")
+	exp, _ := strconv.Atoi(e)
+	if exp >= 0 {
+		return movepoint(m, exp), nil
+	}
+	sign := ""
+	if strings.HasPrefix(m, "-") {
+		sign, m = "-", m[1:]
+	}
+	digits := strings.Replace(m, ".", "", 1)
+	return sign + "0." + strings.Repeat("0", -exp-1) + digits, nil
+}
@@ -0,0 +1,111 @@
+package numfmt
+
+import (
+	"math"
+	"testing"
+
+	"github.com/igorgatis/sezzle/backend/pkg/internal/calculator"
+)
+
+func TestBase(t *testing.T) {
+	tests := []struct {
+		x        float64
+		base     int
+		expected string
+	}{
+		{255, 16, "ff"},
+		{-255, 16, "-ff"},
+		{10, 2, "1010"},
+		{35, 36, "z"},
+		{0, 8, "0"},
+		{255.5, 16, "ff.8"},
+		{0.1, 2, "0.0001100110011001100110011001100110011001100110011001101"},
+		{1.0 / 3, 3, "0.02222222222222222222222222222222222"}, // Slightly below 1/3.
+		{9007199254740993, 10, "9007199254740992"},
+	}
+	for _, tt := range tests {
+		got, err := Base(tt.x, tt.base)
+		if err != nil || got != tt.expected {
+			t.Errorf("Base(%v, %d) = %q, %v, want %q", tt.x, tt.base, got, err, tt.expected)
+		}
+	}
+	for _, base := range []int{1, 37} {
+		if _, err := Base(1, base); err != ErrInvalidBase {
+			t.Errorf("Base(1, %d) error = %v, want ErrInvalidBase", base, err)
+		}
+	}
+	for _, x := range []float64{math.Inf(1), math.Inf(-1), math.NaN()} {
+		if _, err := Base(x, 16); err != calculator.ErrOverflow {
+			t.Errorf("Base(%v, 16) error = %v, want ErrOverflow", x, err)
+		}
+	}
+}
+
+func TestNotation(t *testing.T) {
+	tests := []struct {
+		x                       float64
+		scientific, engineering string
+	}{
+		{1234567.89, "1.23456789e6", "1.23456789e6"},
+		{12345.6, "1.23456e4", "12.3456e3"},
+		{0.0125, "1.25e-2", "12.5e-3"},
+		{-0.00015, "-1.5e-4", "-150e-6"},
+		{100, "1e2", "100e0"},
+		{0, "0e0", "0e0"},
+	}
+	for _, tt := range tests {
+		if got := Scientific(tt.x); got != tt.scientific {
+			t.Errorf("Scientific(%v) = %q, want %q", tt.x, got, tt.scientific)
+		}
+		if got := Engineering(tt.x); got != tt.engineering {
+			t.Errorf("Engineering(%v) = %q, want %q", tt.x, got, tt.engineering)
+		}
+	}
+}
+
+func TestFixed(t *testing.T) {
+	tests := []struct {
+		x        float64
+		places   int
+		expected string
+	}{
+		{1234.5678, 2, "1234.57"},
+		{1.005, 2, "1.00"}, // 1.005 is slightly below in binary.
+		{-2.5, 0, "-2"},
+		{3, 3, "3.000"},
+	}
+	for _, tt := range tests {
+		got, err := Fixed(tt.x, tt.places)
+		if err != nil || got != tt.expected {
+			t.Errorf("Fixed(%v, %d) = %q, %v, want %q", tt.x, tt.places, got, err, tt.expected)
+		}
+	}
+	if _, err := Fixed(1, -1); err != ErrInvalidPrecision {
+		t.Errorf("Fixed(1, -1) error = %v", err)
+	}
+}
+
+func TestSignificant(t *testing.T) {
+	tests := []struct {
+		x        float64
+		figures  int
+		expected string
+	}{
+		{1234567.89, 3, "1230000"},
+		{9999999, 1, "10000000"},
+		{3.14159, 3, "3.14"},
+		{0.00012345, 2, "0.00012"},
+		{-0.099999, 2, "-0.10"},
+		{42, 5, "42.000"},
+		{0, 3, "0.00"},
+	}
+	for _, tt := range tests {
+		got, err := Significant(tt.x, tt.figures)
+		if err != nil || got != tt.expected {
+			t.Errorf("Significant(%v, %d) = %q, %v, want %q", tt.x, tt.figures, got, err, tt.expected)
+		}
+	}
+	if _, err := Significant(1, 0); err != ErrInvalidPrecision {
+		t.Errorf("Significant(1, 0) error = %v", err)
+	}
+}
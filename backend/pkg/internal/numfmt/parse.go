@@ -0,0 +1,96 @@
+package numfmt
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/igorgatis/sezzle/backend/pkg/internal/calculator"
+)
+
+var (
+	ErrInvalidNumber   = &calculator.Error{Code: "invalid_number", Message: "invalid number"}
+	ErrAmbiguousNumber = &calculator.Error{Code: "ambiguous_number", Message: "ambiguous number"}
+)
+
+// separators are the characters used as decimal or group separators by any
+// locale. Seeing one a locale does not use makes input ambiguous.
+const separators = ".,' \u00a0\u202f’"
+
+// groupSeparators returns the group separator of l and the ones people type
+// in its place, e.g. a plain space for a narrow no-break space.
+func (l Locale) groupSeparators() []string {
+	switch l.Group {
+	case " ", "\u00a0", "\u202f":
+		return []string{" ", "\u00a0", "\u202f"}
+	case "'", "’":
+		return []string{"'", "’"}
+	}
+	return []string{l.Group}
+}
+
+// Parse parses s written with the separators of l, e.g. "1.234,5" in de-DE.
+// Group separators must follow the grouping of l. Input with separators
+// misplaced or not used by l fails with ErrAmbiguousNumber, since it most
+// likely follows the conventions of another locale.
+func (l Locale) Parse(s string) (float64, error) {
+	t := strings.TrimSpace(s)
+	sign := ""
+	if rest, ok := strings.CutPrefix(t, "-"); ok {
+		sign, t = "-", rest
+	} else if rest, ok := strings.CutPrefix(t, "\u2212"); ok {
+		sign, t = "-", rest
+	} else {
+		t = strings.TrimPrefix(t, "+")
+	}
+	ip, frac, hasFrac := strings.Cut(t, l.Decimal)
+	if hasFrac && (frac == "" || !isDigits(frac)) {
+		return 0, l.invalid(s, frac)
+	}
+	if ip == "" && hasFrac {
+		ip = "0"
+	}
+	for _, sep := range l.groupSeparators() {
+		ip = strings.ReplaceAll(ip, sep, l.Group)
+	}
+	groups := strings.Split(ip, l.Group)
+	for i, g := range groups {
+		if !isDigits(g) {
+			return 0, l.invalid(s, g)
+		}
+		size := l.Grouping[min(len(groups)-1-i, len(l.Grouping)-1)]
+		if len(groups) > 1 && (len(g) > size || (i > 0 && len(g) != size)) {
+			return 0, fmt.Errorf("%w: %q: %s groups digits by %d", ErrAmbiguousNumber, s, l.Tag, l.Grouping[0])
+		}
+	}
+	num := sign + strings.Join(groups, "")
+	if hasFrac {
+		num += "." + frac
+	}
+	v, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidNumber, s)
+	}
+	return v, nil
+}
+
+// invalid returns the error for s, whose part is not made of digits.
+func (l Locale) invalid(s, part string) error {
+	if strings.ContainsAny(part, separators) {
+		return fmt.Errorf("%w: %q: %s uses %q as decimal separator",
+			ErrAmbiguousNumber, s, l.Tag, l.Decimal)
+	}
+	return fmt.Errorf("%w: %q", ErrInvalidNumber, s)
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,69 @@
+package numfmt
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestLocaleParse(t *testing.T) {
+	tests := []struct {
+		tag       string
+		in        string
+		expected  float64
+		expectErr error
+	}{
+		{"de-DE", "1.234,5", 1234.5, nil},
+		{"de-DE", "1234,5", 1234.5, nil},
+		{"de-DE", "-1.234.567", -1234567, nil},
+		{"de-DE", ",5", 0.5, nil},
+		{"de-DE", "1,234", 1.234, nil},
+		{"de-DE", "1.5", 0, ErrAmbiguousNumber},
+		{"de-DE", "1.234.5", 0, ErrAmbiguousNumber},
+		{"de-DE", "1,234.5", 0, ErrAmbiguousNumber},
+		{"de-DE", "1,2,3", 0, ErrAmbiguousNumber},
+		{"de-DE", "1.2345", 0, ErrAmbiguousNumber},
+		{"en-US", "1,234.5", 1234.5, nil},
+		{"en-US", "1,23", 0, ErrAmbiguousNumber},
+		{"en-US", "1234,5", 0, ErrAmbiguousNumber},
+		{"en-IN", "12,34,567.89", 1234567.89, nil},
+		{"en-IN", "1,234,567", 0, ErrAmbiguousNumber},
+		{"fr-FR", "1 234,5", 1234.5, nil},
+		{"fr-FR", "1\u202f234,5", 1234.5, nil},
+		{"ru-RU", "1\u00a0234,5", 1234.5, nil},
+		{"fr-FR", "\u22122,5", -2.5, nil},
+		{"fr-FR", "1.5", 0, ErrAmbiguousNumber},
+		{"de-CH", "1'234.5", 1234.5, nil},
+		{"de-CH", "1’234.5", 1234.5, nil},
+		{"en-US", "+7", 7, nil},
+		{"en-US", "", 0, ErrInvalidNumber},
+		{"en-US", "1e3", 0, ErrInvalidNumber},
+		{"en-US", "12a", 0, ErrInvalidNumber},
+		{"en-US", "1.", 0, ErrInvalidNumber},
+		{"en-US", "--1", 0, ErrInvalidNumber},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tag+" "+tt.in, func(t *testing.T) {
+			l, err := ParseLocale(tt.tag)
+			if err != nil {
+				t.Fatal(err)
+			}
+			v, err := l.Parse(tt.in)
+			if !errors.Is(err, tt.expectErr) {
+				t.Fatalf("Parse(%q) error = %v, want %v", tt.in, err, tt.expectErr)
+			}
+			if v != tt.expected {
+				t.Errorf("Parse(%q) = %v, want %v", tt.in, v, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLocaleRoundTrip(t *testing.T) {
+	for _, tag := range Locales() {
+		l, _ := ParseLocale(tag)
+		s := l.Format("-1234567.25")
+		if v, err := l.Parse(s); err != nil || v != -1234567.25 {
+			t.Errorf("%s: Parse(%q) = %v, %v", tag, s, v, err)
+		}
+	}
+}
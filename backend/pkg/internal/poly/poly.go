@@ -0,0 +1,148 @@
+// Package poly implements polynomials with real coefficients.
+package poly
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/igorgatis/sezzle/backend/pkg/internal/calculator"
+)
+
+// MaxDegree bounds the degree of polynomials given to New.
+const MaxDegree = 1000
+
+var (
+	ErrInvalidPolynomial = &calculator.Error{Code: "invalid_polynomial", Message: "invalid polynomial"}
+	ErrZeroPolynomial    = &calculator.Error{Code: "zero_polynomial", Message: "polynomial is zero"}
+)
+
+// Poly is a polynomial by its coefficients from the constant term up, so
+// p[i] multiplies x^i. Operations return new polynomials without trailing
+// zero coefficients, the zero polynomial having none.
+type Poly []float64
+
+// New returns the polynomial with coefficients c, which must be non-empty,
+// finite and at most MaxDegree+1.
+func New(c []float64) (Poly, error) {
+	if len(c) == 0 || len(c) > MaxDegree+1 {
+		return nil, fmt.Errorf("%w: %d coefficients, want 1 to %d", ErrInvalidPolynomial, len(c), MaxDegree+1)
+	}
+	for i, v := range c {
+		if math.IsInf(v, 0) || math.IsNaN(v) {
+			return nil, fmt.Errorf("%w: coefficient [%d] is not finite", ErrInvalidPolynomial, i)
+		}
+	}
+	return Poly(c).trim(), nil
+}
+
+// Degree returns the degree of p, -1 for the zero polynomial.
+func (p Poly) Degree() int {
+	return len(p.trim()) - 1
+}
+
+func (p Poly) trim() Poly {
+	n := len(p)
+	for n > 0 && p[n-1] == 0 {
+		n--
+	}
+	return append(Poly{}, p[:n]...)
+}
+
+// checked fails with calculator.ErrOverflow when a coefficient of p is not
+// finite.
+func (p Poly) checked() (Poly, error) {
+	for i, v := range p {
+		if math.IsInf(v, 0) || math.IsNaN(v) {
+			return nil, fmt.Errorf("%w: coefficient [%d]", calculator.ErrOverflow, i)
+		}
+	}
+	return p.trim(), nil
+}
+
+// Eval evaluates p at x by Horner's rule.
+func (p Poly) Eval(x float64) (float64, error) {
+	y := 0.0
+	for i := len(p) - 1; i >= 0; i-- {
+		y = y*x + p[i]
+	}
+	if math.IsInf(y, 0) || math.IsNaN(y) {
+		return 0, fmt.Errorf("%w: p(%g)", calculator.ErrOverflow, x)
+	}
+	return y, nil
+}
+
+func (p Poly) Add(q Poly) (Poly, error) {
+	r := make(Poly, max(len(p), len(q)))
+	copy(r, p)
+	for i, v := range q {
+		r[i] += v
+	}
+	return r.checked()
+}
+
+func (p Poly) Sub(q Poly) (Poly, error) {
+	r := make(Poly, max(len(p), len(q)))
+	copy(r, p)
+	for i, v := range q {
+		r[i] -= v
+	}
+	return r.checked()
+}
+
+func (p Poly) Mul(q Poly) (Poly, error) {
+	p, q = p.trim(), q.trim()
+	if len(p) == 0 || len(q) == 0 {
+		return Poly{}, nil
+	}
+	r := make(Poly, len(p)+len(q)-1)
+	for i, a := range p {
+		for j, b := range q {
+			r[i+j] += a * b
+		}
+	}
+	return r.checked()
+}
+
+// Div divides p by q by long division, returning the quotient and a
+// remainder of lower degree than q.
+func (p Poly) Div(q Poly) (quo, rem Poly, err error) {
+	q = q.trim()
+	if len(q) == 0 {
+		return nil, nil, fmt.Errorf("%w: polynomial division", calculator.ErrDivisionByZero)
+	}
+	rem = p.trim()
+	if len(rem) < len(q) {
+		return Poly{}, rem, nil
+	}
+	quo = make(Poly, len(rem)-len(q)+1)
+	lead := q[len(q)-1]
+	for i := len(quo) - 1; i >= 0; i-- {
+		c := rem[i+len(q)-1] / lead
+		quo[i] = c
+		// The leading term cancels exactly, whatever the rounding of the
+		// others.
+		rem[i+len(q)-1] = 0
+		for j := range len(q) - 1 {
+			rem[i+j] -= c * q[j]
+		}
+	}
+	if quo, err = quo.checked(); err != nil {
+		return nil, nil, err
+	}
+	if rem, err = rem[:len(q)-1].checked(); err != nil {
+		return nil, nil, err
+	}
+	return quo, rem, nil
+}
+
+func (p Poly) Derivative() (Poly, error) {
+	p = p.trim()
+	if len(p) <= 1 {
+		return Poly{}, nil
+	}
+	d := make(Poly, len(p)-1)
+	for i := range d {
+		d[i] = float64(i+1) * p[i+1]
+	}
+	return d.checked()
+}
@@ -0,0 +1,133 @@
+package poly
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/igorgatis/sezzle/backend/pkg/internal/calculator"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name      string
+		c         []float64
+		expected  Poly
+		expectErr error
+	}{
+		{"trims", []float64{1, 2, 0, 0}, Poly{1, 2}, nil},
+		{"zero", []float64{0, 0}, Poly{}, nil},
+		{"empty", nil, nil, ErrInvalidPolynomial},
+		{"too long", make([]float64, MaxDegree+2), nil, ErrInvalidPolynomial},
+		{"not finite", []float64{1, math.Inf(1)}, nil, ErrInvalidPolynomial},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := New(tt.c)
+			if !errors.Is(err, tt.expectErr) {
+				t.Fatalf("error = %v, want %v", err, tt.expectErr)
+			}
+			if err == nil && !slices.Equal(p, tt.expected) {
+				t.Errorf("New = %v, want %v", p, tt.expected)
+			}
+		})
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	p := Poly{-1, 0, 1} // x^2 - 1
+	q := Poly{1, 1}     // x + 1
+	tests := []struct {
+		name     string
+		op       func(p, q Poly) (Poly, error)
+		p, q     Poly
+		expected Poly
+	}{
+		{"add", Poly.Add, p, q, Poly{0, 1, 1}},
+		{"add cancels", Poly.Add, p, Poly{0, 0, -1}, Poly{-1}},
+		{"sub", Poly.Sub, p, q, Poly{-2, -1, 1}},
+		{"sub self", Poly.Sub, p, p, Poly{}},
+		{"mul", Poly.Mul, p, q, Poly{-1, -1, 1, 1}},
+		{"mul zero", Poly.Mul, p, Poly{0}, Poly{}},
+		{"mul constant", Poly.Mul, p, Poly{2}, Poly{-2, 0, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := tt.op(tt.p, tt.q)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(r, tt.expected) {
+				t.Errorf("got %v, want %v", r, tt.expected)
+			}
+		})
+	}
+	if _, err := (Poly{1e308}).Add(Poly{1e308}); !errors.Is(err, calculator.ErrOverflow) {
+		t.Errorf("overflowing Add error = %v, want %v", err, calculator.ErrOverflow)
+	}
+	if _, err := (Poly{0, 1e200}).Mul(Poly{0, 1e200}); !errors.Is(err, calculator.ErrOverflow) {
+		t.Errorf("overflowing Mul error = %v, want %v", err, calculator.ErrOverflow)
+	}
+}
+
+func TestDiv(t *testing.T) {
+	tests := []struct {
+		name     string
+		p, q     Poly
+		quo, rem Poly
+	}{
+		{"exact", Poly{-1, 0, 1}, Poly{1, 1}, Poly{-1, 1}, Poly{}},
+		{"remainder", Poly{5, -3, 0, 2}, Poly{-1, 1}, Poly{-1, 2, 2}, Poly{4}},
+		{"by constant", Poly{2, 4}, Poly{2}, Poly{1, 2}, Poly{}},
+		{"lower degree", Poly{1, 1}, Poly{0, 0, 1}, Poly{}, Poly{1, 1}},
+		{"non-monic", Poly{1, 2, 6}, Poly{1, 2}, Poly{-0.5, 3}, Poly{1.5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quo, rem, err := tt.p.Div(tt.q)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(quo, tt.quo) || !slices.Equal(rem, tt.rem) {
+				t.Errorf("got %v rem %v, want %v rem %v", quo, rem, tt.quo, tt.rem)
+			}
+		})
+	}
+	if _, _, err := (Poly{1, 1}).Div(Poly{0}); !errors.Is(err, calculator.ErrDivisionByZero) {
+		t.Errorf("division by zero error = %v, want %v", err, calculator.ErrDivisionByZero)
+	}
+}
+
+func TestEvalAndDerivative(t *testing.T) {
+	p := Poly{5, -3, 0, 2} // 2x^3 - 3x + 5
+	for _, tt := range []struct{ x, expected float64 }{{0, 5}, {1, 4}, {-2, -5}, {0.5, 3.75}} {
+		if got, err := p.Eval(tt.x); err != nil || got != tt.expected {
+			t.Errorf("p(%g) = %v, %v, want %v", tt.x, got, err, tt.expected)
+		}
+	}
+	if _, err := p.Eval(1e200); !errors.Is(err, calculator.ErrOverflow) {
+		t.Errorf("p(1e200) error = %v, want %v", err, calculator.ErrOverflow)
+	}
+	if got, _ := (Poly{}).Eval(3); got != 0 {
+		t.Errorf("zero polynomial at 3 = %v", got)
+	}
+	d, err := p.Derivative()
+	if err != nil || !slices.Equal(d, Poly{-3, 0, 6}) {
+		t.Errorf("Derivative = %v, %v, want [-3 0 6]", d, err)
+	}
+	if d, _ := (Poly{7}).Derivative(); len(d) != 0 {
+		t.Errorf("derivative of a constant = %v, want zero", d)
+	}
+}
+
+func TestDegree(t *testing.T) {
+	for _, tt := range []struct {
+		p        Poly
+		expected int
+	}{{Poly{}, -1}, {Poly{0}, -1}, {Poly{3}, 0}, {Poly{1, 2, 0}, 1}} {
+		if got := tt.p.Degree(); got != tt.expected {
+			t.Errorf("%v.Degree() = %d, want %d", tt.p, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package poly
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"math"
+	"math/cmplx"
+	"slices"
+
+	"github.com/igorgatis/sezzle/backend/pkg/internal/calculator"
+)
+
+const (
+	DefaultMaxIterations = 500
+	// MaxIterations bounds the iteration limit callers may ask for.
+	MaxIterations = 10_000
+)
+
+// eps is the spacing of float64 values at 1.
+const eps = 0x1p-52
+
+var (
+	ErrInvalidLimit = &calculator.Error{Code: "invalid_iteration_limit", Message: "invalid iteration limit"}
+	// ErrTimeout shares its code with numeric.ErrTimeout.
+	ErrTimeout = &calculator.Error{Code: "timeout", Message: "computation timed out"}
+)
+
+// Roots are all the roots of a polynomial, repeated by multiplicity and
+// sorted by real and then imaginary part.
+type Roots struct {
+	Values     []complex128
+	Iterations int
+}
+
+// Roots finds all the roots of p by the Durand-Kerner method, after
+// removing those at 0, within maxIterations (DefaultMaxIterations when 0).
+// A root is taken as found once p there is within the rounding error of
+// evaluating it, so roots of multiplicity m are accurate to about the m-th
+// root of the machine epsilon. Roots are real, or imaginary, when p is as
+// small at their real, or imaginary, part. It fails with ErrZeroPolynomial
+// for the zero polynomial, with calculator.ErrNoConvergence when the
+// iteration limit is reached and with ErrTimeout when ctx is done, as each
+// iteration costs O(n²) for degree n.
+func (p Poly) Roots(ctx context.Context, maxIterations int) (Roots, error) {
+	if maxIterations == 0 {
+		maxIterations = DefaultMaxIterations
+	}
+	if maxIterations < 0 || maxIterations > MaxIterations {
+		return Roots{}, fmt.Errorf("%w: %d, want 1 to %d", ErrInvalidLimit, maxIterations, MaxIterations)
+	}
+	p = p.trim()
+	if len(p) == 0 {
+		return Roots{}, ErrZeroPolynomial
+	}
+	zeros := 0
+	for p[zeros] == 0 {
+		zeros++
+	}
+	m := len(p) - 1 - zeros
+	// a is the monic polynomial of the non-zero roots.
+	a := make(Poly, m+1)
+	for i := range a {
+		a[i] = p[zeros+i] / p[len(p)-1]
+	}
+	if _, err := a.checked(); err != nil {
+		return Roots{}, err
+	}
+	r := Roots{Values: make([]complex128, zeros, zeros+m)}
+	if m == 0 {
+		return r, nil
+	}
+	z, iterations, err := durandKerner(ctx, a, maxIterations)
+	if err != nil {
+		return Roots{}, err
+	}
+	for _, zi := range z {
+		if x := real(zi); imag(zi) != 0 && a.negligibleAt(complex(x, 0)) {
+			zi = complex(x, 0)
+		}
+		if y := imag(zi); real(zi) != 0 && a.negligibleAt(complex(0, y)) {
+			zi = complex(0, y)
+		}
+		r.Values = append(r.Values, zi)
+	}
+	conjugatePairs(r.Values[zeros:])
+	slices.SortFunc(r.Values, func(x, y complex128) int {
+		return cmp.Or(cmp.Compare(real(x), real(y)), cmp.Compare(imag(x), imag(y)))
+	})
+	r.Iterations = iterations
+	return r, nil
+}
+
+// durandKerner finds the roots of the monic polynomial a, updating each
+// approximation in turn by Weierstrass' correction a(z_i)/Π(z_i - z_j).
+// The approximations start on the circle whose radius is the geometric
+// mean of the moduli of the roots, at an angular offset that keeps them off
+// the real axis, where they would stay for real coefficients.
+func durandKerner(ctx context.Context, a Poly, maxIterations int) ([]complex128, int, error) {
+	m := len(a) - 1
+	radius := math.Pow(math.Abs(a[0]), 1/float64(m))
+	z := make([]complex128, m)
+	for i := range z {
+		z[i] = cmplx.Rect(radius, 2*math.Pi*float64(i)/float64(m)+0.4)
+	}
+	for iteration := 1; iteration <= maxIterations; iteration++ {
+		if err := ctx.Err(); err != nil {
+			return nil, 0, fmt.Errorf("%w: after %d iterations", ErrTimeout, iteration-1)
+		}
+		done := true
+		for i, zi := range z {
+			v, bound, outside := a.horner(zi)
+			if cmplx.Abs(v) <= bound {
+				continue
+			}
+			done = false
+			// Outside the unit circle v lacks the factor z_i^m, so the
+			// m-1 differences are divided by z_i and v is multiplied by
+			// it. The product is kept as d*2^e so that it cannot overflow
+			// for high degrees.
+			d, e := complex(1, 0), 0
+			for j, zj := range z {
+				if j == i {
+					continue
+				}
+				if outside {
+					d *= 1 - zj/zi
+				} else {
+					d *= zi - zj
+				}
+				if s := math.Abs(real(d)) + math.Abs(imag(d)); s > 0x1p500 || s < 0x1p-500 {
+					_, k := math.Frexp(s)
+					d = complex(math.Ldexp(real(d), -k), math.Ldexp(imag(d), -k))
+					e += k
+				}
+			}
+			if d == 0 || cmplx.IsNaN(d) {
+				// Coinciding approximations are moved apart.
+				z[i] += complex(radius*1e-8, radius*1e-8)
+				continue
+			}
+			if outside {
+				v *= zi
+			}
+			w := v / d
+			z[i] -= complex(math.Ldexp(real(w), -e), math.Ldexp(imag(w), -e))
+			if cmplx.IsInf(z[i]) || cmplx.IsNaN(z[i]) {
+				return nil, 0, fmt.Errorf("%w: Durand-Kerner diverged", calculator.ErrNoConvergence)
+			}
+		}
+		if done {
+			return z, iteration, nil
+		}
+	}
+	return nil, 0, fmt.Errorf("%w: %d iterations of Durand-Kerner", calculator.ErrNoConvergence, maxIterations)
+}
+
+// horner evaluates p at z by Horner's rule, returning the value and a bound
+// on its rounding error. Outside the unit circle, where powers of z could
+// overflow, it evaluates the reversed polynomial at 1/z instead, which is
+// p(z)/z^m for p of degree m, and reports so.
+func (p Poly) horner(z complex128) (v complex128, bound float64, outside bool) {
+	outside = cmplx.Abs(z) > 1
+	if outside {
+		z = 1 / z
+	}
+	r := cmplx.Abs(z)
+	for i := range p {
+		c := p[len(p)-1-i]
+		if outside {
+			c = p[i]
+		}
+		v = v*z + complex(c, 0)
+		bound = bound*r + math.Abs(c)
+	}
+	return v, 4 * float64(len(p)) * eps * bound, outside
+}
+
+// negligibleAt reports whether p(z) is within the rounding error of
+// evaluating it.
+func (p Poly) negligibleAt(z complex128) bool {
+	v, bound, _ := p.horner(z)
+	return cmplx.Abs(v) <= bound
+}
+
+// conjugatePairs makes the non-real roots of a polynomial with real
+// coefficients exact conjugates, pairing each in the upper half-plane with
+// the nearest conjugate of one in the lower half-plane.
+func conjugatePairs(z []complex128) {
+	used := make([]bool, len(z))
+	for i, zi := range z {
+		if imag(zi) <= 0 {
+			continue
+		}
+		k := -1
+		for j, zj := range z {
+			if !used[j] && imag(zj) < 0 && (k < 0 || cmplx.Abs(zj-cmplx.Conj(zi)) < cmplx.Abs(z[k]-cmplx.Conj(zi))) {
+				k = j
+			}
+		}
+		if k < 0 {
+			continue
+		}
+		used[k] = true
+		mean := (zi + cmplx.Conj(z[k])) / 2
+		z[i], z[k] = mean, cmplx.Conj(mean)
+	}
+}
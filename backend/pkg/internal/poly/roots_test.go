@@ -0,0 +1,130 @@
+package poly
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/cmplx"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/igorgatis/sezzle/backend/pkg/internal/calculator"
+)
+
+func TestRoots(t *testing.T) {
+	wilkinson := Poly{1}
+	for k := 1; k <= 10; k++ {
+		wilkinson, _ = wilkinson.Mul(Poly{-float64(k), 1})
+	}
+	tests := []struct {
+		name     string
+		p        Poly
+		expected []complex128
+		tol      float64
+	}{
+		{"constant", Poly{7}, []complex128{}, 0},
+		{"linear", Poly{3, 2}, []complex128{-1.5}, 1e-15},
+		{"quadratic", Poly{2, -3, 1}, []complex128{1, 2}, 1e-14},
+		{"imaginary", Poly{1, 0, 1}, []complex128{-1i, 1i}, 1e-15},
+		{"cube roots of unity", Poly{-1, 0, 0, 1},
+			[]complex128{complex(-0.5, -math.Sqrt(3)/2), complex(-0.5, math.Sqrt(3)/2), 1}, 1e-15},
+		{"zero roots", Poly{0, 0, -4, 1}, []complex128{0, 0, 4}, 1e-15},
+		{"double root", Poly{1, -2, 1}, []complex128{1, 1}, 1e-7},
+		{"triple root", Poly{-1, 3, -3, 1}, []complex128{1, 1, 1}, 1e-4},
+		{"complex pair", Poly{5, -2, 1}, []complex128{complex(1, -2), complex(1, 2)}, 1e-14},
+		{"wilkinson", wilkinson, []complex128{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, 1e-8},
+		{"small leading coefficient", Poly{-1, 0, 1e-10}, []complex128{-1e5, 1e5}, 1e-9},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := tt.p.Roots(context.Background(), 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(r.Values) != len(tt.expected) {
+				t.Fatalf("roots = %v, want %v", r.Values, tt.expected)
+			}
+			for i, z := range r.Values {
+				if cmplx.Abs(z-tt.expected[i]) > tt.tol*max(1, cmplx.Abs(tt.expected[i])) {
+					t.Errorf("roots = %v, want %v", r.Values, tt.expected)
+					break
+				}
+			}
+		})
+	}
+}
+
+// TestRootsHighDegree checks the residuals of random polynomials up to
+// MaxDegree, which overflow unless evaluated with care.
+func TestRootsHighDegree(t *testing.T) {
+	rnd := rand.New(rand.NewPCG(1, 2))
+	for _, n := range []int{20, 100, MaxDegree} {
+		p := make(Poly, n+1)
+		for i := range p {
+			p[i] = rnd.NormFloat64()
+		}
+		r, err := p.Roots(context.Background(), 0)
+		if err != nil {
+			t.Fatalf("degree %d: %v", n, err)
+		}
+		if len(r.Values) != n {
+			t.Fatalf("degree %d: %d roots", n, len(r.Values))
+		}
+		conjugates := 0
+		for _, z := range r.Values {
+			v, bound, _ := p.horner(z)
+			if cmplx.Abs(v) > 4*bound {
+				t.Errorf("degree %d: residual %g at %v exceeds %g", n, cmplx.Abs(v), z, bound)
+			}
+			if imag(z) > 0 {
+				conjugates++
+			}
+		}
+		if 2*conjugates > n {
+			t.Errorf("degree %d: %d roots in the upper half-plane", n, conjugates)
+		}
+	}
+}
+
+func TestRootsErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		p             Poly
+		maxIterations int
+		expectErr     error
+	}{
+		{"zero", Poly{0, 0}, 0, ErrZeroPolynomial},
+		{"negative limit", Poly{1, 1}, -1, ErrInvalidLimit},
+		{"limit too large", Poly{1, 1}, MaxIterations + 1, ErrInvalidLimit},
+		{"iteration limit", Poly{1, 2, 3, 4, 5, 6}, 2, calculator.ErrNoConvergence},
+		{"overflow", Poly{1e300, 0, 1e-300}, 0, calculator.ErrOverflow},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.p.Roots(context.Background(), tt.maxIterations); !errors.Is(err, tt.expectErr) {
+				t.Errorf("Roots error = %v, want %v", err, tt.expectErr)
+			}
+		})
+	}
+}
+
+func TestRootsTimeout(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := (Poly{1, 2, 3}).Roots(ctx, 0); !errors.Is(err, ErrTimeout) {
+		t.Errorf("Roots error = %v, want %v", err, ErrTimeout)
+	}
+}
+
+func BenchmarkRoots(b *testing.B) {
+	rnd := rand.New(rand.NewPCG(1, 2))
+	p := make(Poly, 101)
+	for i := range p {
+		p[i] = rnd.NormFloat64()
+	}
+	for b.Loop() {
+		if _, err := p.Roots(context.Background(), 0); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+// Package random draws random numbers and samples, either reproducibly from
+// a seed or from a cryptographically secure source.
+package random
+
+import (
+	crand "crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+
+	"github.com/igorgatis/sezzle/backend/pkg/internal/calculator"
+)
+
+// MaxCount bounds the number of values drawn and items given in one call.
+const MaxCount = 10_000
+
+var (
+	ErrInvalidCount        = &calculator.Error{Code: "invalid_count", Message: "invalid count"}
+	ErrInvalidRange        = &calculator.Error{Code: "invalid_range", Message: "min must not exceed max"}
+	ErrUnknownDistribution = &calculator.Error{Code: "unknown_distribution", Message: "unknown distribution"}
+)
+
+// Source tells where a Generator draws from.
+type Source string
+
+const (
+	// Seeded generators use ChaCha8 keyed with the seed, so the same seed
+	// yields the same values on every run and platform.
+	Seeded Source = "seeded"
+	// Crypto generators read crypto/rand.
+	Crypto Source = "crypto"
+)
+
+type Generator struct {
+	Source Source
+	r      *rand.Rand
+}
+
+// New returns a generator seeded with seed, or a cryptographically secure
+// one when seed is nil.
+func New(seed *uint64) *Generator {
+	if seed == nil {
+		return &Generator{Source: Crypto, r: rand.New(cryptoSource{})}
+	}
+	var key [32]byte
+	binary.LittleEndian.PutUint64(key[:], *seed)
+	return &Generator{Source: Seeded, r: rand.New(rand.NewChaCha8(key))}
+}
+
+type cryptoSource struct{}
+
+func (cryptoSource) Uint64() uint64 {
+	var b [8]byte
+	crand.Read(b[:])
+	return binary.LittleEndian.Uint64(b[:])
+}
+
+// Distribution is a distribution to draw numbers from by inverting its CDF.
+type Distribution struct {
+	Name   string
+	Params []string
+	inv    func(p float64, params []float64) (float64, error)
+}
+
+var distributions = []Distribution{
+	{"normal", []string{"mu", "sigma"}, func(p float64, x []float64) (float64, error) {
+		return calculator.NormalInv(p, x[0], x[1])
+	}},
+	{"binomial", []string{"n", "prob"}, func(p float64, x []float64) (float64, error) {
+		return calculator.BinomialInv(p, x[0], x[1])
+	}},
+	{"poisson", []string{"lambda"}, func(p float64, x []float64) (float64, error) {
+		return calculator.PoissonInv(p, x[0])
+	}},
+	{"exponential", []string{"lambda"}, func(p float64, x []float64) (float64, error) {
+		return calculator.ExponentialInv(p, x[0])
+	}},
+	{"uniform", []string{"a", "b"}, func(p float64, x []float64) (float64, error) {
+		return calculator.UniformInv(p, x[0], x[1])
+	}},
+	{"t", []string{"df"}, func(p float64, x []float64) (float64, error) {
+		return calculator.StudentTInv(p, x[0])
+	}},
+}
+
+func Distributions() []Distribution {
+	return distributions
+}
+
+func LookupDistribution(name string) (Distribution, error) {
+	i := slices.IndexFunc(distributions, func(d Distribution) bool { return d.Name == name })
+	if i < 0 {
+		return Distribution{}, fmt.Errorf("%w: %q", ErrUnknownDistribution, name)
+	}
+	return distributions[i], nil
+}
+
+// Numbers draws count numbers from d with the named params. It fails with
+// calculator.ErrOverflow when parameters are so large that draws are not
+// finite.
+func (g *Generator) Numbers(d Distribution, params map[string]float64, count int) ([]float64, error) {
+	if err := checkCount(count); err != nil {
+		return nil, err
+	}
+	args := make([]float64, len(d.Params))
+	for i, name := range d.Params {
+		v, ok := params[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: missing %s", calculator.ErrInvalidDistribution, name)
+		}
+		args[i] = v
+	}
+	for name := range params {
+		if !slices.Contains(d.Params, name) {
+			return nil, fmt.Errorf("%w: %s has no parameter %s", calculator.ErrInvalidDistribution, d.Name, name)
+		}
+	}
+	values := make([]float64, count)
+	for i := range values {
+		// A uniform value strictly between 0 and 1, which every inverse
+		// CDF accepts.
+		u := (float64(g.r.Uint64()>>11) + 0.5) / (1 << 53)
+		v, err := d.inv(u, args)
+		if err != nil {
+			return nil, err
+		}
+		if math.IsInf(v, 0) || math.IsNaN(v) {
+			return nil, fmt.Errorf("%w: drawing from %s", calculator.ErrOverflow, d.Name)
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+// Integers draws count integers between min and max inclusive.
+func (g *Generator) Integers(min, max int64, count int) ([]int64, error) {
+	if err := checkCount(count); err != nil {
+		return nil, err
+	}
+	if min > max {
+		return nil, fmt.Errorf("%w: min %d, max %d", ErrInvalidRange, min, max)
+	}
+	span := uint64(max - min)
+	values := make([]int64, count)
+	for i := range values {
+		if span == math.MaxUint64 {
+			values[i] = int64(g.r.Uint64())
+		} else {
+			values[i] = min + int64(g.r.Uint64N(span+1))
+		}
+	}
+	return values, nil
+}
+
+// Shuffle returns a random permutation of items.
+func Shuffle[T any](g *Generator, items []T) ([]T, error) {
+	if len(items) > MaxCount {
+		return nil, fmt.Errorf("%w: %d items, at most %d", ErrInvalidCount, len(items), MaxCount)
+	}
+	out := slices.Clone(items)
+	g.r.Shuffle(len(out), func(i, j int) { out[i], out[j] = out[j], out[i] })
+	return out, nil
+}
+
+// Sample draws count of items, each of which may be drawn again when
+// replace is set and at most once otherwise.
+func Sample[T any](g *Generator, items []T, count int, replace bool) ([]T, error) {
+	if len(items) == 0 || len(items) > MaxCount {
+		return nil, fmt.Errorf("%w: %d items, want 1 to %d", ErrInvalidCount, len(items), MaxCount)
+	}
+	if err := checkCount(count); err != nil {
+		return nil, err
+	}
+	if replace {
+		out := make([]T, count)
+		for i := range out {
+			out[i] = items[g.r.Uint64N(uint64(len(items)))]
+		}
+		return out, nil
+	}
+	if count > len(items) {
+		return nil, fmt.Errorf("%w: %d of %d items without replacement", ErrInvalidCount, count, len(items))
+	}
+	// A partial Fisher-Yates shuffle.
+	out := slices.Clone(items)
+	for i := range count {
+		j := i + int(g.r.Uint64N(uint64(len(out)-i)))
+		out[i], out[j] = out[j], out[i]
+	}
+	return out[:count], nil
+}
+
+func checkCount(count int) error {
+	if count < 1 || count > MaxCount {
+		return fmt.Errorf("%w: %d, want 1 to %d", ErrInvalidCount, count, MaxCount)
+	}
+	return nil
+}
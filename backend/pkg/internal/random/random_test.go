@@ -0,0 +1,164 @@
+package random
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/igorgatis/sezzle/backend/pkg/internal/calculator"
+)
+
+func seed(s uint64) *uint64 {
+	return &s
+}
+
+func TestSeeded(t *testing.T) {
+	normal, _ := LookupDistribution("normal")
+	params := map[string]float64{"mu": 0, "sigma": 1}
+	a, _ := New(seed(42)).Numbers(normal, params, 5)
+	b, _ := New(seed(42)).Numbers(normal, params, 5)
+	c, _ := New(seed(43)).Numbers(normal, params, 5)
+	if !slices.Equal(a, b) {
+		t.Errorf("same seed gave %v and %v", a, b)
+	}
+	if slices.Equal(a, c) {
+		t.Errorf("different seeds gave %v", a)
+	}
+	if g := New(seed(42)); g.Source != Seeded {
+		t.Errorf("source = %q, want %q", g.Source, Seeded)
+	}
+}
+
+func TestCrypto(t *testing.T) {
+	g := New(nil)
+	if g.Source != Crypto {
+		t.Errorf("source = %q, want %q", g.Source, Crypto)
+	}
+	a, _ := g.Integers(math.MinInt64, math.MaxInt64, 4)
+	b, _ := New(nil).Integers(math.MinInt64, math.MaxInt64, 4)
+	if slices.Equal(a, b) {
+		t.Errorf("crypto generators both gave %v", a)
+	}
+}
+
+func TestNumbers(t *testing.T) {
+	tests := []struct {
+		dist     string
+		params   map[string]float64
+		mean, sd float64
+	}{
+		{"normal", map[string]float64{"mu": 10, "sigma": 2}, 10, 2},
+		{"binomial", map[string]float64{"n": 20, "prob": 0.3}, 6, math.Sqrt(20 * 0.3 * 0.7)},
+		{"poisson", map[string]float64{"lambda": 4}, 4, 2},
+		{"exponential", map[string]float64{"lambda": 0.5}, 2, 2},
+		{"uniform", map[string]float64{"a": -1, "b": 3}, 1, 4 / math.Sqrt(12)},
+		{"t", map[string]float64{"df": 5}, 0, math.Sqrt(5.0 / 3)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.dist, func(t *testing.T) {
+			d, err := LookupDistribution(tt.dist)
+			if err != nil {
+				t.Fatal(err)
+			}
+			xs, err := New(seed(1)).Numbers(d, tt.params, MaxCount)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sum := 0.0
+			for _, x := range xs {
+				sum += x
+			}
+			// Five standard errors of the mean.
+			if mean := sum / MaxCount; math.Abs(mean-tt.mean) > 5*tt.sd/math.Sqrt(MaxCount) {
+				t.Errorf("mean = %v, want %v", mean, tt.mean)
+			}
+		})
+	}
+}
+
+func TestNumbersErrors(t *testing.T) {
+	normal, _ := LookupDistribution("normal")
+	tests := []struct {
+		name      string
+		params    map[string]float64
+		count     int
+		expectErr error
+	}{
+		{"missing parameter", map[string]float64{"mu": 0}, 1, calculator.ErrInvalidDistribution},
+		{"unknown parameter", map[string]float64{"mu": 0, "sigma": 1, "df": 1}, 1, calculator.ErrInvalidDistribution},
+		{"invalid parameter", map[string]float64{"mu": 0, "sigma": 0}, 1, calculator.ErrInvalidDistribution},
+		{"zero count", map[string]float64{"mu": 0, "sigma": 1}, 0, ErrInvalidCount},
+		{"too many", map[string]float64{"mu": 0, "sigma": 1}, MaxCount + 1, ErrInvalidCount},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New(seed(1)).Numbers(normal, tt.params, tt.count); !errors.Is(err, tt.expectErr) {
+				t.Errorf("error = %v, want %v", err, tt.expectErr)
+			}
+		})
+	}
+	uniform, _ := LookupDistribution("uniform")
+	if _, err := New(seed(1)).Numbers(uniform, map[string]float64{"a": -1.7e308, "b": 1.7e308}, 1); !errors.Is(err, calculator.ErrOverflow) {
+		t.Errorf("Numbers(wide uniform) error = %v, want %v", err, calculator.ErrOverflow)
+	}
+	if _, err := LookupDistribution("cauchy"); !errors.Is(err, ErrUnknownDistribution) {
+		t.Errorf("LookupDistribution error = %v", err)
+	}
+}
+
+func TestIntegers(t *testing.T) {
+	xs, err := New(seed(7)).Integers(-2, 2, 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	seen := map[int64]bool{}
+	for _, x := range xs {
+		if x < -2 || x > 2 {
+			t.Fatalf("%d out of range", x)
+		}
+		seen[x] = true
+	}
+	if len(seen) != 5 {
+		t.Errorf("drew %v, want every value in [-2, 2]", seen)
+	}
+	if xs, _ := New(seed(7)).Integers(3, 3, 2); !slices.Equal(xs, []int64{3, 3}) {
+		t.Errorf("Integers(3, 3) = %v", xs)
+	}
+	if _, err := New(seed(7)).Integers(3, 2, 1); !errors.Is(err, ErrInvalidRange) {
+		t.Errorf("Integers(3, 2) error = %v", err)
+	}
+}
+
+func TestShuffleAndSample(t *testing.T) {
+	items := []string{"a", "b", "c", "d", "e", "f"}
+	shuffled, err := Shuffle(New(seed(3)), items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sorted := slices.Sorted(slices.Values(shuffled))
+	if !slices.Equal(sorted, items) {
+		t.Errorf("Shuffle = %v, not a permutation of %v", shuffled, items)
+	}
+	again, _ := Shuffle(New(seed(3)), items)
+	if !slices.Equal(shuffled, again) {
+		t.Errorf("same seed gave %v and %v", shuffled, again)
+	}
+
+	sample, err := Sample(New(seed(3)), items, 6, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(slices.Sorted(slices.Values(sample)), items) {
+		t.Errorf("Sample without replacement = %v, repeats items", sample)
+	}
+	if sample, _ := Sample(New(seed(3)), items[:1], 3, true); !slices.Equal(sample, []string{"a", "a", "a"}) {
+		t.Errorf("Sample with replacement = %v", sample)
+	}
+	if _, err := Sample(New(seed(3)), items, 7, false); !errors.Is(err, ErrInvalidCount) {
+		t.Errorf("oversized sample error = %v", err)
+	}
+	if _, err := Sample(New(seed(3)), []string{}, 1, true); !errors.Is(err, ErrInvalidCount) {
+		t.Errorf("empty sample error = %v", err)
+	}
+}
@@ -0,0 +1,185 @@
+package stats
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/igorgatis/sezzle/backend/pkg/internal/calculator"
+)
+
+// MaxDegree bounds the degree of polynomial fits.
+const MaxDegree = 10
+
+// maxCondition is the condition number above which a fit is too sensitive
+// to rounding to be trusted.
+const maxCondition = 1e12
+
+var (
+	ErrLengthMismatch = &calculator.Error{Code: "length_mismatch", Message: "x and y must have the same length"}
+	ErrInvalidDegree  = &calculator.Error{Code: "invalid_degree", Message: "invalid polynomial degree"}
+	ErrTooFewPoints   = &calculator.Error{Code: "too_few_points", Message: "not enough points for the degree"}
+	ErrSingular       = &calculator.Error{Code: "singular_system", Message: "system is singular"}
+	ErrIllConditioned = &calculator.Error{Code: "ill_conditioned", Message: "system is ill-conditioned"}
+)
+
+// Fit is a least-squares polynomial fit. Coefficients are in increasing
+// order of power, so a line is intercept then slope.
+type Fit struct {
+	Coefficients []float64
+	RSquared     float64
+	Residuals    []float64
+}
+
+// Predict evaluates the fitted polynomial at x.
+func (f Fit) Predict(x float64) float64 {
+	y := 0.0
+	for i := len(f.Coefficients) - 1; i >= 0; i-- {
+		y = y*x + f.Coefficients[i]
+	}
+	return y
+}
+
+// Linear fits a line to the points (xs[i], ys[i]).
+func Linear(xs, ys []float64) (Fit, error) {
+	return Polynomial(xs, ys, 1)
+}
+
+// Polynomial fits a polynomial of degree to the points (xs[i], ys[i]). It
+// solves the least-squares problem with a Householder QR factorization of
+// the column-scaled Vandermonde matrix, rejecting systems whose distinct x
+// values cannot determine the coefficients (ErrSingular) or whose
+// estimated condition number exceeds 1e12 (ErrIllConditioned). Fits whose
+// coefficients or residuals overflow fail with calculator.ErrOverflow.
+func Polynomial(xs, ys []float64, degree int) (Fit, error) {
+	if len(xs) != len(ys) {
+		return Fit{}, fmt.Errorf("%w: %d and %d", ErrLengthMismatch, len(xs), len(ys))
+	}
+	if degree < 1 || degree > MaxDegree {
+		return Fit{}, fmt.Errorf("%w: %d, want 1 to %d", ErrInvalidDegree, degree, MaxDegree)
+	}
+	n := degree + 1
+	if len(xs) < n {
+		return Fit{}, fmt.Errorf("%w: degree %d needs %d, got %d", ErrTooFewPoints, degree, n, len(xs))
+	}
+	if err := check(xs); err != nil {
+		return Fit{}, fmt.Errorf("x: %w", err)
+	}
+	if err := check(ys); err != nil {
+		return Fit{}, fmt.Errorf("y: %w", err)
+	}
+	m := len(xs)
+	// a holds the columns of the Vandermonde matrix, each scaled to unit
+	// norm.
+	a := make([][]float64, n)
+	scale := make([]float64, n)
+	for j := range a {
+		a[j] = make([]float64, m)
+		for i, x := range xs {
+			a[j][i] = math.Pow(x, float64(j))
+		}
+		scale[j] = norm(a[j])
+		if scale[j] == 0 || math.IsInf(scale[j], 0) {
+			return Fit{}, fmt.Errorf("%w: x^%d", ErrSingular, j)
+		}
+		for i := range a[j] {
+			a[j][i] /= scale[j]
+		}
+	}
+	// b is y scaled by a power of two, exactly, so that reflecting it
+	// cannot overflow.
+	yMax := 0.0
+	for _, y := range ys {
+		yMax = math.Max(yMax, math.Abs(y))
+	}
+	_, e := math.Frexp(yMax)
+	b := make([]float64, m)
+	mean := 0.0
+	for i, y := range ys {
+		b[i] = math.Ldexp(y, -e)
+		mean += b[i]
+	}
+	mean = math.Ldexp(mean/float64(m), e)
+	diag := make([]float64, n)
+	for k := range n {
+		col := a[k][k:]
+		alpha := -math.Copysign(norm(col), col[0])
+		diag[k] = alpha
+		if alpha == 0 {
+			return Fit{}, ErrSingular
+		}
+		// v = col - alpha e1, stored in place.
+		col[0] -= alpha
+		vv := dot(col, col)
+		for j := k + 1; j < n; j++ {
+			reflect(col, a[j][k:], vv)
+		}
+		reflect(col, b[k:], vv)
+	}
+	lo, hi := math.Inf(1), 0.0
+	for _, d := range diag {
+		lo, hi = math.Min(lo, math.Abs(d)), math.Max(hi, math.Abs(d))
+	}
+	if lo <= hi*1e-15 {
+		return Fit{}, fmt.Errorf("%w: need %d distinct x values", ErrSingular, n)
+	}
+	if hi/lo > maxCondition {
+		return Fit{}, fmt.Errorf("%w: condition number about %.1e", ErrIllConditioned, hi/lo)
+	}
+	coef := make([]float64, n)
+	for i := n - 1; i >= 0; i-- {
+		s := b[i]
+		for j := i + 1; j < n; j++ {
+			s -= a[j][i] * coef[j]
+		}
+		coef[i] = s / diag[i]
+	}
+	for j := range coef {
+		coef[j] = math.Ldexp(coef[j]/scale[j], e)
+	}
+	fit := Fit{Coefficients: coef, Residuals: make([]float64, m)}
+	deviations := make([]float64, m)
+	for i, x := range xs {
+		fit.Residuals[i] = ys[i] - fit.Predict(x)
+		deviations[i] = ys[i] - mean
+	}
+	// R² is the ratio of the norms squared, which unlike the sums of
+	// squares do not overflow for large y.
+	fit.RSquared = 1
+	if tot := norm(deviations); tot > 0 {
+		r := norm(fit.Residuals) / tot
+		fit.RSquared = 1 - r*r
+	}
+	for _, v := range append(append([]float64{fit.RSquared}, coef...), fit.Residuals...) {
+		if math.IsInf(v, 0) || math.IsNaN(v) {
+			return Fit{}, calculator.ErrOverflow
+		}
+	}
+	return fit, nil
+}
+
+// reflect applies the Householder reflection I - 2vvᵀ/vv to x.
+func reflect(v, x []float64, vv float64) {
+	if vv == 0 {
+		return
+	}
+	f := 2 * dot(v, x) / vv
+	for i := range x {
+		x[i] -= f * v[i]
+	}
+}
+
+func dot(a, b []float64) float64 {
+	s := 0.0
+	for i := range a {
+		s += a[i] * b[i]
+	}
+	return s
+}
+
+func norm(a []float64) float64 {
+	s := 0.0
+	for _, x := range a {
+		s = math.Hypot(s, x)
+	}
+	return s
+}
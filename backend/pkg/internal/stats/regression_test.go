@@ -0,0 +1,118 @@
+package stats
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/igorgatis/sezzle/backend/pkg/internal/calculator"
+)
+
+func TestLinear(t *testing.T) {
+	// Points on y = 2x + 1 with residuals ±0.1.
+	xs := []float64{0, 1, 2, 3}
+	ys := []float64{1.1, 2.9, 5.1, 6.9}
+	fit, err := Linear(xs, ys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !near(fit.Coefficients[0], 1.06, 1e-12) || !near(fit.Coefficients[1], 1.96, 1e-12) {
+		t.Errorf("coefficients = %v, want [1.06 1.96]", fit.Coefficients)
+	}
+	if !near(fit.RSquared, 0.9983367983367983, 1e-12) {
+		t.Errorf("R² = %v", fit.RSquared)
+	}
+	sum := 0.0
+	for _, r := range fit.Residuals {
+		sum += r
+	}
+	if math.Abs(sum) > 1e-12 {
+		t.Errorf("residuals %v do not sum to zero", fit.Residuals)
+	}
+	if got := fit.Predict(10); !near(got, 20.66, 1e-12) {
+		t.Errorf("Predict(10) = %v, want 20.66", got)
+	}
+}
+
+// TestNISTNorris checks the certified values of the NIST StRD linear
+// regression dataset Norris.
+func TestNISTNorris(t *testing.T) {
+	ys := []float64{0.1, 338.8, 118.1, 888.0, 9.2, 228.1, 668.5, 998.5, 449.1, 778.9, 559.2, 0.3, 0.1, 778.1,
+		668.8, 339.3, 448.9, 10.8, 557.7, 228.3, 998.0, 888.8, 119.6, 0.3, 0.6, 557.6, 339.3, 888.0, 998.5,
+		778.9, 10.2, 117.6, 228.9, 668.4, 449.2, 0.2}
+	xs := []float64{0.2, 337.4, 118.2, 884.6, 10.1, 226.5, 666.3, 996.3, 448.6, 777.0, 558.2, 0.4, 0.6, 775.5,
+		666.9, 338.0, 447.5, 11.6, 556.0, 228.1, 995.8, 887.6, 120.2, 0.3, 0.3, 556.8, 339.1, 887.2, 999.0,
+		779.0, 11.1, 118.3, 229.2, 669.1, 448.9, 0.5}
+	fit, err := Linear(xs, ys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !near(fit.Coefficients[0], -0.262323073774029, 1e-10) || !near(fit.Coefficients[1], 1.00211681802045, 1e-12) {
+		t.Errorf("coefficients = %v", fit.Coefficients)
+	}
+	if !near(fit.RSquared, 0.999993745883712, 1e-12) {
+		t.Errorf("R² = %v", fit.RSquared)
+	}
+}
+
+func TestPolynomial(t *testing.T) {
+	// y = 1 - 2x + 0.5x² exactly.
+	xs := []float64{-2, -1, 0, 1, 2, 3}
+	ys := make([]float64, len(xs))
+	for i, x := range xs {
+		ys[i] = 1 - 2*x + 0.5*x*x
+	}
+	fit, err := Polynomial(xs, ys, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []float64{1, -2, 0.5}
+	for i, c := range fit.Coefficients {
+		if !near(c, want[i], 1e-12) {
+			t.Errorf("coefficients = %v, want %v", fit.Coefficients, want)
+			break
+		}
+	}
+	if !near(fit.RSquared, 1, 1e-12) {
+		t.Errorf("R² = %v, want 1", fit.RSquared)
+	}
+}
+
+// TestPolynomialLarge fits values whose sums and squares overflow.
+func TestPolynomialLarge(t *testing.T) {
+	fit, err := Polynomial([]float64{1, 2, 3}, []float64{1e308, -1e308, 1e308}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !near(fit.Coefficients[0], 1e308/3, 1e-12) || math.Abs(fit.Coefficients[1]) > 1e-12*1e308 ||
+		!near(fit.RSquared, 0, 1e-12) || !near(fit.Residuals[1], -4e308/3, 1e-12) {
+		t.Errorf("unexpected fit: %+v", fit)
+	}
+}
+
+func TestPolynomialErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		xs, ys    []float64
+		degree    int
+		expectErr error
+	}{
+		{"length mismatch", []float64{1, 2}, []float64{1}, 1, ErrLengthMismatch},
+		{"degree zero", []float64{1, 2}, []float64{1, 2}, 0, ErrInvalidDegree},
+		{"degree too high", []float64{1, 2}, []float64{1, 2}, 11, ErrInvalidDegree},
+		{"too few points", []float64{1, 2}, []float64{1, 2}, 2, ErrTooFewPoints},
+		{"vertical line", []float64{3, 3, 3}, []float64{1, 2, 3}, 1, ErrSingular},
+		{"zero x", []float64{0, 0, 0}, []float64{1, 2, 3}, 1, ErrSingular},
+		{"two distinct x for a parabola", []float64{1, 1, 2, 2}, []float64{1, 2, 3, 4}, 2, ErrSingular},
+		{"clustered x", []float64{1e4, 1e4 + 1e-3, 1e4 + 2e-3, 1e4 + 3e-3}, []float64{1, 2, 3, 4}, 2, ErrIllConditioned},
+		{"empty", nil, nil, 1, ErrTooFewPoints},
+		{"overflow", []float64{1, 2, 3}, []float64{1.7e308, -1.7e308, 1.7e308}, 2, calculator.ErrOverflow},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Polynomial(tt.xs, tt.ys, tt.degree); !errors.Is(err, tt.expectErr) {
+				t.Errorf("error = %v, want %v", err, tt.expectErr)
+			}
+		})
+	}
+}
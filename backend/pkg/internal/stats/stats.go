@@ -0,0 +1,135 @@
+// Package stats implements descriptive statistics over lists of numbers.
+package stats
+
+import (
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/igorgatis/sezzle/backend/pkg/internal/calculator"
+)
+
+// MaxValues bounds the size of a data set.
+const MaxValues = 1_000_000
+
+var (
+	ErrEmpty             = &calculator.Error{Code: "empty_data", Message: "data set is empty"}
+	ErrTooManyValues     = &calculator.Error{Code: "too_many_values", Message: "data set is too large"}
+	ErrInvalidValue      = &calculator.Error{Code: "invalid_value", Message: "values must be finite"}
+	ErrInvalidPercentile = &calculator.Error{Code: "invalid_percentile", Message: "percentile must be between 0 and 100"}
+)
+
+// Summary describes a data set. Sample variance and deviation are NaN for
+// a single value.
+type Summary struct {
+	Count           int
+	Sum, Mean       float64
+	Min, Max, Range float64
+	Median          float64
+	Q1, Q3, IQR     float64
+	// Mode holds the most frequent values, in increasing order, or none
+	// when no value repeats.
+	Mode               []float64
+	PopulationVariance float64
+	SampleVariance     float64
+	PopulationStdDev   float64
+	SampleStdDev       float64
+
+	sorted []float64
+}
+
+// Describe summarizes xs. The sum is compensated (Kahan–Babuška) and the
+// mean and variance are accumulated with Welford's algorithm, so that
+// large offsets do not swamp small deviations.
+func Describe(xs []float64) (Summary, error) {
+	if err := check(xs); err != nil {
+		return Summary{}, err
+	}
+	s := Summary{Count: len(xs), sorted: slices.Sorted(slices.Values(xs))}
+	var sum, comp, mean, m2 float64
+	for i, x := range xs {
+		t := sum + x
+		if math.Abs(sum) >= math.Abs(x) {
+			comp += (sum - t) + x
+		} else {
+			comp += (x - t) + sum
+		}
+		sum = t
+		delta := x - mean
+		mean += delta / float64(i+1)
+		m2 += delta * (x - mean)
+	}
+	s.Sum, s.Mean = sum+comp, mean
+	s.Min, s.Max = s.sorted[0], s.sorted[len(xs)-1]
+	s.Range = s.Max - s.Min
+	s.Median = s.percentile(50)
+	s.Q1, s.Q3 = s.percentile(25), s.percentile(75)
+	s.IQR = s.Q3 - s.Q1
+	s.Mode = mode(s.sorted)
+	s.PopulationVariance = m2 / float64(len(xs))
+	s.SampleVariance = math.NaN()
+	if len(xs) > 1 {
+		s.SampleVariance = m2 / float64(len(xs)-1)
+	}
+	s.PopulationStdDev = math.Sqrt(s.PopulationVariance)
+	s.SampleStdDev = math.Sqrt(s.SampleVariance)
+	for _, v := range []float64{s.Sum, s.Mean, s.Range, s.PopulationVariance, s.IQR} {
+		if math.IsInf(v, 0) || math.IsNaN(v) {
+			return Summary{}, calculator.ErrOverflow
+		}
+	}
+	return s, nil
+}
+
+func check(xs []float64) error {
+	if len(xs) == 0 {
+		return ErrEmpty
+	}
+	if len(xs) > MaxValues {
+		return fmt.Errorf("%w: %d values, at most %d", ErrTooManyValues, len(xs), MaxValues)
+	}
+	for i, x := range xs {
+		if math.IsInf(x, 0) || math.IsNaN(x) {
+			return fmt.Errorf("%w: value %d", ErrInvalidValue, i)
+		}
+	}
+	return nil
+}
+
+// Percentile returns the pth percentile, p in [0, 100], interpolating
+// linearly between the closest ranks as spreadsheets' PERCENTILE.INC does.
+func (s Summary) Percentile(p float64) (float64, error) {
+	if !(p >= 0 && p <= 100) {
+		return 0, fmt.Errorf("%w: %g", ErrInvalidPercentile, p)
+	}
+	return s.percentile(p), nil
+}
+
+func (s Summary) percentile(p float64) float64 {
+	h := p * float64(len(s.sorted)-1) / 100
+	lo := math.Floor(h)
+	i := int(lo)
+	if i+1 >= len(s.sorted) {
+		return s.sorted[len(s.sorted)-1]
+	}
+	return s.sorted[i] + (h-lo)*(s.sorted[i+1]-s.sorted[i])
+}
+
+func mode(sorted []float64) []float64 {
+	var modes []float64
+	best := 1
+	for i := 0; i < len(sorted); {
+		j := i + 1
+		for j < len(sorted) && sorted[j] == sorted[i] {
+			j++
+		}
+		switch n := j - i; {
+		case n > best:
+			best, modes = n, []float64{sorted[i]}
+		case n == best && n > 1:
+			modes = append(modes, sorted[i])
+		}
+		i = j
+	}
+	return modes
+}
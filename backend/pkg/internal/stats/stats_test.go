@@ -0,0 +1,149 @@
+package stats
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/igorgatis/sezzle/backend/pkg/internal/calculator"
+)
+
+func near(a, b, tol float64) bool {
+	return math.Abs(a-b) <= tol*math.Max(1, math.Abs(b))
+}
+
+func TestDescribe(t *testing.T) {
+	s, err := Describe([]float64{2, 4, 4, 4, 5, 5, 7, 9})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Summary{
+		Count: 8, Sum: 40, Mean: 5, Min: 2, Max: 9, Range: 7, Median: 4.5,
+		Q1: 4, Q3: 5.5, IQR: 1.5, Mode: []float64{4},
+		PopulationVariance: 4, SampleVariance: 32.0 / 7, PopulationStdDev: 2, SampleStdDev: math.Sqrt(32.0 / 7),
+	}
+	s.sorted = nil
+	if s.Count != want.Count || s.Sum != want.Sum || s.Mean != want.Mean || s.Min != want.Min ||
+		s.Max != want.Max || s.Range != want.Range || s.Median != want.Median || s.Q1 != want.Q1 ||
+		s.Q3 != want.Q3 || s.IQR != want.IQR || !slices.Equal(s.Mode, want.Mode) ||
+		s.PopulationVariance != want.PopulationVariance || !near(s.SampleVariance, want.SampleVariance, 1e-15) ||
+		s.PopulationStdDev != want.PopulationStdDev || !near(s.SampleStdDev, want.SampleStdDev, 1e-15) {
+		t.Errorf("Describe = %+v, want %+v", s, want)
+	}
+}
+
+// TestNISTDatasets checks the certified values of the NIST StRD univariate
+// summary statistics datasets NumAcc1 and NumAcc4, whose large offsets break
+// naive variance algorithms.
+func TestNISTDatasets(t *testing.T) {
+	numAcc4 := []float64{1000000.2}
+	for range 500 {
+		numAcc4 = append(numAcc4, 1000000.1, 1000000.3)
+	}
+	tests := []struct {
+		name         string
+		data         []float64
+		mean, stdDev float64
+	}{
+		{"NumAcc1", []float64{10000001, 10000003, 10000002}, 10000002, 1},
+		{"NumAcc4", numAcc4, 1000000.2, 0.1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := Describe(tt.data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !near(s.Mean, tt.mean, 1e-15) || !near(s.SampleStdDev, tt.stdDev, 1e-8) {
+				t.Errorf("mean = %v, sample std dev = %v, want %v, %v", s.Mean, s.SampleStdDev, tt.mean, tt.stdDev)
+			}
+		})
+	}
+}
+
+func TestCompensatedSum(t *testing.T) {
+	data := []float64{1e16}
+	for range 1000 {
+		data = append(data, 1)
+	}
+	data = append(data, -1e16)
+	s, err := Describe(data)
+	if err != nil || s.Sum != 1000 {
+		t.Errorf("Sum = %v, %v, want 1000", s.Sum, err)
+	}
+}
+
+func TestMode(t *testing.T) {
+	tests := []struct {
+		data []float64
+		want []float64
+	}{
+		{[]float64{1, 2, 3}, nil},
+		{[]float64{3, 1, 3, 1, 2}, []float64{1, 3}},
+		{[]float64{7}, nil},
+		{[]float64{5, 5, 5, 1, 1}, []float64{5}},
+	}
+	for _, tt := range tests {
+		s, _ := Describe(tt.data)
+		if !slices.Equal(s.Mode, tt.want) {
+			t.Errorf("mode of %v = %v, want %v", tt.data, s.Mode, tt.want)
+		}
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	s, _ := Describe([]float64{15, 20, 35, 40, 50})
+	tests := []struct {
+		p         float64
+		expected  float64
+		expectErr error
+	}{
+		{0, 15, nil},
+		{100, 50, nil},
+		{40, 29, nil},
+		{90, 46, nil},
+		{50, 35, nil},
+		{-1, 0, ErrInvalidPercentile},
+		{101, 0, ErrInvalidPercentile},
+		{math.NaN(), 0, ErrInvalidPercentile},
+	}
+	for _, tt := range tests {
+		got, err := s.Percentile(tt.p)
+		if !errors.Is(err, tt.expectErr) || (err == nil && !near(got, tt.expected, 1e-12)) {
+			t.Errorf("Percentile(%v) = %v, %v, want %v, %v", tt.p, got, err, tt.expected, tt.expectErr)
+		}
+	}
+}
+
+func TestDescribeErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      []float64
+		expectErr error
+	}{
+		{"empty", nil, ErrEmpty},
+		{"infinite", []float64{1, math.Inf(1)}, ErrInvalidValue},
+		{"overflow", []float64{math.MaxFloat64, math.MaxFloat64}, calculator.ErrOverflow},
+	}
+	for _, tt := range tests {
+		if _, err := Describe(tt.data); !errors.Is(err, tt.expectErr) {
+			t.Errorf("%s: error = %v, want %v", tt.name, err, tt.expectErr)
+		}
+	}
+	if s, err := Describe([]float64{3}); err != nil || !math.IsNaN(s.SampleVariance) || s.PopulationVariance != 0 {
+		t.Errorf("single value = %+v, %v", s, err)
+	}
+}
+
+func BenchmarkDescribe(b *testing.B) {
+	data := make([]float64, 100_000)
+	for i := range data {
+		data[i] = math.Sin(float64(i))
+	}
+	for b.Loop() {
+		if _, err := Describe(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package storage
+
+import (
+	"container/list"
+	"slices"
+	"strings"
+	"sync"
+)
+
+type lruEntry[T any] struct {
+	key   string
+	value T
+}
+
+// lruStore is a memory store that evicts the least recently used item once
+// it holds more than capacity.
+type lruStore[T any] struct {
+	mu       sync.Mutex
+	capacity int
+	// order holds the entries from the most to the least recently used.
+	order *list.List
+	items map[string]*list.Element
+}
+
+// NewLRU returns a memory store of at most capacity items, which evicts the
+// least recently read or written one to make room.
+func NewLRU[T any](capacity int) Store[T] {
+	return &lruStore[T]{capacity: capacity, order: list.New(), items: make(map[string]*list.Element)}
+}
+
+func (s *lruStore[T]) Get(key string) (T, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.items[key]
+	if !ok {
+		var zero T
+		return zero, ErrNotFound
+	}
+	s.order.MoveToFront(e)
+	return e.Value.(*lruEntry[T]).value, nil
+}
+
+func (s *lruStore[T]) Put(key string, value T) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if e, ok := s.items[key]; ok {
+		e.Value.(*lruEntry[T]).value = value
+		s.order.MoveToFront(e)
+		return nil
+	}
+	s.items[key] = s.order.PushFront(&lruEntry[T]{key, value})
+	if s.order.Len() > s.capacity {
+		oldest := s.order.Remove(s.order.Back()).(*lruEntry[T])
+		delete(s.items, oldest.key)
+	}
+	return nil
+}
+
+func (s *lruStore[T]) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.items[key]
+	if !ok {
+		return ErrNotFound
+	}
+	s.order.Remove(e)
+	delete(s.items, key)
+	return nil
+}
+
+func (s *lruStore[T]) List(prefix string) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var keys []string
+	for k := range s.items {
+		if strings.HasPrefix(k, prefix) {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+	return keys, nil
+}
@@ -0,0 +1,42 @@
+package storage
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestLRUStore(t *testing.T) {
+	s := NewLRU[int](2)
+	if _, err := s.Get("missing"); err != ErrNotFound {
+		t.Errorf("Get(missing) error = %v, want %v", err, ErrNotFound)
+	}
+	s.Put("a", 1)
+	s.Put("b", 2)
+	// Reading a makes b the least recently used.
+	if v, err := s.Get("a"); err != nil || v != 1 {
+		t.Errorf("Get(a) = %v, %v, want 1, nil", v, err)
+	}
+	s.Put("c", 3)
+	if _, err := s.Get("b"); err != ErrNotFound {
+		t.Errorf("Get(b) after eviction error = %v, want %v", err, ErrNotFound)
+	}
+	// Overwriting keeps the size and refreshes the key.
+	s.Put("a", 4)
+	s.Put("d", 5)
+	if keys, _ := s.List(""); !slices.Equal(keys, []string{"a", "d"}) {
+		t.Errorf("List() = %v, want [a d]", keys)
+	}
+	if v, err := s.Get("a"); err != nil || v != 4 {
+		t.Errorf("Get(a) = %v, %v, want 4, nil", v, err)
+	}
+	if err := s.Delete("a"); err != nil {
+		t.Errorf("Delete(a) error = %v", err)
+	}
+	if err := s.Delete("a"); err != ErrNotFound {
+		t.Errorf("Delete(a) again error = %v, want %v", err, ErrNotFound)
+	}
+	s.Put("e", 6)
+	if keys, _ := s.List(""); !slices.Equal(keys, []string{"d", "e"}) {
+		t.Errorf("List() = %v, want [d e]", keys)
+	}
+}
@@ -0,0 +1,56 @@
+package storage
+
+import (
+	"slices"
+	"strings"
+	"sync"
+)
+
+type memoryStore[T any] struct {
+	mu    sync.RWMutex
+	items map[string]T
+}
+
+func NewMemory[T any]() Store[T] {
+	return &memoryStore[T]{items: make(map[string]T)}
+}
+
+func (s *memoryStore[T]) Get(key string) (T, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	v, ok := s.items[key]
+	if !ok {
+		return v, ErrNotFound
+	}
+	return v, nil
+}
+
+func (s *memoryStore[T]) Put(key string, value T) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.items[key] = value
+	return nil
+}
+
+func (s *memoryStore[T]) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.items[key]; !ok {
+		return ErrNotFound
+	}
+	delete(s.items, key)
+	return nil
+}
+
+func (s *memoryStore[T]) List(prefix string) ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var keys []string
+	for k := range s.items {
+		if strings.HasPrefix(k, prefix) {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+	return keys, nil
+}
@@ -0,0 +1,48 @@
+package storage
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestMemoryStore(t *testing.T) {
+	s := NewMemory[int]()
+
+	if _, err := s.Get("missing"); err != ErrNotFound {
+		t.Errorf("Get(missing) error = %v, want %v", err, ErrNotFound)
+	}
+	if err := s.Delete("missing"); err != ErrNotFound {
+		t.Errorf("Delete(missing) error = %v, want %v", err, ErrNotFound)
+	}
+
+	for i, k := range []string{"b/2", "a/1", "b/1"} {
+		if err := s.Put(k, i); err != nil {
+			t.Fatalf("Put(%q) error = %v", k, err)
+		}
+	}
+	if v, err := s.Get("a/1"); err != nil || v != 1 {
+		t.Errorf("Get(a/1) = %v, %v, want 1, nil", v, err)
+	}
+
+	tests := []struct {
+		prefix string
+		want   []string
+	}{
+		{"", []string{"a/1", "b/1", "b/2"}},
+		{"b/", []string{"b/1", "b/2"}},
+		{"c/", nil},
+	}
+	for _, tt := range tests {
+		got, err := s.List(tt.prefix)
+		if err != nil || !slices.Equal(got, tt.want) {
+			t.Errorf("List(%q) = %v, %v, want %v", tt.prefix, got, err, tt.want)
+		}
+	}
+
+	if err := s.Delete("b/1"); err != nil {
+		t.Errorf("Delete(b/1) error = %v", err)
+	}
+	if _, err := s.Get("b/1"); err != ErrNotFound {
+		t.Errorf("Get(b/1) after delete error = %v, want %v", err, ErrNotFound)
+	}
+}
@@ -0,0 +1,15 @@
+package storage
+
+import "errors"
+
+var ErrNotFound = errors.New("not found")
+
+// Store keeps server-side state by key. Implementations must be safe for
+// concurrent use.
+type Store[T any] interface {
+	Get(key string) (T, error)
+	Put(key string, value T) error
+	Delete(key string) error
+	// List returns the sorted keys starting with prefix.
+	List(prefix string) ([]string, error)
+}
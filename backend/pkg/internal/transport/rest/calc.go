@@ -2,163 +2,317 @@ package rest
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/cmplx"
 	"net/http"
+	"reflect"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	"github.com/igorgatis/sezzle/backend/pkg/internal/calculator"
+	"github.com/igorgatis/sezzle/backend/pkg/internal/numfmt"
 )
 
+var errMissingOperand = errors.New("missing operand")
+
+// BinaryOperand and UnaryOperand are the request bodies of the operations
+// served before the registry.
 type BinaryOperand struct {
-	A json.Number `json:"a" binding:"required" example:"12.3" swaggertype:"number"`
-	B json.Number `json:"b" binding:"required" example:"4.5" swaggertype:"number"`
+	A json.Number `json:"a"`
+	B json.Number `json:"b"`
 }
 
 type UnaryOperand struct {
-	A json.Number `json:"a" binding:"required" example:"6.7" swaggertype:"number"`
+	A json.Number `json:"a"`
+}
+
+// legacyOperandTypes maps the operations served before the registry to
+// their request types, whose missing operands and malformed bodies are still
+// reported in the words of gin's binding.
+var legacyOperandTypes = map[string]reflect.Type{
+	"add":        reflect.TypeFor[BinaryOperand](),
+	"subtract":   reflect.TypeFor[BinaryOperand](),
+	"multiply":   reflect.TypeFor[BinaryOperand](),
+	"divide":     reflect.TypeFor[BinaryOperand](),
+	"power":      reflect.TypeFor[BinaryOperand](),
+	"percentage": reflect.TypeFor[BinaryOperand](),
+	"sqrt":       reflect.TypeFor[UnaryOperand](),
+}
+
+// missingOperandError reports the missing operands of operation name, the
+// first one only unless it is one of legacyOperandTypes.
+func missingOperandError(name string, missing []string) error {
+	typ, ok := legacyOperandTypes[name]
+	if !ok {
+		return fmt.Errorf("%w: %s", errMissingOperand, missing[0])
+	}
+	lines := make([]string, len(missing))
+	for i, m := range missing {
+		field := strings.ToUpper(m[:1]) + m[1:]
+		lines[i] = fmt.Sprintf("Key: '%s.%s' Error:Field validation for '%s' failed on the 'required' tag", typ.Name(), field, field)
+	}
+	return errors.New(strings.Join(lines, "\n"))
+}
+
+// bindError rewords err, the error binding the request body of operation
+// name, as binding its legacyOperandTypes type does, which names that type
+// when the body is not an object.
+func bindError(c *gin.Context, name string, err error) error {
+	typ, ok := legacyOperandTypes[name]
+	var typeErr *json.UnmarshalTypeError
+	if !ok || !errors.As(err, &typeErr) {
+		return err
+	}
+	if legacyErr := binding.JSON.BindBody(c.MustGet(gin.BodyBytesKey).([]byte), reflect.New(typ).Interface()); legacyErr != nil {
+		return legacyErr
+	}
+	return err
 }
 
 type Response struct {
 	Result json.Number `json:"result" example:"8.9" swaggertype:"number"`
+	// Rounding is the rounding applied to Result, if any.
+	Rounding *RoundingOptions `json:"rounding,omitempty"`
+	// Formatted is set when the request has format options.
+	Formatted *Formatted `json:"formatted,omitempty"`
 }
 
 type ErrorResponse struct {
 	Error string `json:"error" example:"invalid input"`
+	// Code is set for domain errors, e.g. "division_by_zero".
+	Code string `json:"code,omitempty" example:"division_by_zero"`
+}
+
+// ComplexNumber is a complex operand or result in rectangular form.
+type ComplexNumber struct {
+	Re json.Number `json:"re" example:"3" swaggertype:"number"`
+	Im json.Number `json:"im" example:"4" swaggertype:"number"`
+}
+
+type Polar struct {
+	R     json.Number `json:"r" example:"5" swaggertype:"number"`
+	Theta json.Number `json:"theta" example:"0.9272952180016122" swaggertype:"number"`
 }
 
-func RegisterCalculatorV1(r gin.IRouter, calc calculator.Calculator) {
+// ComplexResponse is returned by operations evaluated in complex mode.
+type ComplexResponse struct {
+	Result ComplexNumber `json:"result"`
+	Text   string        `json:"text" example:"3+4i"`
+	// Polar holds the modulus and the argument in radians.
+	Polar Polar `json:"polar"`
+}
+
+const (
+	// AngleUnitField is the optional request field selecting the angle unit
+	// of operations on angles.
+	AngleUnitField = "angle_unit"
+	// ModeField is the optional request field selecting real or complex
+	// mode.
+	ModeField = "mode"
+	// LocaleField is the optional request field selecting the locale of
+	// operands given as strings.
+	LocaleField = "locale"
+)
+
+// RegisterCalculatorV1 serves every operation of ops at POST /v1/{name}. The
+// request body holds one JSON number (or numeric string) per operand.
+// Results are rounded as rounding unless requests ask otherwise.
+func RegisterCalculatorV1(r gin.IRouter, ops *calculator.Registry, rounding calculator.Rounding) {
 	g := r.Group("/v1")
-	g.POST("/add", addHandler(calc))
-	g.POST("/subtract", subtractHandler(calc))
-	g.POST("/multiply", multiplyHandler(calc))
-	g.POST("/divide", divideHandler(calc))
-	g.POST("/power", powerHandler(calc))
-	g.POST("/sqrt", sqrtHandler(calc))
-	g.POST("/percentage", percentageHandler(calc))
-}
-
-func writeResponse(c *gin.Context, result float64) {
-	c.JSON(http.StatusOK, Response{
-		Result: json.Number(strconv.FormatFloat(result, 'f', -1, 64)),
+	g.GET("/operations", operationsHandler(ops))
+	for _, op := range ops.Operations() {
+		g.POST("/"+op.Name, operationHandler(op, rounding))
+	}
+}
+
+func formatNumber(v float64) json.Number {
+	return json.Number(strconv.FormatFloat(v, 'f', -1, 64))
+}
+
+// writeComplexResponse writes z, failing with calculator.ErrOverflow when
+// its modulus overflows even though its parts may not.
+func writeComplexResponse(c *gin.Context, z complex128) {
+	r := cmplx.Abs(z)
+	if cmplx.IsInf(z) || cmplx.IsNaN(z) || math.IsInf(r, 0) {
+		writeErrorResponse(c, calculator.ErrOverflow)
+		return
+	}
+	c.JSON(http.StatusOK, ComplexResponse{
+		Result: ComplexNumber{Re: formatNumber(real(z)), Im: formatNumber(imag(z))},
+		Text:   calculator.FormatComplex(z),
+		Polar:  Polar{R: formatNumber(r), Theta: formatNumber(cmplx.Phase(z))},
 	})
 }
 
 func writeErrorResponse(c *gin.Context, err error) {
-	c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
-}
-
-// @Summary Add two numbers
-// @Param input body BinaryOperand true "Operands"
-// @Success 200 {object} Response
-// @Failure 400 {object} ErrorResponse
-// @Router /v1/add [post]
-func addHandler(calc calculator.Calculator) gin.HandlerFunc {
-	return binaryOp(calc.Add)
-}
-
-// @Summary Subtract two numbers
-// @Param input body BinaryOperand true "Operands"
-// @Success 200 {object} Response
-// @Failure 400 {object} ErrorResponse
-// @Router /v1/subtract [post]
-func subtractHandler(calc calculator.Calculator) gin.HandlerFunc {
-	return binaryOp(calc.Subtract)
-}
-
-// @Summary Multiply two numbers
-// @Param input body BinaryOperand true "Operands"
-// @Success 200 {object} Response
-// @Failure 400 {object} ErrorResponse
-// @Router /v1/multiply [post]
-func multiplyHandler(calc calculator.Calculator) gin.HandlerFunc {
-	return binaryOp(calc.Multiply)
-}
-
-// @Summary Divide two numbers
-// @Param input body BinaryOperand true "Operands"
-// @Success 200 {object} Response
-// @Failure 400 {object} ErrorResponse
-// @Router /v1/divide [post]
-func divideHandler(calc calculator.Calculator) gin.HandlerFunc {
-	return binaryOpErr(calc.Divide)
-}
-
-// @Summary Power operation
-// @Param input body BinaryOperand true "Operands"
-// @Success 200 {object} Response
-// @Failure 400 {object} ErrorResponse
-// @Router /v1/power [post]
-func powerHandler(calc calculator.Calculator) gin.HandlerFunc {
-	return binaryOpErr(calc.Power)
-}
-
-// @Summary Square root
-// @Param input body UnaryOperand true "Operand"
-// @Success 200 {object} Response
-// @Failure 400 {object} ErrorResponse
-// @Router /v1/sqrt [post]
-func sqrtHandler(calc calculator.Calculator) gin.HandlerFunc {
-	return unaryOpErr(calc.Sqrt)
-}
-
-// @Summary Percentage calculation
-// @Param input body BinaryOperand true "Operands"
-// @Success 200 {object} Response
-// @Failure 400 {object} ErrorResponse
-// @Router /v1/percentage [post]
-func percentageHandler(calc calculator.Calculator) gin.HandlerFunc {
-	return binaryOpErr(calc.Percentage)
-}
-
-func binaryOp(op func(a, b float64) float64) gin.HandlerFunc {
-	return binaryOpErr(func(a, b float64) (float64, error) {
-		return op(a, b), nil
-	})
+	resp := ErrorResponse{Error: err.Error()}
+	var domainErr *calculator.Error
+	if errors.As(err, &domainErr) {
+		resp.Code = domainErr.Code
+	}
+	c.JSON(http.StatusBadRequest, resp)
 }
 
-func binaryOpErr(op func(a, b float64) (float64, error)) gin.HandlerFunc {
+func operationHandler(op calculator.Operation, defaultRounding calculator.Rounding) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		var input BinaryOperand
-		if err := c.ShouldBindJSON(&input); err != nil {
+		var input map[string]json.RawMessage
+		if err := c.ShouldBindBodyWithJSON(&input); err != nil {
+			writeErrorResponse(c, bindError(c, op.Name, err))
+			return
+		}
+		mode, err := stringField(input, ModeField, calculator.ParseMode)
+		if err != nil {
 			writeErrorResponse(c, err)
 			return
 		}
-		a, err := input.A.Float64()
+		if mode == calculator.Complex {
+			complexOperation(c, op, input)
+			return
+		}
+		locale, err := stringField(input, LocaleField, parseLocale)
 		if err != nil {
-			writeErrorResponse(c, err) // Should never happen.
+			writeErrorResponse(c, err)
+			return
+		}
+		args := make([]float64, len(op.Operands))
+		var missing []string
+		for i, o := range op.Operands {
+			raw, ok := input[o.Name]
+			if !ok || string(raw) == "null" {
+				missing = append(missing, o.Name)
+				continue
+			}
+			if args[i], err = parseOperand(raw, locale); err != nil {
+				writeErrorResponse(c, err)
+				return
+			}
+		}
+		if len(missing) > 0 {
+			writeErrorResponse(c, missingOperandError(op.Name, missing))
 			return
 		}
-		b, err := input.B.Float64()
+		unit := calculator.Radians
+		if op.AngleEval != nil {
+			if unit, err = stringField(input, AngleUnitField, calculator.ParseAngleUnit); err != nil {
+				writeErrorResponse(c, err)
+				return
+			}
+		}
+		var format *FormatOptions
+		if raw, ok := input[FormatField]; ok {
+			if err := json.Unmarshal(raw, &format); err != nil {
+				writeErrorResponse(c, fmt.Errorf("%s: %w", FormatField, err))
+				return
+			}
+			if format != nil && format.Locale == "" && locale != nil {
+				format.Locale = locale.Tag
+			}
+		}
+		var roundingOpts *RoundingOptions
+		if raw, ok := input[RoundingField]; ok {
+			if err := json.Unmarshal(raw, &roundingOpts); err != nil {
+				writeErrorResponse(c, fmt.Errorf("%s: %w", RoundingField, err))
+				return
+			}
+		}
+		rounding, err := resolveRounding(defaultRounding, roundingOpts)
 		if err != nil {
-			writeErrorResponse(c, err) // Should never happen.
+			writeErrorResponse(c, err)
 			return
 		}
-		result, err := op(a, b)
+		result, err := op.ApplyUnit(args, unit)
 		if err != nil {
 			writeErrorResponse(c, err)
 			return
 		}
-		writeResponse(c, result)
+		writeResponse(c, result, rounding, format)
 	}
 }
 
-func unaryOpErr(op func(a float64) (float64, error)) gin.HandlerFunc {
-	return func(c *gin.Context) {
-		var input UnaryOperand
-		if err := c.ShouldBindJSON(&input); err != nil {
-			writeErrorResponse(c, err)
-			return
+// parseOperand parses a JSON number or numeric string. With a locale,
+// strings are written with its separators, e.g. "1.234,5" in de-DE.
+func parseOperand(raw json.RawMessage, locale *numfmt.Locale) (float64, error) {
+	var s string
+	if locale != nil && json.Unmarshal(raw, &s) == nil {
+		return locale.Parse(s)
+	}
+	var n json.Number
+	if err := json.Unmarshal(raw, &n); err != nil {
+		return 0, err
+	}
+	return n.Float64()
+}
+
+// stringField parses the optional string field name of input with parse,
+// which must accept "" as the default.
+func stringField[T any](input map[string]json.RawMessage, name string, parse func(string) (T, error)) (T, error) {
+	var s string
+	if raw, ok := input[name]; ok {
+		if err := json.Unmarshal(raw, &s); err != nil {
+			var zero T
+			return zero, fmt.Errorf("%s: %w", name, err)
 		}
-		a, err := input.A.Float64()
-		if err != nil {
-			writeErrorResponse(c, err) // Should never happen.
+	}
+	return parse(s)
+}
+
+// complexOperation evaluates op in complex mode. Operands may be JSON
+// numbers, strings such as "3+4i" or objects such as {"re": 3, "im": 4}.
+func complexOperation(c *gin.Context, op calculator.Operation, input map[string]json.RawMessage) {
+	if unit, err := stringField(input, AngleUnitField, calculator.ParseAngleUnit); err != nil {
+		writeErrorResponse(c, err)
+		return
+	} else if unit != calculator.Radians {
+		writeErrorResponse(c, fmt.Errorf("%w: angles are in radians", calculator.ErrComplexUnsupported))
+		return
+	}
+	args := make([]complex128, len(op.Operands))
+	for i, o := range op.Operands {
+		raw, ok := input[o.Name]
+		if !ok || string(raw) == "null" {
+			writeErrorResponse(c, fmt.Errorf("%w: %s", errMissingOperand, o.Name))
 			return
 		}
-		result, err := op(a)
+		z, err := parseComplexOperand(raw)
 		if err != nil {
-			writeErrorResponse(c, err)
+			writeErrorResponse(c, fmt.Errorf("%s: %w", o.Name, err))
 			return
 		}
-		writeResponse(c, result)
+		args[i] = z
+	}
+	result, err := op.ApplyComplex(args)
+	if err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	writeComplexResponse(c, result)
+}
+
+func parseComplexOperand(raw json.RawMessage) (complex128, error) {
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return calculator.ParseComplex(s)
+	}
+	var n json.Number
+	if json.Unmarshal(raw, &n) == nil {
+		v, err := n.Float64()
+		return complex(v, 0), err
+	}
+	var obj struct {
+		Re *json.Number `json:"re"`
+		Im *json.Number `json:"im"`
+	}
+	if err := json.Unmarshal(raw, &obj); err != nil || obj.Re == nil || obj.Im == nil {
+		return 0, calculator.ErrInvalidComplex
+	}
+	re, err := obj.Re.Float64()
+	if err != nil {
+		return 0, err
 	}
+	im, err := obj.Im.Float64()
+	return complex(re, im), err
 }
@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/gin-gonic/gin"
+	"github.com/igorgatis/sezzle/backend/pkg/internal/calculator"
 )
 
 type mockCalculator struct {
@@ -31,7 +32,7 @@ func init() {
 
 func setupServer(mock *mockCalculator) *httptest.Server {
 	engine := gin.New()
-	RegisterCalculatorV1(engine, mock)
+	RegisterCalculatorV1(engine, calculator.DefaultRegistry(mock), calculator.Rounding{})
 	return httptest.NewServer(engine)
 }
 
@@ -55,8 +56,11 @@ func TestOperationsSanity(t *testing.T) {
 			srv := setupServer(&mockCalculator{result: tt.result})
 			defer srv.Close()
 
-			resp, _ := http.Post(srv.URL+tt.op, "application/json",
+			resp, err := http.Post(srv.URL+tt.op, "application/json",
 				bytes.NewBufferString(tt.body))
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
 			defer resp.Body.Close()
 
 			var r Response
@@ -81,8 +85,11 @@ func TestOperationsSanity(t *testing.T) {
 			srv := setupServer(&mockCalculator{err: errors.New("err")})
 			defer srv.Close()
 
-			resp, _ := http.Post(srv.URL+tt.op, "application/json",
+			resp, err := http.Post(srv.URL+tt.op, "application/json",
 				bytes.NewBufferString(tt.body))
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
 			defer resp.Body.Close()
 
 			var r ErrorResponse
@@ -101,8 +108,11 @@ func TestMissingRoutes(t *testing.T) {
 	paths := []string{"/notfound", "/v1", "/add", "/v1/notfound"}
 	for _, path := range paths {
 		t.Run("POST "+path, func(t *testing.T) {
-			resp, _ := http.Post(srv.URL+path, "application/json",
+			resp, err := http.Post(srv.URL+path, "application/json",
 				bytes.NewBufferString(`{"a": 1}`))
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
 			defer resp.Body.Close()
 			if resp.StatusCode != http.StatusNotFound {
 				t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusNotFound)
@@ -121,7 +131,10 @@ func TestInvalidMethods(t *testing.T) {
 	}
 	for _, path := range paths {
 		t.Run("GET "+path, func(t *testing.T) {
-			resp, _ := http.Get(srv.URL + path)
+			resp, err := http.Get(srv.URL + path)
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
 			defer resp.Body.Close()
 			if resp.StatusCode != http.StatusNotFound {
 				t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusNotFound)
@@ -147,6 +160,8 @@ func TestBinaryOp(t *testing.T) {
 		{"large b", &mockCalculator{result: 3.0375e-20}, `{"a": 3, "b": "98765432109876543210"}`, http.StatusOK, ptr(3.0375e-20), false},
 		{"missing a", &mockCalculator{}, `{"b": 3}`, http.StatusBadRequest, nil, true},
 		{"missing b", &mockCalculator{}, `{"a": 2}`, http.StatusBadRequest, nil, true},
+		{"null b", &mockCalculator{}, `{"a": 2, "b": null}`, http.StatusBadRequest, nil, true},
+		{"non-numeric b", &mockCalculator{}, `{"a": 2, "b": true}`, http.StatusBadRequest, nil, true},
 		{"invalid string a", &mockCalculator{}, `{"a": "foo", "b": 3}`, http.StatusBadRequest, nil, true},
 		{"invalid string b", &mockCalculator{}, `{"a": 2, "b": "bar"}`, http.StatusBadRequest, nil, true},
 		{"calculator error", &mockCalculator{err: errors.New("err")}, `{"a": 1, "b": 2}`, http.StatusBadRequest, nil, true},
@@ -158,8 +173,11 @@ func TestBinaryOp(t *testing.T) {
 			srv := setupServer(tt.mock)
 			defer srv.Close()
 
-			resp, _ := http.Post(srv.URL+"/v1/divide", "application/json",
+			resp, err := http.Post(srv.URL+"/v1/divide", "application/json",
 				bytes.NewBufferString(tt.body))
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
 			defer resp.Body.Close()
 
 			assertHTTPResponse(t, resp, tt.expectedStatus, tt.expectedResult, tt.expectError)
@@ -191,8 +209,11 @@ func TestUnaryOp(t *testing.T) {
 			srv := setupServer(tt.mock)
 			defer srv.Close()
 
-			resp, _ := http.Post(srv.URL+"/v1/sqrt", "application/json",
+			resp, err := http.Post(srv.URL+"/v1/sqrt", "application/json",
 				bytes.NewBufferString(tt.body))
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
 			defer resp.Body.Close()
 
 			assertHTTPResponse(t, resp, tt.expectedStatus, tt.expectedResult, tt.expectError)
@@ -225,3 +246,233 @@ func assertHTTPResponse(t *testing.T, r *http.Response, status int, result *floa
 		}
 	}
 }
+
+func TestListOperations(t *testing.T) {
+	srv := setupServer(&mockCalculator{})
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/v1/operations")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var list OperationList
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	var names []string
+	for _, op := range list.Operations[:7] {
+		names = append(names, op.Name)
+	}
+	want := "[add subtract multiply divide power sqrt percentage]"
+	if got := fmt.Sprint(names); got != want {
+		t.Errorf("operations = %s, want %s", got, want)
+	}
+
+	divide := list.Operations[3]
+	if divide.Label != "Division" || divide.Symbol != "/" || divide.Arity != 2 ||
+		divide.Path != "/v1/divide" {
+		t.Errorf("divide = %+v", divide)
+	}
+	if got := fmt.Sprint(divide.Operands); got != "[{a Dividend} {b Divisor}]" {
+		t.Errorf("divide operands = %s", got)
+	}
+	if got := fmt.Sprint(divide.Errors); got != "[{division_by_zero division by zero}]" {
+		t.Errorf("divide errors = %s", got)
+	}
+	if got := fmt.Sprint(divide.Schema["required"]); got != "[a b]" {
+		t.Errorf("divide schema required = %s, want [a b]", got)
+	}
+	if got := len(list.Operations[0].Errors); got != 0 {
+		t.Errorf("add errors = %d, want 0", got)
+	}
+}
+
+func TestErrorCodes(t *testing.T) {
+	srv := setupServer(&mockCalculator{err: calculator.ErrDivisionByZero})
+	defer srv.Close()
+
+	resp := post(t, srv.URL+"/v1/divide", `{"a": 1, "b": 0}`)
+	assertBody(t, resp, http.StatusBadRequest, `{"error":"division by zero","code":"division_by_zero"}`)
+	resp = post(t, srv.URL+"/v1/divide", `{"a": 1}`)
+	assertBody(t, resp, http.StatusBadRequest,
+		`{"error":"Key: 'BinaryOperand.B' Error:Field validation for 'B' failed on the 'required' tag"}`)
+}
+
+func TestMissingOperandLegacyBody(t *testing.T) {
+	srv := setupServer(&mockCalculator{})
+	defer srv.Close()
+
+	tests := []struct {
+		path, body, want string
+	}{
+		{"/v1/add", `{}`, `{"error":"Key: 'BinaryOperand.A' Error:Field validation for 'A' failed on the 'required' tag` +
+			`\nKey: 'BinaryOperand.B' Error:Field validation for 'B' failed on the 'required' tag"}`},
+		{"/v1/power", `{"a": null, "b": 2}`, `{"error":"Key: 'BinaryOperand.A' Error:Field validation for 'A' failed on the 'required' tag"}`},
+		{"/v1/sqrt", `{"b": 1}`, `{"error":"Key: 'UnaryOperand.A' Error:Field validation for 'A' failed on the 'required' tag"}`},
+		{"/v1/percentage", `{"b": "x"}`, ""},
+		{"/v1/sin", `{}`, `{"error":"missing operand: a"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			resp := post(t, srv.URL+tt.path, tt.body)
+			assertBody(t, resp, http.StatusBadRequest, tt.want)
+		})
+	}
+}
+
+func TestMalformedLegacyBody(t *testing.T) {
+	srv := setupServer(&mockCalculator{})
+	defer srv.Close()
+
+	tests := []struct {
+		path, body, want string
+	}{
+		{"/v1/add", `{"a": true, "b": 1}`, `{"error":"json: cannot unmarshal bool into Go value of type json.Number"}`},
+		{"/v1/multiply", `[1, 2]`, `{"error":"json: cannot unmarshal array into Go value of type rest.BinaryOperand"}`},
+		{"/v1/sqrt", `4`, `{"error":"json: cannot unmarshal number into Go value of type rest.UnaryOperand"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			resp := post(t, srv.URL+tt.path, tt.body)
+			assertBody(t, resp, http.StatusBadRequest, tt.want)
+		})
+	}
+}
+
+func TestCustomOperation(t *testing.T) {
+	ops := calculator.NewRegistry()
+	err := ops.Register(calculator.Operation{
+		Name:     "hypot",
+		Operands: []calculator.Operand{{Name: "x"}, {Name: "y"}},
+		Eval: func(args []float64) (float64, error) {
+			return args[0]*args[0] + args[1]*args[1], nil
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	engine := gin.New()
+	RegisterCalculatorV1(engine, ops, calculator.Rounding{})
+	srv := httptest.NewServer(engine)
+	defer srv.Close()
+
+	resp := post(t, srv.URL+"/v1/hypot", `{"x": 3, "y": "4"}`)
+	assertBody(t, resp, http.StatusOK, `{"result":25}`)
+	resp = post(t, srv.URL+"/v1/hypot", `{"x": 3}`)
+	assertBody(t, resp, http.StatusBadRequest, `{"error":"missing operand: y"}`)
+}
+
+func TestAngleUnit(t *testing.T) {
+	engine := gin.New()
+	RegisterCalculatorV1(engine, calculator.DefaultRegistry(calculator.New()), calculator.Rounding{})
+	srv := httptest.NewServer(engine)
+	defer srv.Close()
+
+	tests := []struct {
+		name       string
+		op         string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{"default radians", "/v1/cos", `{"a": 0}`, http.StatusOK, `{"result":1}`},
+		{"degrees", "/v1/sin", `{"a": 30, "angle_unit": "degrees"}`, http.StatusOK, `{"result":0.5}`},
+		{"gradians result", "/v1/asin", `{"a": 1, "angle_unit": "gradians"}`, http.StatusOK, `{"result":100}`},
+		{"invalid unit", "/v1/sin", `{"a": 30, "angle_unit": "turns"}`, http.StatusBadRequest,
+			`{"error":"invalid angle unit: \"turns\"","code":"invalid_angle_unit"}`},
+		{"non-string unit", "/v1/sin", `{"a": 30, "angle_unit": 1}`, http.StatusBadRequest, ""},
+		{"ignored by non-angle operation", "/v1/add", `{"a": 1, "b": 2, "angle_unit": "turns"}`,
+			http.StatusOK, `{"result":3}`},
+		{"domain error", "/v1/tan", `{"a": 90, "angle_unit": "degrees"}`, http.StatusBadRequest,
+			`{"error":"tangent undefined at odd multiples of a right angle","code":"tan_undefined"}`},
+		{"log base", "/v1/log", `{"a": 8, "b": 2}`, http.StatusOK, `{"result":3}`},
+		{"log invalid base", "/v1/log", `{"a": 8, "b": 1}`, http.StatusBadRequest,
+			`{"error":"logarithm base must be positive and not 1","code":"invalid_log_base"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := post(t, srv.URL+tt.op, tt.body)
+			assertBody(t, resp, tt.wantStatus, tt.wantBody)
+		})
+	}
+}
+
+func TestDistributions(t *testing.T) {
+	engine := gin.New()
+	RegisterCalculatorV1(engine, calculator.DefaultRegistry(calculator.New()), calculator.Rounding{})
+	srv := httptest.NewServer(engine)
+	defer srv.Close()
+
+	tests := []struct {
+		name       string
+		op         string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{"cdf", "/v1/uniform_cdf", `{"x": 3, "a": 2, "b": 6}`, http.StatusOK, `{"result":0.25}`},
+		{"discrete inverse", "/v1/poisson_inv", `{"p": 0.5, "lambda": 3}`, http.StatusOK, `{"result":3}`},
+		{"rounded", "/v1/normal_inv", `{"p": 0.975, "mu": 0, "sigma": 1, "rounding": {"decimals": 2}}`,
+			http.StatusOK, `{"result":1.96,"rounding":{"mode":"half_even","decimals":2}}`},
+		{"invalid parameter", "/v1/normal_pdf", `{"x": 0, "mu": 0, "sigma": -1}`, http.StatusBadRequest,
+			`{"error":"invalid distribution parameter: sigma must be positive, got -1","code":"invalid_distribution_parameter"}`},
+		{"invalid probability", "/v1/t_inv", `{"p": 1, "df": 3}`, http.StatusBadRequest,
+			`{"error":"probability outside the valid range: 1, want strictly between 0 and 1","code":"invalid_probability"}`},
+		{"missing parameter", "/v1/binomial_cdf", `{"k": 3, "n": 10}`, http.StatusBadRequest,
+			`{"error":"missing operand: prob"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := post(t, srv.URL+tt.op, tt.body)
+			assertBody(t, resp, tt.wantStatus, tt.wantBody)
+		})
+	}
+}
+
+func TestComplexMode(t *testing.T) {
+	engine := gin.New()
+	RegisterCalculatorV1(engine, calculator.DefaultRegistry(calculator.New()), calculator.Rounding{})
+	srv := httptest.NewServer(engine)
+	defer srv.Close()
+
+	tests := []struct {
+		name       string
+		op         string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{"string operands", "/v1/multiply", `{"a": "1+2i", "b": "3-i", "mode": "complex"}`, http.StatusOK,
+			`{"result":{"re":5,"im":5},"text":"5+5i","polar":{"r":7.0710678118654755,"theta":0.7853981633974483}}`},
+		{"object and number operands", "/v1/add", `{"a": {"re": 1, "im": -2}, "b": 2, "mode": "complex"}`,
+			http.StatusOK, `{"result":{"re":3,"im":-2},"text":"3-2i","polar":{"r":3.6055512754639896,"theta":-0.5880026035475675}}`},
+		{"negative sqrt", "/v1/sqrt", `{"a": -4, "mode": "complex"}`, http.StatusOK,
+			`{"result":{"re":0,"im":2},"text":"2i","polar":{"r":2,"theta":1.5707963267948966}}`},
+		{"real mode by default", "/v1/sqrt", `{"a": -4}`, http.StatusBadRequest,
+			`{"error":"sqrt negative number","code":"negative_sqrt"}`},
+		{"complex only", "/v1/rect", `{"a": 2, "b": 0}`, http.StatusBadRequest,
+			`{"error":"operation only available in complex mode","code":"complex_required"}`},
+		{"unsupported", "/v1/factorial", `{"a": 3, "mode": "complex"}`, http.StatusBadRequest,
+			`{"error":"operation not available in complex mode: factorial","code":"complex_unsupported"}`},
+		{"degrees", "/v1/sin", `{"a": 30, "angle_unit": "degrees", "mode": "complex"}`, http.StatusBadRequest,
+			`{"error":"operation not available in complex mode: angles are in radians","code":"complex_unsupported"}`},
+		{"invalid mode", "/v1/add", `{"a": 1, "b": 2, "mode": "quaternion"}`, http.StatusBadRequest,
+			`{"error":"invalid mode: \"quaternion\"","code":"invalid_mode"}`},
+		{"invalid operand", "/v1/add", `{"a": "1+", "b": 2, "mode": "complex"}`, http.StatusBadRequest,
+			`{"error":"a: invalid complex number: \"1+\"","code":"invalid_complex"}`},
+		{"missing operand", "/v1/add", `{"a": 1, "mode": "complex"}`, http.StatusBadRequest,
+			`{"error":"missing operand: b"}`},
+		{"division by zero", "/v1/divide", `{"a": "i", "b": 0, "mode": "complex"}`, http.StatusBadRequest,
+			`{"error":"division by zero","code":"division_by_zero"}`},
+		{"modulus overflow", "/v1/add", `{"a": {"re": 1.7e308, "im": 1.7e308}, "b": 0, "mode": "complex"}`,
+			http.StatusBadRequest, `{"error":"result overflows","code":"overflow"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := post(t, srv.URL+tt.op, tt.body)
+			assertBody(t, resp, tt.wantStatus, tt.wantBody)
+		})
+	}
+}
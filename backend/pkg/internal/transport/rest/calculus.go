@@ -0,0 +1,147 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/igorgatis/sezzle/backend/pkg/internal/expr"
+	"github.com/igorgatis/sezzle/backend/pkg/internal/numeric"
+)
+
+type IntegrateRequest struct {
+	Expression string `json:"expression" binding:"required" example:"exp(-x^2)"`
+	// Variable is the integration variable, x when omitted.
+	Variable string   `json:"variable" example:"x" default:"x"`
+	Lower    *float64 `json:"lower" binding:"required" example:"0"`
+	Upper    *float64 `json:"upper" binding:"required" example:"1"`
+	Method   string   `json:"method" enums:"gauss_kronrod,simpson" default:"gauss_kronrod"`
+	// Tolerance is the absolute error to reach, 1e-10 when omitted.
+	Tolerance float64 `json:"tolerance" example:"1e-10"`
+	// MaxEvaluations is 100000 when omitted, up to 1000000.
+	MaxEvaluations int    `json:"max_evaluations" example:"100000"`
+	AngleUnit      string `json:"angle_unit" enums:"radians,degrees,gradians" default:"radians"`
+}
+
+type IntegrateResponse struct {
+	Result        float64 `json:"result" example:"0.746824132812427"`
+	ErrorEstimate float64 `json:"error_estimate" example:"7.887024366937112e-13"`
+	Method        string  `json:"method" example:"gauss_kronrod"`
+	Evaluations   int     `json:"evaluations" example:"15"`
+	// Intervals is the number of subintervals the range was split into.
+	Intervals int `json:"intervals" example:"1"`
+}
+
+type DerivativeRequest struct {
+	Expression string `json:"expression" binding:"required" example:"sin(x)"`
+	// Variable is the differentiation variable, x when omitted.
+	Variable string   `json:"variable" example:"x" default:"x"`
+	At       *float64 `json:"at" binding:"required" example:"1"`
+	// Order is 1 for the first derivative or 2 for the second.
+	Order  int    `json:"order" example:"1" default:"1"`
+	Method string `json:"method" enums:"richardson,central" default:"richardson"`
+	// Step is the difference step, chosen from at when omitted.
+	Step      float64 `json:"step" example:"0.1"`
+	AngleUnit string  `json:"angle_unit" enums:"radians,degrees,gradians" default:"radians"`
+}
+
+type DerivativeResponse struct {
+	Result        float64 `json:"result" example:"0.5403023058681412"`
+	ErrorEstimate float64 `json:"error_estimate" example:"2.3314683517128287e-15"`
+	Method        string  `json:"method" example:"richardson"`
+	Order         int     `json:"order" example:"1"`
+	Evaluations   int     `json:"evaluations" example:"13"`
+}
+
+// RegisterCalculusV1 serves numeric integration and differentiation of
+// expressions, which may call the tenant's functions.
+func RegisterCalculusV1(r gin.IRouter, registry *expr.Registry) {
+	g := r.Group("/v1/calculus")
+	g.POST("/integrate", integrateHandler(registry))
+	g.POST("/derivative", derivativeHandler(registry))
+}
+
+// @Summary Definite integral of an expression
+// @Description Adaptive Gauss-Kronrod (7-15 points, bisecting the subinterval of largest error) or adaptive Simpson. Gauss-Kronrod does not evaluate the bounds, so it handles integrable singularities there. Integrands that cannot be evaluated fail with singular_integrand; integrals whose error estimate does not reach the tolerance fail with divergent_integral, and with timeout after 2 seconds.
+// @Param X-Tenant-ID header string false "Tenant scope of user-defined functions" default(default)
+// @Param input body IntegrateRequest true "Expression, bounds and limits"
+// @Success 200 {object} IntegrateResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /v1/calculus/integrate [post]
+func integrateHandler(registry *expr.Registry) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var input IntegrateRequest
+		if err := c.ShouldBindJSON(&input); err != nil {
+			writeErrorResponse(c, err)
+			return
+		}
+		q := numeric.Quadrature{
+			Method:         numeric.Method(input.Method),
+			Tolerance:      input.Tolerance,
+			MaxEvaluations: input.MaxEvaluations,
+		}
+		if q.Method == "" {
+			q.Method = numeric.GaussKronrod
+		}
+		f, err := compileFunc(c, registry, input.Expression, input.Variable, input.AngleUnit)
+		if err != nil {
+			writeErrorResponse(c, err)
+			return
+		}
+		ctx, cancel := context.WithTimeout(c.Request.Context(), numericTimeout)
+		defer cancel()
+		r, err := numeric.Integrate(ctx, f, *input.Lower, *input.Upper, q)
+		if err != nil {
+			writeErrorResponse(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, IntegrateResponse{
+			Result:        r.Value,
+			ErrorEstimate: r.Error,
+			Method:        string(q.Method),
+			Evaluations:   r.Evaluations,
+			Intervals:     r.Intervals,
+		})
+	}
+}
+
+// @Summary Derivative of an expression at a point
+// @Description First or second derivative by central differences, or by Ridders' Richardson extrapolation of central differences with shrinking steps. Points where the expression or its neighborhood cannot be evaluated fail with that evaluation error.
+// @Param X-Tenant-ID header string false "Tenant scope of user-defined functions" default(default)
+// @Param input body DerivativeRequest true "Expression and point"
+// @Success 200 {object} DerivativeResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /v1/calculus/derivative [post]
+func derivativeHandler(registry *expr.Registry) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var input DerivativeRequest
+		if err := c.ShouldBindJSON(&input); err != nil {
+			writeErrorResponse(c, err)
+			return
+		}
+		method := numeric.Method(input.Method)
+		if method == "" {
+			method = numeric.Richardson
+		}
+		if input.Order == 0 {
+			input.Order = 1
+		}
+		f, err := compileFunc(c, registry, input.Expression, input.Variable, input.AngleUnit)
+		if err != nil {
+			writeErrorResponse(c, err)
+			return
+		}
+		d, err := numeric.Differentiate(f, *input.At, input.Order, method, input.Step)
+		if err != nil {
+			writeErrorResponse(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, DerivativeResponse{
+			Result:        d.Value,
+			ErrorEstimate: d.Error,
+			Method:        string(method),
+			Order:         input.Order,
+			Evaluations:   d.Evaluations,
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package rest
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestCalculus(t *testing.T) {
+	srv := setupExprServer()
+	defer srv.Close()
+	tests := []struct {
+		name       string
+		path       string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{"integrate", "/integrate", `{"expression": "exp(-x^2)", "lower": 0, "upper": 1}`, http.StatusOK,
+			`{"result":0.746824132812427,"error_estimate":7.887024366937112e-13,"method":"gauss_kronrod","evaluations":15,"intervals":1}`},
+		{"integrate simpson", "/integrate", `{"expression": "3*t^2", "variable": "t", "lower": 0, "upper": 2, "method": "simpson"}`,
+			http.StatusOK,
+			`{"result":8,"error_estimate":0,"method":"simpson","evaluations":5,"intervals":2}`},
+		{"integrate degrees", "/integrate", `{"expression": "sin(x)", "lower": 0, "upper": 180, "angle_unit": "degrees"}`,
+			http.StatusOK,
+			`{"result":114.59155902616465,"error_estimate":9.992007221626409e-15,"method":"gauss_kronrod","evaluations":45,"intervals":2}`},
+		{"integrate endpoint singularity", "/integrate", `{"expression": "1/sqrt(x)", "lower": 0, "upper": 1}`,
+			http.StatusOK,
+			`{"result":1.9999999999574587,"error_estimate":7.41768873011315e-11,"method":"gauss_kronrod","evaluations":1815,"intervals":61}`},
+		{"integrate pole", "/integrate", `{"expression": "1/x", "lower": -1, "upper": 1, "method": "simpson"}`,
+			http.StatusBadRequest,
+			`{"error":"integrand is not finite or undefined: f(0): division by zero","code":"singular_integrand"}`},
+		{"integrate simpson overflow", "/integrate", `{"expression": "1", "lower": -1.7e308, "upper": 1.7e308, "method": "simpson"}`,
+			http.StatusBadRequest, `{"error":"integral did not converge: sum overflows near 0","code":"divergent_integral"}`},
+		{"integrate divergent", "/integrate", `{"expression": "1/x", "lower": 0, "upper": 1}`, http.StatusBadRequest,
+			`{"error":"integral did not converge: error estimate 1.8460866863965717 is not decreasing near 9.5367431640625e-07","code":"divergent_integral"}`},
+		{"integrate evaluation limit", "/integrate", `{"expression": "sin(x)", "lower": 0, "upper": 100, "max_evaluations": 30}`,
+			http.StatusBadRequest,
+			`{"error":"integral did not converge: error estimate 7.537685576706586 when the evaluation limit of 30 was reached","code":"divergent_integral"}`},
+		{"integrate missing bound", "/integrate", `{"expression": "x", "lower": 0}`, http.StatusBadRequest, ""},
+		{"integrate unknown method", "/integrate", `{"expression": "x", "lower": 0, "upper": 1, "method": "trapezoid"}`,
+			http.StatusBadRequest,
+			`{"error":"invalid method: \"trapezoid\"","code":"invalid_method"}`},
+		{"derivative", "/derivative", `{"expression": "sin(x)", "at": 1}`, http.StatusOK,
+			`{"result":0.5403023058681412,"error_estimate":2.3314683517128287e-15,"method":"richardson","order":1,"evaluations":13}`},
+		{"derivative central", "/derivative", `{"expression": "x^3", "at": 2, "method": "central"}`, http.StatusOK,
+			`{"result":12.000000000146674,"error_estimate":7.27280299130888e-10,"method":"central","order":1,"evaluations":5}`},
+		{"second derivative", "/derivative", `{"expression": "exp(x)", "at": 0, "order": 2}`, http.StatusOK,
+			`{"result":1.0000000000005318,"error_estimate":2.7822188997106423e-13,"method":"richardson","order":2,"evaluations":11}`},
+		{"derivative domain", "/derivative", `{"expression": "ln(x)", "at": 0}`, http.StatusBadRequest,
+			`{"error":"f(0): logarithm of non-positive number","code":"log_domain"}`},
+		{"derivative order", "/derivative", `{"expression": "x", "at": 0, "order": 3}`, http.StatusBadRequest,
+			`{"error":"invalid derivative order: 3, want 1 to 2","code":"invalid_order"}`},
+		{"derivative missing point", "/derivative", `{"expression": "x"}`, http.StatusBadRequest, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := post(t, srv.URL+"/v1/calculus"+tt.path, tt.body)
+			assertBody(t, resp, tt.wantStatus, tt.wantBody)
+		})
+	}
+}
@@ -0,0 +1,217 @@
+package rest
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/igorgatis/sezzle/backend/pkg/internal/calculator"
+	"github.com/igorgatis/sezzle/backend/pkg/internal/expr"
+	"github.com/igorgatis/sezzle/backend/pkg/internal/storage"
+)
+
+type EvaluateRequest struct {
+	Expression string                 `json:"expression" binding:"required" example:"x^2 + 1"`
+	Variables  map[string]json.Number `json:"variables" swaggertype:"object,number"`
+	AngleUnit  string                 `json:"angle_unit" enums:"radians,degrees,gradians" default:"radians"`
+	Rounding   *RoundingOptions       `json:"rounding"`
+	Format     *FormatOptions         `json:"format"`
+}
+
+type CompileRequest struct {
+	Expression string   `json:"expression" binding:"required" example:"a*x^2 + b"`
+	Variables  []string `json:"variables" example:"a,x,b"`
+	AngleUnit  string   `json:"angle_unit" enums:"radians,degrees,gradians" default:"radians"`
+}
+
+type CompileResponse struct {
+	ID        string   `json:"id" example:"5ZQ7WJTKBCXH3FQXKDPNPRY2XA"`
+	Variables []string `json:"variables" example:"a,x,b"`
+}
+
+type EvaluateBatchRequest struct {
+	Bindings []map[string]json.Number `json:"bindings" binding:"required"`
+	Rounding *RoundingOptions         `json:"rounding"`
+}
+
+type EvaluateBatchResponse struct {
+	Results  []json.Number    `json:"results" swaggertype:"array,number"`
+	Rounding *RoundingOptions `json:"rounding,omitempty"`
+}
+
+// RegisterExpressionsV1 serves expression evaluation. Results are rounded as
+// rounding unless requests ask otherwise. Compiled expressions are kept in
+// programs, which should bound their number.
+func RegisterExpressionsV1(r gin.IRouter, registry *expr.Registry, programs storage.Store[*expr.Program], rounding calculator.Rounding) {
+	g := r.Group("/v1")
+	g.POST("/evaluate", evaluateHandler(registry, rounding))
+	g.POST("/expressions", compileHandler(registry, programs))
+	g.POST("/expressions/:id/evaluate", evaluateBatchHandler(programs, rounding))
+	g.DELETE("/expressions/:id", deleteExpressionHandler(programs))
+}
+
+// @Summary Evaluate an expression once
+// @Param X-Tenant-ID header string false "Tenant scope of user-defined functions" default(default)
+// @Param input body EvaluateRequest true "Expression and variable values"
+// @Success 200 {object} Response
+// @Failure 400 {object} ErrorResponse
+// @Router /v1/evaluate [post]
+func evaluateHandler(registry *expr.Registry, defaultRounding calculator.Rounding) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var input EvaluateRequest
+		if err := c.ShouldBindJSON(&input); err != nil {
+			writeErrorResponse(c, err)
+			return
+		}
+		rounding, err := resolveRounding(defaultRounding, input.Rounding)
+		if err != nil {
+			writeErrorResponse(c, err)
+			return
+		}
+		env, err := tenantEnv(c, registry, input.AngleUnit)
+		if err != nil {
+			writeErrorResponse(c, err)
+			return
+		}
+		bindings, err := parseBindings(input.Variables)
+		if err != nil {
+			writeErrorResponse(c, err)
+			return
+		}
+		result, err := env.Evaluate(input.Expression, bindings)
+		if err != nil {
+			writeErrorResponse(c, err)
+			return
+		}
+		writeResponse(c, result, rounding, input.Format)
+	}
+}
+
+// @Summary Compile an expression for repeated evaluation
+// @Param X-Tenant-ID header string false "Tenant scope of user-defined functions" default(default)
+// @Param input body CompileRequest true "Expression and declared variables"
+// @Success 201 {object} CompileResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /v1/expressions [post]
+func compileHandler(registry *expr.Registry, programs storage.Store[*expr.Program]) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var input CompileRequest
+		if err := c.ShouldBindJSON(&input); err != nil {
+			writeErrorResponse(c, err)
+			return
+		}
+		env, err := tenantEnv(c, registry, input.AngleUnit)
+		if err != nil {
+			writeErrorResponse(c, err)
+			return
+		}
+		prog, err := env.Compile(input.Expression, input.Variables)
+		if err != nil {
+			writeErrorResponse(c, err)
+			return
+		}
+		id := rand.Text()
+		if err := programs.Put(id, prog); err != nil {
+			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+			return
+		}
+		c.JSON(http.StatusCreated, CompileResponse{ID: id, Variables: prog.Vars()})
+	}
+}
+
+// @Summary Evaluate a compiled expression against many binding sets
+// @Param id path string true "Compiled expression ID"
+// @Param input body EvaluateBatchRequest true "Variable bindings, one set per evaluation"
+// @Success 200 {object} EvaluateBatchResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /v1/expressions/{id}/evaluate [post]
+func evaluateBatchHandler(programs storage.Store[*expr.Program], defaultRounding calculator.Rounding) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		prog, err := programs.Get(c.Param("id"))
+		if errors.Is(err, storage.ErrNotFound) {
+			c.JSON(http.StatusNotFound, ErrorResponse{Error: "expression not found"})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+			return
+		}
+		var input EvaluateBatchRequest
+		if err := c.ShouldBindJSON(&input); err != nil {
+			writeErrorResponse(c, err)
+			return
+		}
+		rounding, err := resolveRounding(defaultRounding, input.Rounding)
+		if err != nil {
+			writeErrorResponse(c, err)
+			return
+		}
+		bindings := make([]map[string]float64, len(input.Bindings))
+		for i, b := range input.Bindings {
+			if bindings[i], err = parseBindings(b); err != nil {
+				writeErrorResponse(c, fmt.Errorf("bindings[%d]: %w", i, err))
+				return
+			}
+		}
+		results, err := prog.EvalBatch(bindings)
+		if err != nil {
+			writeErrorResponse(c, err)
+			return
+		}
+		out := EvaluateBatchResponse{Results: make([]json.Number, len(results)), Rounding: roundingInfo(rounding)}
+		for i, r := range results {
+			r = rounding.Round(r)
+			if math.IsInf(r, 0) || math.IsNaN(r) {
+				writeErrorResponse(c, fmt.Errorf("bindings[%d]: %w", i, calculator.ErrOverflow))
+				return
+			}
+			out.Results[i] = formatNumber(r)
+		}
+		c.JSON(http.StatusOK, out)
+	}
+}
+
+// @Summary Delete a compiled expression
+// @Param id path string true "Compiled expression ID"
+// @Success 204
+// @Failure 404 {object} ErrorResponse
+// @Router /v1/expressions/{id} [delete]
+func deleteExpressionHandler(programs storage.Store[*expr.Program]) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		err := programs.Delete(c.Param("id"))
+		if errors.Is(err, storage.ErrNotFound) {
+			c.JSON(http.StatusNotFound, ErrorResponse{Error: "expression not found"})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
+
+func tenantEnv(c *gin.Context, registry *expr.Registry, angleUnit string) (*expr.Env, error) {
+	unit, err := calculator.ParseAngleUnit(angleUnit)
+	if err != nil {
+		return nil, err
+	}
+	return registry.Env(tenant(c), unit)
+}
+
+func parseBindings(in map[string]json.Number) (map[string]float64, error) {
+	out := make(map[string]float64, len(in))
+	for name, n := range in {
+		v, err := n.Float64()
+		if err != nil {
+			return nil, fmt.Errorf("variable %s: %w", name, err)
+		}
+		out[name] = v
+	}
+	return out, nil
+}
@@ -0,0 +1,171 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/igorgatis/sezzle/backend/pkg/internal/calculator"
+	"github.com/igorgatis/sezzle/backend/pkg/internal/expr"
+	"github.com/igorgatis/sezzle/backend/pkg/internal/storage"
+)
+
+func setupExprServer() *httptest.Server {
+	engine := gin.New()
+	registry := expr.NewRegistry(expr.NewEnv(calculator.DefaultRegistry(calculator.New())),
+		storage.NewMemory[expr.Definition]())
+	RegisterExpressionsV1(engine, registry, storage.NewMemory[*expr.Program](), calculator.Rounding{})
+	RegisterFunctionsV1(engine, registry)
+	RegisterSolveV1(engine, registry)
+	RegisterCalculusV1(engine, registry)
+	RegisterSymbolicV1(engine, registry)
+	return httptest.NewServer(engine)
+}
+
+func post(t *testing.T, url, body string) *http.Response {
+	t.Helper()
+	resp, err := http.Post(url, "application/json", bytes.NewBufferString(body))
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+func TestEvaluate(t *testing.T) {
+	srv := setupExprServer()
+	defer srv.Close()
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{"happy path", `{"expression": "x^2 + y", "variables": {"x": 3, "y": "1"}}`,
+			http.StatusOK, `{"result":10}`},
+		{"no variables", `{"expression": "2 * (3 + 4)"}`, http.StatusOK, `{"result":14}`},
+		{"missing expression", `{}`, http.StatusBadRequest, ""},
+		{"syntax error", `{"expression": "1 +"}`, http.StatusBadRequest,
+			`{"error":"syntax error: unexpected end of expression"}`},
+		{"unbound variable", `{"expression": "x"}`, http.StatusBadRequest,
+			`{"error":"unknown variable: x"}`},
+		{"invalid variable value", `{"expression": "x", "variables": {"x": "foo"}}`,
+			http.StatusBadRequest, ""},
+		{"angle unit", `{"expression": "sin(x)", "variables": {"x": 90}, "angle_unit": "degrees"}`,
+			http.StatusOK, `{"result":1}`},
+		{"invalid angle unit", `{"expression": "sin(1)", "angle_unit": "turns"}`,
+			http.StatusBadRequest, `{"error":"invalid angle unit: \"turns\"","code":"invalid_angle_unit"}`},
+		{"division by zero", `{"expression": "1/0"}`, http.StatusBadRequest,
+			`{"error":"division by zero","code":"division_by_zero"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := post(t, srv.URL+"/v1/evaluate", tt.body)
+			assertBody(t, resp, tt.wantStatus, tt.wantBody)
+		})
+	}
+}
+
+func TestCompiledExpressions(t *testing.T) {
+	srv := setupExprServer()
+	defer srv.Close()
+
+	resp := post(t, srv.URL+"/v1/expressions",
+		`{"expression": "a*x + b", "variables": ["a", "x", "b"]}`)
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusCreated)
+	}
+	var compiled CompileResponse
+	if err := json.NewDecoder(resp.Body).Decode(&compiled); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if compiled.ID == "" || len(compiled.Variables) != 3 {
+		t.Fatalf("unexpected response: %+v", compiled)
+	}
+	url := srv.URL + "/v1/expressions/" + compiled.ID + "/evaluate"
+
+	tests := []struct {
+		name       string
+		url        string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{"happy path", url, `{"bindings": [{"a": 2, "x": 3, "b": 1}, {"a": 0.5, "x": 4, "b": "-1"}]}`,
+			http.StatusOK, `{"results":[7,1]}`},
+		{"empty bindings", url, `{"bindings": []}`, http.StatusOK, `{"results":[]}`},
+		{"rounding", url, `{"bindings": [{"a": 2, "x": 0.125, "b": 0}, {"a": 1, "x": 0.35, "b": 0}],
+			"rounding": {"mode": "half_up", "decimals": 1}}`,
+			http.StatusOK, `{"results":[0.3,0.4],"rounding":{"mode":"half_up","decimals":1}}`},
+		{"rounding overflow", url, `{"bindings": [{"a": 1, "x": 1, "b": 0}, {"a": 1, "x": 1.7e308, "b": 0}],
+			"rounding": {"mode": "up", "significant": 1}}`,
+			http.StatusBadRequest, `{"error":"bindings[1]: result overflows","code":"overflow"}`},
+		{"missing variable", url, `{"bindings": [{"a": 1, "x": 1, "b": 1}, {"a": 1}]}`,
+			http.StatusBadRequest, `{"error":"bindings[1]: missing variable: x"}`},
+		{"invalid value", url, `{"bindings": [{"a": "foo"}]}`, http.StatusBadRequest, ""},
+		{"missing bindings", url, `{}`, http.StatusBadRequest, ""},
+		{"unknown id", srv.URL + "/v1/expressions/nope/evaluate", `{"bindings": []}`,
+			http.StatusNotFound, `{"error":"expression not found"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := post(t, tt.url, tt.body)
+			assertBody(t, resp, tt.wantStatus, tt.wantBody)
+		})
+	}
+
+	resp = do(t, http.MethodDelete, srv.URL+"/v1/expressions/"+compiled.ID, "", "")
+	assertBody(t, resp, http.StatusNoContent, "")
+	resp = post(t, url, `{"bindings": []}`)
+	assertBody(t, resp, http.StatusNotFound, `{"error":"expression not found"}`)
+	resp = do(t, http.MethodDelete, srv.URL+"/v1/expressions/"+compiled.ID, "", "")
+	assertBody(t, resp, http.StatusNotFound, `{"error":"expression not found"}`)
+}
+
+func TestCompileErrors(t *testing.T) {
+	srv := setupExprServer()
+	defer srv.Close()
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"missing expression", `{"variables": ["x"]}`},
+		{"syntax error", `{"expression": "x +"}`},
+		{"undeclared variable", `{"expression": "x + y", "variables": ["x"]}`},
+		{"invalid variable", `{"expression": "1", "variables": ["1x"]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := post(t, srv.URL+"/v1/expressions", tt.body)
+			assertBody(t, resp, http.StatusBadRequest, "")
+		})
+	}
+}
+
+// assertBody checks the status and, when want is not empty, the exact body.
+// Error responses are always expected to carry a message.
+func assertBody(t *testing.T, r *http.Response, status int, want string) {
+	t.Helper()
+
+	if r.StatusCode != status {
+		t.Errorf("status = %d, want %d", r.StatusCode, status)
+	}
+	var body bytes.Buffer
+	if _, err := body.ReadFrom(r.Body); err != nil {
+		t.Fatalf("failed to read body: %v", err)
+	}
+	if want != "" && body.String() != want {
+		t.Errorf("body = %s, want %s", body.String(), want)
+	}
+	if status >= http.StatusBadRequest {
+		var resp ErrorResponse
+		if err := json.Unmarshal(body.Bytes(), &resp); err != nil || resp.Error == "" {
+			t.Errorf("expected error response, got %s", body.String())
+		}
+	}
+}
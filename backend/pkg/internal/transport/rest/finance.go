@@ -0,0 +1,131 @@
+package rest
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/igorgatis/sezzle/backend/pkg/internal/calculator"
+	"github.com/igorgatis/sezzle/backend/pkg/internal/money"
+)
+
+// CashFlowsRequest holds cash flows, the first of which happens now and the
+// others at the end of each following period, or on Dates when given.
+type CashFlowsRequest struct {
+	CashFlows []float64 `json:"cash_flows" binding:"required" example:"-1000,300,400,500"`
+	// Dates are YYYY-MM-DD, one per cash flow. Flows are then discounted by
+	// the years of 365 days since the earliest date.
+	Dates    []string         `json:"dates" example:"2026-01-01,2026-07-01,2027-01-01,2027-07-01"`
+	Rounding *RoundingOptions `json:"rounding"`
+	Format   *FormatOptions   `json:"format"`
+}
+
+type NPVRequest struct {
+	// Rate is the discount rate per period, or per year with dates.
+	Rate *float64 `json:"rate" binding:"required" example:"0.1"`
+	CashFlowsRequest
+}
+
+type IRRRequest struct {
+	// Guess picks the closest rate when several zero the NPV.
+	Guess *float64 `json:"guess" example:"0.1" default:"0.1"`
+	CashFlowsRequest
+}
+
+// RegisterFinanceV1 serves the cash flow operations, which take a variable
+// number of operands. Fixed operand ones such as fv and pv are served with
+// the other operations.
+func RegisterFinanceV1(r gin.IRouter, rounding calculator.Rounding) {
+	g := r.Group("/v1/finance")
+	g.POST("/npv", npvHandler(rounding))
+	g.POST("/irr", irrHandler(rounding))
+}
+
+// @Summary Net present value of cash flows
+// @Description With dates this is XNPV.
+// @Param input body NPVRequest true "Rate and cash flows"
+// @Success 200 {object} Response
+// @Failure 400 {object} ErrorResponse
+// @Router /v1/finance/npv [post]
+func npvHandler(defaultRounding calculator.Rounding) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req NPVRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			writeErrorResponse(c, err)
+			return
+		}
+		dates, rounding, err := parseCashFlows(&req.CashFlowsRequest, defaultRounding)
+		if err != nil {
+			writeErrorResponse(c, err)
+			return
+		}
+		var v float64
+		if dates != nil {
+			v, err = calculator.XNPV(*req.Rate, req.CashFlows, dates)
+		} else {
+			v, err = calculator.NPV(*req.Rate, req.CashFlows)
+		}
+		if err != nil {
+			writeErrorResponse(c, err)
+			return
+		}
+		writeResponse(c, v, rounding, req.Format)
+	}
+}
+
+// @Summary Internal rate of return of cash flows
+// @Description With dates this is XIRR. Fails with no_convergence when no rate zeroes the NPV.
+// @Param input body IRRRequest true "Cash flows"
+// @Success 200 {object} Response
+// @Failure 400 {object} ErrorResponse
+// @Router /v1/finance/irr [post]
+func irrHandler(defaultRounding calculator.Rounding) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req IRRRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			writeErrorResponse(c, err)
+			return
+		}
+		dates, rounding, err := parseCashFlows(&req.CashFlowsRequest, defaultRounding)
+		if err != nil {
+			writeErrorResponse(c, err)
+			return
+		}
+		guess := 0.1
+		if req.Guess != nil {
+			guess = *req.Guess
+		}
+		var v float64
+		if dates != nil {
+			v, err = calculator.XIRR(req.CashFlows, dates, guess)
+		} else {
+			v, err = calculator.IRR(req.CashFlows, guess)
+		}
+		if err != nil {
+			writeErrorResponse(c, err)
+			return
+		}
+		writeResponse(c, v, rounding, req.Format)
+	}
+}
+
+func parseCashFlows(req *CashFlowsRequest, def calculator.Rounding) ([]time.Time, calculator.Rounding, error) {
+	rounding, err := resolveRounding(def, req.Rounding)
+	if err != nil {
+		return nil, rounding, err
+	}
+	if req.Dates == nil {
+		return nil, rounding, nil
+	}
+	if len(req.Dates) != len(req.CashFlows) {
+		return nil, rounding, fmt.Errorf("%w: %d dates for %d cash flows",
+			calculator.ErrInvalidDates, len(req.Dates), len(req.CashFlows))
+	}
+	dates := make([]time.Time, len(req.Dates))
+	for i, s := range req.Dates {
+		if dates[i], err = time.Parse(money.DateLayout, s); err != nil {
+			return nil, rounding, fmt.Errorf("dates[%d]: %w: %q", i, errInvalidDate, s)
+		}
+	}
+	return dates, rounding, nil
+}
@@ -0,0 +1,55 @@
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/igorgatis/sezzle/backend/pkg/internal/calculator"
+)
+
+func TestFinance(t *testing.T) {
+	engine := gin.New()
+	RegisterFinanceV1(engine, calculator.Rounding{})
+	srv := httptest.NewServer(engine)
+	defer srv.Close()
+
+	tests := []struct {
+		name       string
+		path       string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{"npv", "/v1/finance/npv", `{"rate": 1, "cash_flows": [-100, 200, 400]}`, http.StatusOK, `{"result":100}`},
+		{"xnpv", "/v1/finance/npv", `{"rate": 1, "cash_flows": [-100, 200], "dates": ["2027-01-01", "2026-01-01"]}`,
+			http.StatusOK, `{"result":150}`},
+		{"npv invalid rate", "/v1/finance/npv", `{"rate": -1, "cash_flows": [-100, 200]}`, http.StatusBadRequest,
+			`{"error":"rate must be greater than -1","code":"invalid_rate"}`},
+		{"xnpv no flows", "/v1/finance/npv", `{"rate": 0.1, "cash_flows": [], "dates": []}`, http.StatusBadRequest,
+			`{"error":"not enough cash flows: at least one is required","code":"invalid_cash_flows"}`},
+		{"npv missing rate", "/v1/finance/npv", `{"cash_flows": [-100, 200]}`, http.StatusBadRequest, ""},
+		{"irr", "/v1/finance/irr", `{"cash_flows": [-1000, 300, 400, 500], "rounding": {"decimals": 6}}`, http.StatusOK,
+			`{"result":0.088963,"rounding":{"mode":"half_even","decimals":6}}`},
+		{"irr guess", "/v1/finance/irr", `{"cash_flows": [-100, 230, -132], "guess": 0.25, "rounding": {"decimals": 6}}`,
+			http.StatusOK, `{"result":0.2,"rounding":{"mode":"half_even","decimals":6}}`},
+		{"xirr", "/v1/finance/irr", `{"cash_flows": [-10000, 2750, 4250, 3250, 2750],
+			"dates": ["2008-01-01", "2008-03-01", "2008-10-30", "2009-02-15", "2009-04-01"], "rounding": {"decimals": 4}}`,
+			http.StatusOK, `{"result":0.3734,"rounding":{"mode":"half_even","decimals":4}}`},
+		{"irr no convergence", "/v1/finance/irr", `{"cash_flows": [-100, 50, -50, 0.000000001]}`, http.StatusBadRequest,
+			`{"error":"root finding did not converge: no rate between -0.999 and 1e+06 zeroes the NPV","code":"no_convergence"}`},
+		{"irr no sign change", "/v1/finance/irr", `{"cash_flows": [100, 200]}`, http.StatusBadRequest,
+			`{"error":"cash flows must include both positive and negative values","code":"cash_flow_signs"}`},
+		{"dates mismatch", "/v1/finance/irr", `{"cash_flows": [-100, 200], "dates": ["2026-01-01"]}`, http.StatusBadRequest,
+			`{"error":"there must be one date per cash flow: 1 dates for 2 cash flows","code":"invalid_dates"}`},
+		{"invalid date", "/v1/finance/irr", `{"cash_flows": [-100, 200], "dates": ["2026-01-01", "soon"]}`,
+			http.StatusBadRequest, `{"error":"dates[1]: invalid date, want YYYY-MM-DD: \"soon\"","code":"invalid_date"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := post(t, srv.URL+tt.path, tt.body)
+			assertBody(t, resp, tt.wantStatus, tt.wantBody)
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package rest
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/igorgatis/sezzle/backend/pkg/internal/calculator"
+	"github.com/igorgatis/sezzle/backend/pkg/internal/numfmt"
+)
+
+// FormatField is the optional request field asking for formatted variants
+// of the result.
+const FormatField = "format"
+
+// FormatOptions selects the formatted variants of a result. Scientific and
+// engineering notation are always included.
+type FormatOptions struct {
+	// Base is between 2 and 36.
+	Base     int  `json:"base,omitempty" example:"16"`
+	Decimals *int `json:"decimals,omitempty" example:"2"`
+	// Significant is a number of significant figures.
+	Significant int    `json:"significant,omitempty" example:"3"`
+	Locale      string `json:"locale,omitempty" example:"de-DE"`
+}
+
+// Formatted holds formatted variants of a result. Localized is the fixed,
+// significant or canonical variant, in that order of preference, in the
+// requested locale.
+type Formatted struct {
+	Scientific  string `json:"scientific" example:"1.23456789e6"`
+	Engineering string `json:"engineering" example:"1.23456789e6"`
+	Base        string `json:"base,omitempty" example:"12d687.e3d70a3d"`
+	Fixed       string `json:"fixed,omitempty" example:"1234567.89"`
+	Significant string `json:"significant,omitempty" example:"1230000"`
+	Localized   string `json:"localized,omitempty" example:"1.234.567,89"`
+}
+
+type FormatRequest struct {
+	Value json.Number `json:"value" binding:"required" swaggertype:"number" example:"1234567.89"`
+	FormatOptions
+}
+
+func RegisterFormatV1(r gin.IRouter) {
+	r.POST("/v1/format", formatHandler)
+}
+
+// @Summary Format a number in another base or notation
+// @Param input body FormatRequest true "Number and formatting options"
+// @Success 200 {object} Response
+// @Failure 400 {object} ErrorResponse
+// @Router /v1/format [post]
+func formatHandler(c *gin.Context) {
+	var input FormatRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	v, err := input.Value.Float64()
+	if err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	writeResponse(c, v, calculator.Rounding{}, &input.FormatOptions)
+}
+
+func formatResult(v float64, opts *FormatOptions) (*Formatted, error) {
+	f := &Formatted{
+		Scientific:  numfmt.Scientific(v),
+		Engineering: numfmt.Engineering(v),
+	}
+	var err error
+	if opts.Base != 0 {
+		if f.Base, err = numfmt.Base(v, opts.Base); err != nil {
+			return nil, err
+		}
+	}
+	if opts.Decimals != nil {
+		if f.Fixed, err = numfmt.Fixed(v, *opts.Decimals); err != nil {
+			return nil, err
+		}
+	}
+	if opts.Significant != 0 {
+		if f.Significant, err = numfmt.Significant(v, opts.Significant); err != nil {
+			return nil, err
+		}
+	}
+	if opts.Locale != "" {
+		l, err := numfmt.ParseLocale(opts.Locale)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case f.Fixed != "":
+			f.Localized = l.Format(f.Fixed)
+		case f.Significant != "":
+			f.Localized = l.Format(f.Significant)
+		default:
+			f.Localized = l.Format(strconv.FormatFloat(v, 'f', -1, 64))
+		}
+	}
+	return f, nil
+}
+
+// parseLocale parses an optional locale tag.
+func parseLocale(tag string) (*numfmt.Locale, error) {
+	if tag == "" {
+		return nil, nil
+	}
+	l, err := numfmt.ParseLocale(tag)
+	if err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+// writeResponse rounds result and adds the formatted variants requested.
+// Results that are not finite, which JSON cannot represent, fail with
+// calculator.ErrOverflow.
+func writeResponse(c *gin.Context, result float64, rounding calculator.Rounding, format *FormatOptions) {
+	result = rounding.Round(result)
+	if math.IsInf(result, 0) || math.IsNaN(result) {
+		writeErrorResponse(c, calculator.ErrOverflow)
+		return
+	}
+	resp := Response{Result: formatNumber(result), Rounding: roundingInfo(rounding)}
+	if format != nil {
+		var err error
+		if resp.Formatted, err = formatResult(result, format); err != nil {
+			writeErrorResponse(c, err)
+			return
+		}
+	}
+	c.JSON(http.StatusOK, resp)
+}
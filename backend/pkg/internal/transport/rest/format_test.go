@@ -0,0 +1,98 @@
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/igorgatis/sezzle/backend/pkg/internal/calculator"
+)
+
+func TestFormat(t *testing.T) {
+	engine := gin.New()
+	RegisterFormatV1(engine)
+	RegisterCalculatorV1(engine, calculator.DefaultRegistry(calculator.New()), calculator.Rounding{})
+	srv := httptest.NewServer(engine)
+	defer srv.Close()
+
+	tests := []struct {
+		name       string
+		url        string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{"notations only", "/v1/format", `{"value": 0.0125}`, http.StatusOK,
+			`{"result":0.0125,"formatted":{"scientific":"1.25e-2","engineering":"12.5e-3"}}`},
+		{"all variants", "/v1/format",
+			`{"value": 1234567.89, "base": 16, "decimals": 1, "significant": 3, "locale": "de-DE"}`, http.StatusOK,
+			`{"result":1234567.89,"formatted":{"scientific":"1.23456789e6","engineering":"1.23456789e6",` +
+				`"base":"12d687.e3d70a3d","fixed":"1234567.9","significant":"1230000","localized":"1.234.567,9"}}`},
+		{"localized canonical", "/v1/format", `{"value": "-1234.5", "locale": "en-IN"}`, http.StatusOK,
+			`{"result":-1234.5,"formatted":{"scientific":"-1.2345e3","engineering":"-1.2345e3","localized":"-1,234.5"}}`},
+		{"invalid base", "/v1/format", `{"value": 1, "base": 37}`, http.StatusBadRequest,
+			`{"error":"base must be between 2 and 36","code":"invalid_base"}`},
+		{"invalid locale", "/v1/format", `{"value": 1, "locale": "tlh"}`, http.StatusBadRequest,
+			`{"error":"unsupported locale: \"tlh\"","code":"invalid_locale"}`},
+		{"missing value", "/v1/format", `{"base": 2}`, http.StatusBadRequest, ""},
+		{"operation with format", "/v1/multiply", `{"a": 255, "b": 2, "format": {"base": 2}}`, http.StatusOK,
+			`{"result":510,"formatted":{"scientific":"5.1e2","engineering":"510e0","base":"111111110"}}`},
+		{"operation without format", "/v1/multiply", `{"a": 255, "b": 2}`, http.StatusOK, `{"result":510}`},
+		{"operation invalid format", "/v1/multiply", `{"a": 255, "b": 2, "format": {"decimals": -1}}`,
+			http.StatusBadRequest, `{"error":"precision out of range","code":"invalid_precision"}`},
+		{"overflow in base", "/v1/multiply", `{"a": 1e308, "b": 10, "format": {"base": 16}}`,
+			http.StatusBadRequest, `{"error":"result overflows","code":"overflow"}`},
+		{"overflow with rounding", "/v1/multiply", `{"a": 1e308, "b": 10, "rounding": {"decimals": 2}}`,
+			http.StatusBadRequest, `{"error":"result overflows","code":"overflow"}`},
+		{"rounding overflows", "/v1/multiply",
+			`{"a": 1.7976931348623157e308, "b": 1, "rounding": {"significant": 1}}`,
+			http.StatusBadRequest, `{"error":"result overflows","code":"overflow"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := post(t, srv.URL+tt.url, tt.body)
+			assertBody(t, resp, tt.wantStatus, tt.wantBody)
+		})
+	}
+}
+
+func TestLocaleOperands(t *testing.T) {
+	engine := gin.New()
+	RegisterCalculatorV1(engine, calculator.DefaultRegistry(calculator.New()), calculator.Rounding{})
+	srv := httptest.NewServer(engine)
+	defer srv.Close()
+
+	tests := []struct {
+		name       string
+		url        string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{"de-DE", "/v1/add", `{"a": "1.234,5", "b": "0,25", "locale": "de-DE"}`, http.StatusOK,
+			`{"result":1234.75}`},
+		{"fr-FR spaces", "/v1/multiply", `{"a": "1 234,5", "b": 2, "locale": "fr-FR"}`, http.StatusOK,
+			`{"result":2469}`},
+		{"localized output", "/v1/add", `{"a": "1.234,5", "b": "1", "locale": "de-DE", "format": {"decimals": 2}}`,
+			http.StatusOK, `{"result":1235.5,"formatted":{"scientific":"1.2355e3","engineering":"1.2355e3",` +
+				`"fixed":"1235.50","localized":"1.235,50"}}`},
+		{"format locale wins", "/v1/add", `{"a": "1,5", "b": "1", "locale": "de-DE", "format": {"locale": "en-US"}}`,
+			http.StatusOK, `{"result":2.5,"formatted":{"scientific":"2.5e0","engineering":"2.5e0","localized":"2.5"}}`},
+		{"ambiguous", "/v1/add", `{"a": "1.5", "b": 1, "locale": "de-DE"}`, http.StatusBadRequest,
+			`{"error":"ambiguous number: \"1.5\": de-DE groups digits by 3","code":"ambiguous_number"}`},
+		{"wrong decimal separator", "/v1/add", `{"a": "1,234.5", "b": 1, "locale": "de-DE"}`, http.StatusBadRequest,
+			`{"error":"ambiguous number: \"1,234.5\": de-DE uses \",\" as decimal separator","code":"ambiguous_number"}`},
+		{"invalid", "/v1/add", `{"a": "abc", "b": 1, "locale": "en-US"}`, http.StatusBadRequest,
+			`{"error":"invalid number: \"abc\"","code":"invalid_number"}`},
+		{"unsupported locale", "/v1/add", `{"a": "1", "b": 1, "locale": "xx"}`, http.StatusBadRequest,
+			`{"error":"unsupported locale: \"xx\"","code":"invalid_locale"}`},
+		{"without locale", "/v1/add", `{"a": "1,5", "b": 1}`, http.StatusBadRequest, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := post(t, srv.URL+tt.url, tt.body)
+			assertBody(t, resp, tt.wantStatus, tt.wantBody)
+		})
+	}
+}
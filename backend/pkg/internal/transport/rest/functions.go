@@ -0,0 +1,149 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/igorgatis/sezzle/backend/pkg/internal/expr"
+	"github.com/igorgatis/sezzle/backend/pkg/internal/storage"
+)
+
+// TenantHeader selects the scope of user-defined functions.
+const TenantHeader = "X-Tenant-ID"
+
+const defaultTenant = "default"
+
+type FunctionInput struct {
+	Params []string `json:"params" example:"x"`
+	Body   string   `json:"body" binding:"required" example:"max(0.3, x * 0.029)"`
+}
+
+type FunctionDefinition struct {
+	Name   string   `json:"name" example:"fee"`
+	Params []string `json:"params" example:"x"`
+	Body   string   `json:"body" example:"max(0.3, x * 0.029)"`
+}
+
+type FunctionList struct {
+	Functions []FunctionDefinition `json:"functions"`
+}
+
+func RegisterFunctionsV1(r gin.IRouter, registry *expr.Registry) {
+	g := r.Group("/v1/functions")
+	g.GET("", listFunctionsHandler(registry))
+	g.GET("/:name", getFunctionHandler(registry))
+	g.PUT("/:name", putFunctionHandler(registry))
+	g.DELETE("/:name", deleteFunctionHandler(registry))
+}
+
+func tenant(c *gin.Context) string {
+	if t := c.GetHeader(TenantHeader); t != "" {
+		return t
+	}
+	return defaultTenant
+}
+
+func toFunctionDefinition(d expr.Definition) FunctionDefinition {
+	params := d.Params
+	if params == nil {
+		params = []string{}
+	}
+	return FunctionDefinition{Name: d.Name, Params: params, Body: d.Body}
+}
+
+func writeRegistryError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "function not found"})
+	case errors.Is(err, expr.ErrFunctionInUse):
+		c.JSON(http.StatusConflict, ErrorResponse{Error: err.Error()})
+	default:
+		writeErrorResponse(c, err)
+	}
+}
+
+// @Summary List user-defined functions
+// @Param X-Tenant-ID header string false "Tenant scope" default(default)
+// @Success 200 {object} FunctionList
+// @Failure 400 {object} ErrorResponse
+// @Router /v1/functions [get]
+func listFunctionsHandler(registry *expr.Registry) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defs, err := registry.List(tenant(c))
+		if err != nil {
+			writeRegistryError(c, err)
+			return
+		}
+		out := FunctionList{Functions: make([]FunctionDefinition, len(defs))}
+		for i, d := range defs {
+			out.Functions[i] = toFunctionDefinition(d)
+		}
+		c.JSON(http.StatusOK, out)
+	}
+}
+
+// @Summary Get a user-defined function
+// @Param X-Tenant-ID header string false "Tenant scope" default(default)
+// @Param name path string true "Function name"
+// @Success 200 {object} FunctionDefinition
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /v1/functions/{name} [get]
+func getFunctionHandler(registry *expr.Registry) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		d, err := registry.Get(tenant(c), c.Param("name"))
+		if err != nil {
+			writeRegistryError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, toFunctionDefinition(d))
+	}
+}
+
+// @Summary Define or update a user-defined function
+// @Param X-Tenant-ID header string false "Tenant scope" default(default)
+// @Param name path string true "Function name"
+// @Param input body FunctionInput true "Parameters and body expression"
+// @Success 200 {object} FunctionDefinition
+// @Success 201 {object} FunctionDefinition
+// @Failure 400 {object} ErrorResponse
+// @Router /v1/functions/{name} [put]
+func putFunctionHandler(registry *expr.Registry) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var input FunctionInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			writeErrorResponse(c, err)
+			return
+		}
+		d := expr.Definition{Name: c.Param("name"), Params: input.Params, Body: input.Body}
+		created, err := registry.Put(tenant(c), d)
+		if err != nil {
+			writeRegistryError(c, err)
+			return
+		}
+		status := http.StatusOK
+		if created {
+			status = http.StatusCreated
+		}
+		c.JSON(status, toFunctionDefinition(d))
+	}
+}
+
+// @Summary Delete a user-defined function
+// @Param X-Tenant-ID header string false "Tenant scope" default(default)
+// @Param name path string true "Function name"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /v1/functions/{name} [delete]
+func deleteFunctionHandler(registry *expr.Registry) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := registry.Delete(tenant(c), c.Param("name")); err != nil {
+			writeRegistryError(c, err)
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
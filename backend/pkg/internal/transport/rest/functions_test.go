@@ -0,0 +1,91 @@
+package rest
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func do(t *testing.T, method, url, tenant, body string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(method, url, bytes.NewBufferString(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tenant != "" {
+		req.Header.Set(TenantHeader, tenant)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+func TestFunctions(t *testing.T) {
+	srv := setupExprServer()
+	defer srv.Close()
+	url := srv.URL + "/v1/functions"
+
+	steps := []struct {
+		name       string
+		method     string
+		path       string
+		tenant     string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{"empty list", "GET", "", "acme", "", http.StatusOK, `{"functions":[]}`},
+		{"define fee", "PUT", "/fee", "acme", `{"params": ["x"], "body": "max(0.3, x * 0.5)"}`,
+			http.StatusCreated, `{"name":"fee","params":["x"],"body":"max(0.3, x * 0.5)"}`},
+		{"define total", "PUT", "/total", "acme", `{"params": ["x"], "body": "x + fee(x)"}`,
+			http.StatusCreated, ""},
+		{"update fee", "PUT", "/fee", "acme", `{"params": ["x"], "body": "x * 2"}`,
+			http.StatusOK, `{"name":"fee","params":["x"],"body":"x * 2"}`},
+		{"get fee", "GET", "/fee", "acme", "", http.StatusOK,
+			`{"name":"fee","params":["x"],"body":"x * 2"}`},
+		{"list", "GET", "", "acme", "", http.StatusOK,
+			`{"functions":[{"name":"fee","params":["x"],"body":"x * 2"},{"name":"total","params":["x"],"body":"x + fee(x)"}]}`},
+		{"other tenant", "GET", "/fee", "", "", http.StatusNotFound, ""},
+		{"invalid body", "PUT", "/bad", "acme", `{"params": ["x"], "body": "x +"}`, http.StatusBadRequest, ""},
+		{"missing body", "PUT", "/bad", "acme", `{"params": ["x"]}`, http.StatusBadRequest, ""},
+		{"builtin name", "PUT", "/sqrt", "acme", `{"params": ["x"], "body": "x"}`, http.StatusBadRequest, ""},
+		{"recursive", "PUT", "/fee", "acme", `{"params": ["x"], "body": "total(x)"}`, http.StatusBadRequest, ""},
+		{"define many calls", "PUT", "/many", "acme",
+			`{"params": ["x"], "body": "` + strings.Repeat("fee(x) + ", 999) + `fee(x)"}`, http.StatusCreated, ""},
+		{"too complex", "PUT", "/more", "acme",
+			`{"params": ["x"], "body": "` + strings.Repeat("many(x) + ", 19) + `many(x)"}`, http.StatusBadRequest, ""},
+		{"invalid tenant", "GET", "", "a/b", "", http.StatusBadRequest, ""},
+		{"delete in use", "DELETE", "/fee", "acme", "", http.StatusConflict, ""},
+		{"delete total", "DELETE", "/total", "acme", "", http.StatusNoContent, ""},
+		{"delete missing", "DELETE", "/total", "acme", "", http.StatusNotFound, ""},
+	}
+	for _, tt := range steps {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := do(t, tt.method, url+tt.path, tt.tenant, tt.body)
+			assertBody(t, resp, tt.wantStatus, tt.wantBody)
+		})
+	}
+}
+
+func TestEvaluateWithFunctions(t *testing.T) {
+	srv := setupExprServer()
+	defer srv.Close()
+
+	resp := do(t, "PUT", srv.URL+"/v1/functions/fee", "acme",
+		`{"params": ["x"], "body": "max(0.3, x * 0.5)"}`)
+	assertBody(t, resp, http.StatusCreated, "")
+
+	body := `{"expression": "fee(10) + fee(0)"}`
+	resp = do(t, "POST", srv.URL+"/v1/evaluate", "acme", body)
+	assertBody(t, resp, http.StatusOK, `{"result":5.3}`)
+
+	resp = do(t, "POST", srv.URL+"/v1/evaluate", "", body)
+	assertBody(t, resp, http.StatusBadRequest, `{"error":"unknown function: fee"}`)
+
+	resp = do(t, "POST", srv.URL+"/v1/expressions", "a/b", body)
+	assertBody(t, resp, http.StatusBadRequest, "")
+}
@@ -0,0 +1,395 @@
+package rest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/igorgatis/sezzle/backend/pkg/internal/calculator"
+	"github.com/igorgatis/sezzle/backend/pkg/internal/linalg"
+)
+
+type MatrixRequest struct {
+	A [][]float64 `json:"a" binding:"required"`
+}
+
+type MatrixBinaryRequest struct {
+	A [][]float64 `json:"a" binding:"required"`
+	B [][]float64 `json:"b" binding:"required"`
+}
+
+type SolveRequest struct {
+	A [][]float64 `json:"a" binding:"required"`
+	B []float64   `json:"b" binding:"required" example:"3,5"`
+}
+
+type VectorBinaryRequest struct {
+	A []float64 `json:"a" binding:"required" example:"1,2,3"`
+	B []float64 `json:"b" binding:"required" example:"4,5,6"`
+}
+
+type SVDRequest struct {
+	A [][]float64 `json:"a" binding:"required"`
+	// MaxSweeps limits the Jacobi sweeps, 100 when omitted.
+	MaxSweeps int `json:"max_sweeps" example:"100"`
+}
+
+type EigenRequest struct {
+	A [][]float64 `json:"a" binding:"required"`
+	// MaxIterations limits the QL iterations, 30 per row when omitted.
+	MaxIterations int `json:"max_iterations" example:"60"`
+}
+
+type MatrixResponse struct {
+	Result [][]float64 `json:"result"`
+}
+
+type VectorResponse struct {
+	Result []float64 `json:"result" example:"0.8,1.4"`
+}
+
+type RankResponse struct {
+	Rank int `json:"rank" example:"2"`
+}
+
+type QRResponse struct {
+	Q [][]float64 `json:"q"`
+	R [][]float64 `json:"r"`
+}
+
+type CholeskyResponse struct {
+	L [][]float64 `json:"l"`
+}
+
+type SVDResponse struct {
+	U      [][]float64 `json:"u"`
+	S      []float64   `json:"s" example:"5,3"`
+	V      [][]float64 `json:"v"`
+	Sweeps int         `json:"sweeps" example:"2"`
+}
+
+type EigenResponse struct {
+	Values     []float64   `json:"values" example:"1,3"`
+	Vectors    [][]float64 `json:"vectors"`
+	Iterations int         `json:"iterations" example:"1"`
+}
+
+// RegisterLinalgV1 serves matrix operations at /v1/matrix and vector
+// operations at /v1/vector. Matrices are arrays of rows of equal length, at
+// most 1000×1000.
+func RegisterLinalgV1(r gin.IRouter) {
+	m := r.Group("/v1/matrix")
+	m.POST("/add", matrixBinaryHandler(linalg.Matrix.Add))
+	m.POST("/subtract", matrixBinaryHandler(linalg.Matrix.Sub))
+	m.POST("/multiply", matrixBinaryHandler(linalg.Matrix.Mul))
+	m.POST("/transpose", transposeHandler)
+	m.POST("/determinant", determinantHandler)
+	m.POST("/inverse", inverseHandler)
+	m.POST("/rank", rankHandler)
+	m.POST("/solve", solveHandler)
+	m.POST("/qr", qrHandler)
+	m.POST("/cholesky", choleskyHandler)
+	m.POST("/svd", svdHandler)
+	m.POST("/eigen", eigenHandler)
+	v := r.Group("/v1/vector")
+	v.POST("/dot", dotHandler)
+	v.POST("/cross", crossHandler)
+}
+
+// @Summary Add, subtract or multiply two matrices
+// @Param operation path string true "Operation" Enums(add, subtract, multiply)
+// @Param input body MatrixBinaryRequest true "Matrices"
+// @Success 200 {object} MatrixResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /v1/matrix/{operation} [post]
+func matrixBinaryHandler(op func(a, b linalg.Matrix) (linalg.Matrix, error)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req MatrixBinaryRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			writeErrorResponse(c, err)
+			return
+		}
+		a, err := parseMatrix("a", req.A)
+		if err != nil {
+			writeErrorResponse(c, err)
+			return
+		}
+		b, err := parseMatrix("b", req.B)
+		if err != nil {
+			writeErrorResponse(c, err)
+			return
+		}
+		m, err := op(a, b)
+		writeMatrix(c, m, err)
+	}
+}
+
+// @Summary Transpose a matrix
+// @Param input body MatrixRequest true "Matrix"
+// @Success 200 {object} MatrixResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /v1/matrix/transpose [post]
+func transposeHandler(c *gin.Context) {
+	if a, ok := bindMatrix(c); ok {
+		writeMatrix(c, a.Transpose(), nil)
+	}
+}
+
+// @Summary Determinant of a square matrix
+// @Param input body MatrixRequest true "Matrix"
+// @Success 200 {object} Response
+// @Failure 400 {object} ErrorResponse
+// @Router /v1/matrix/determinant [post]
+func determinantHandler(c *gin.Context) {
+	a, ok := bindMatrix(c)
+	if !ok {
+		return
+	}
+	d, err := a.Det()
+	if err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	writeResponse(c, d, calculator.Rounding{}, nil)
+}
+
+// @Summary Inverse of a square matrix
+// @Description Singular matrices fail with singular_matrix.
+// @Param input body MatrixRequest true "Matrix"
+// @Success 200 {object} MatrixResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /v1/matrix/inverse [post]
+func inverseHandler(c *gin.Context) {
+	if a, ok := bindMatrix(c); ok {
+		inv, err := a.Inverse()
+		writeMatrix(c, inv, err)
+	}
+}
+
+// @Summary Rank of a matrix
+// @Param input body MatrixRequest true "Matrix"
+// @Success 200 {object} RankResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /v1/matrix/rank [post]
+func rankHandler(c *gin.Context) {
+	if a, ok := bindMatrix(c); ok {
+		c.JSON(http.StatusOK, RankResponse{Rank: a.Rank()})
+	}
+}
+
+// @Summary Solve the linear system a x = b
+// @Description Uses LU decomposition with partial pivoting. Singular systems fail with singular_matrix.
+// @Param input body SolveRequest true "Square matrix and right-hand side"
+// @Success 200 {object} VectorResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /v1/matrix/solve [post]
+func solveHandler(c *gin.Context) {
+	var req SolveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	a, err := parseMatrix("a", req.A)
+	if err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	b, err := parseVector("b", req.B)
+	if err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	x, err := linalg.Solve(a, b)
+	if err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, VectorResponse{Result: x})
+}
+
+// @Summary QR decomposition of a matrix
+// @Description Thin Householder factorization a = q r of an m×n matrix, with q m×k with orthonormal columns and r k×n upper triangular, where k = min(m, n). Fails with overflow when r does not fit in a float64.
+// @Param input body MatrixRequest true "Matrix"
+// @Success 200 {object} QRResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /v1/matrix/qr [post]
+func qrHandler(c *gin.Context) {
+	a, ok := bindMatrix(c)
+	if !ok {
+		return
+	}
+	qr, err := linalg.FactorizeQR(a)
+	if err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, QRResponse{Q: qr.Q.ToRows(), R: qr.R.ToRows()})
+}
+
+// @Summary Cholesky decomposition of a symmetric positive definite matrix
+// @Description Lower triangular l with a = l lᵀ. Fails with not_symmetric or not_positive_definite.
+// @Param input body MatrixRequest true "Matrix"
+// @Success 200 {object} CholeskyResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /v1/matrix/cholesky [post]
+func choleskyHandler(c *gin.Context) {
+	a, ok := bindMatrix(c)
+	if !ok {
+		return
+	}
+	l, err := linalg.Cholesky(a)
+	if err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, CholeskyResponse{L: l.ToRows()})
+}
+
+// @Summary Singular value decomposition of a matrix
+// @Description Thin decomposition a = u diag(s) vᵀ by one-sided Jacobi, with s non-negative and decreasing. Fails with no_convergence when max_sweeps, 1 to 100000, is reached and with timeout after 2 seconds.
+// @Param input body SVDRequest true "Matrix and sweep limit"
+// @Success 200 {object} SVDResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /v1/matrix/svd [post]
+func svdHandler(c *gin.Context) {
+	var req SVDRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	a, err := parseMatrix("a", req.A)
+	if err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	ctx, cancel := context.WithTimeout(c.Request.Context(), numericTimeout)
+	defer cancel()
+	s, err := linalg.FactorizeSVD(ctx, a, req.MaxSweeps)
+	if err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, SVDResponse{U: s.U.ToRows(), S: s.Values, V: s.V.ToRows(), Sweeps: s.Sweeps})
+}
+
+// @Summary Eigenvalues and eigenvectors of a symmetric matrix
+// @Description Householder tridiagonalization followed by implicit QL. Values are increasing and the columns of vectors are the matching orthonormal eigenvectors. Fails with no_convergence when max_iterations, 1 to 100000, is reached, with overflow when eigenvalues do not fit in a float64 and with timeout after 2 seconds.
+// @Param input body EigenRequest true "Symmetric matrix and iteration limit"
+// @Success 200 {object} EigenResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /v1/matrix/eigen [post]
+func eigenHandler(c *gin.Context) {
+	var req EigenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	a, err := parseMatrix("a", req.A)
+	if err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	ctx, cancel := context.WithTimeout(c.Request.Context(), numericTimeout)
+	defer cancel()
+	e, err := linalg.SymmetricEigen(ctx, a, req.MaxIterations)
+	if err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, EigenResponse{Values: e.Values, Vectors: e.Vectors.ToRows(), Iterations: e.Iterations})
+}
+
+// @Summary Dot product of two vectors
+// @Param input body VectorBinaryRequest true "Vectors"
+// @Success 200 {object} Response
+// @Failure 400 {object} ErrorResponse
+// @Router /v1/vector/dot [post]
+func dotHandler(c *gin.Context) {
+	a, b, ok := bindVectors(c)
+	if !ok {
+		return
+	}
+	d, err := linalg.Dot(a, b)
+	if err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	writeResponse(c, d, calculator.Rounding{}, nil)
+}
+
+// @Summary Cross product of two 3-dimensional vectors
+// @Param input body VectorBinaryRequest true "Vectors"
+// @Success 200 {object} VectorResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /v1/vector/cross [post]
+func crossHandler(c *gin.Context) {
+	a, b, ok := bindVectors(c)
+	if !ok {
+		return
+	}
+	x, err := linalg.Cross(a, b)
+	if err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, VectorResponse{Result: x})
+}
+
+func parseMatrix(name string, rows [][]float64) (linalg.Matrix, error) {
+	m, err := linalg.New(rows)
+	if err != nil {
+		return linalg.Matrix{}, fmt.Errorf("%s: %w", name, err)
+	}
+	return m, nil
+}
+
+func parseVector(name string, x []float64) (linalg.Vector, error) {
+	v, err := linalg.NewVector(x)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return v, nil
+}
+
+// bindMatrix binds a MatrixRequest, writing the error response on failure.
+func bindMatrix(c *gin.Context) (linalg.Matrix, bool) {
+	var req MatrixRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeErrorResponse(c, err)
+		return linalg.Matrix{}, false
+	}
+	a, err := parseMatrix("a", req.A)
+	if err != nil {
+		writeErrorResponse(c, err)
+		return linalg.Matrix{}, false
+	}
+	return a, true
+}
+
+// bindVectors binds a VectorBinaryRequest, writing the error response on
+// failure.
+func bindVectors(c *gin.Context) (a, b linalg.Vector, ok bool) {
+	var req VectorBinaryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeErrorResponse(c, err)
+		return nil, nil, false
+	}
+	var err error
+	if a, err = parseVector("a", req.A); err == nil {
+		b, err = parseVector("b", req.B)
+	}
+	if err != nil {
+		writeErrorResponse(c, err)
+		return nil, nil, false
+	}
+	return a, b, true
+}
+
+func writeMatrix(c *gin.Context, m linalg.Matrix, err error) {
+	if err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, MatrixResponse{Result: m.ToRows()})
+}
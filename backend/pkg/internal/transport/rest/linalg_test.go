@@ -0,0 +1,95 @@
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestLinalg(t *testing.T) {
+	engine := gin.New()
+	RegisterLinalgV1(engine)
+	srv := httptest.NewServer(engine)
+	defer srv.Close()
+
+	tests := []struct {
+		name       string
+		path       string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{"add", "/v1/matrix/add", `{"a": [[1, 2], [3, 4]], "b": [[10, 20], [30, 40]]}`, http.StatusOK,
+			`{"result":[[11,22],[33,44]]}`},
+		{"subtract", "/v1/matrix/subtract", `{"a": [[1, 2]], "b": [[1, 1]]}`, http.StatusOK, `{"result":[[0,1]]}`},
+		{"multiply", "/v1/matrix/multiply", `{"a": [[1, 2, 3], [4, 5, 6]], "b": [[7, 8], [9, 10], [11, 12]]}`,
+			http.StatusOK, `{"result":[[58,64],[139,154]]}`},
+		{"multiply mismatch", "/v1/matrix/multiply", `{"a": [[1, 2]], "b": [[1, 2]]}`, http.StatusBadRequest,
+			`{"error":"dimensions do not match: cannot multiply 1×2 by 1×2","code":"dimension_mismatch"}`},
+		{"transpose", "/v1/matrix/transpose", `{"a": [[1, 2, 3]]}`, http.StatusOK, `{"result":[[1],[2],[3]]}`},
+		{"determinant", "/v1/matrix/determinant", `{"a": [[4, 6], [3, 8]]}`, http.StatusOK, `{"result":14}`},
+		{"singular determinant", "/v1/matrix/determinant", `{"a": [[1, 2], [2, 4]]}`, http.StatusOK, `{"result":0}`},
+		{"determinant not square", "/v1/matrix/determinant", `{"a": [[1, 2]]}`, http.StatusBadRequest,
+			`{"error":"matrix must be square: got 1×2","code":"not_square"}`},
+		{"inverse", "/v1/matrix/inverse", `{"a": [[2, 0], [0, 4]]}`, http.StatusOK, `{"result":[[0.5,0],[0,0.25]]}`},
+		{"singular inverse", "/v1/matrix/inverse", `{"a": [[1, 2], [2, 4]]}`, http.StatusBadRequest,
+			`{"error":"matrix is singular","code":"singular_matrix"}`},
+		{"rank", "/v1/matrix/rank", `{"a": [[1, 2, 3], [4, 5, 6], [7, 8, 9]]}`, http.StatusOK, `{"rank":2}`},
+		{"solve", "/v1/matrix/solve", `{"a": [[2, 1, -1], [-3, -1, 2], [-2, 1, 2]], "b": [8, -11, -3]}`,
+			http.StatusOK, ""},
+		{"singular solve", "/v1/matrix/solve", `{"a": [[1, 2], [2, 4]], "b": [1, 2]}`, http.StatusBadRequest,
+			`{"error":"matrix is singular","code":"singular_matrix"}`},
+		{"solve length mismatch", "/v1/matrix/solve", `{"a": [[1, 0], [0, 1]], "b": [1]}`, http.StatusBadRequest,
+			`{"error":"dimensions do not match: 2×2 system with 1 right-hand side values","code":"dimension_mismatch"}`},
+		{"ragged", "/v1/matrix/rank", `{"a": [[1, 2], [3]]}`, http.StatusBadRequest,
+			`{"error":"a: invalid matrix: row 1 has 1 elements, want 2","code":"invalid_matrix"}`},
+		{"empty", "/v1/matrix/transpose", `{"a": []}`, http.StatusBadRequest,
+			`{"error":"a: invalid matrix: no elements","code":"invalid_matrix"}`},
+		{"missing matrix", "/v1/matrix/add", `{"a": [[1]]}`, http.StatusBadRequest, ""},
+		{"overflow", "/v1/matrix/multiply", `{"a": [[1e200]], "b": [[1e200]]}`, http.StatusBadRequest,
+			`{"error":"result overflows","code":"overflow"}`},
+		{"qr", "/v1/matrix/qr", `{"a": [[0], [2]]}`, http.StatusOK, `{"q":[[0],[-1]],"r":[[-2]]}`},
+		{"qr large", "/v1/matrix/qr", `{"a": [[1e200, 0], [1e200, 1]]}`, http.StatusOK,
+			`{"q":[[-0.7071067811865475,0.7071067811865475],[-0.7071067811865475,-0.7071067811865476]],` +
+				`"r":[[-1.4142135623730949e+200,-0.7071067811865475],[0,-0.7071067811865476]]}`},
+		{"qr overflow", "/v1/matrix/qr", `{"a": [[1.5e308], [1.5e308]]}`, http.StatusBadRequest,
+			`{"error":"result overflows","code":"overflow"}`},
+		{"cholesky", "/v1/matrix/cholesky", `{"a": [[4, 12, -16], [12, 37, -43], [-16, -43, 98]]}`, http.StatusOK,
+			`{"l":[[2,0,0],[6,1,0],[-8,5,3]]}`},
+		{"cholesky indefinite", "/v1/matrix/cholesky", `{"a": [[1, 2], [2, 1]]}`, http.StatusBadRequest,
+			`{"error":"matrix is not positive definite: pivot 1 is -3","code":"not_positive_definite"}`},
+		{"svd", "/v1/matrix/svd", `{"a": [[3, 0], [0, -5]]}`, http.StatusOK,
+			`{"u":[[0,1],[-1,0]],"s":[5,3],"v":[[0,1],[1,0]],"sweeps":1}`},
+		{"svd large", "/v1/matrix/svd", `{"a": [[1e200, 0], [1e200, 1]]}`, http.StatusOK,
+			`{"u":[[0.7071067811865475,-0.7071067811865475],[0.7071067811865475,0.7071067811865475]],` +
+				`"s":[1.414213562373095e+200,0.7071067811865476],"v":[[1,-5e-201],[5e-201,1]],"sweeps":2}`},
+		{"svd overflow", "/v1/matrix/svd", `{"a": [[1.5e308], [1.5e308]]}`, http.StatusBadRequest,
+			`{"error":"result overflows","code":"overflow"}`},
+		{"svd sweep limit", "/v1/matrix/svd", `{"a": [[1, 2], [3, 4]], "max_sweeps": 1}`, http.StatusBadRequest,
+			`{"error":"decomposition did not converge: after 1 sweeps","code":"no_convergence"}`},
+		{"eigen", "/v1/matrix/eigen", `{"a": [[3, 0], [0, 2]]}`, http.StatusOK,
+			`{"values":[2,3],"vectors":[[0,1],[1,0]],"iterations":0}`},
+		{"eigen overflow", "/v1/matrix/eigen", `{"a": [[1e308, 1e308], [1e308, 1e308]]}`, http.StatusBadRequest,
+			`{"error":"result overflows","code":"overflow"}`},
+		{"eigen not symmetric", "/v1/matrix/eigen", `{"a": [[1, 2], [3, 4]]}`, http.StatusBadRequest,
+			`{"error":"matrix must be symmetric: elements [1][0] and [0][1] differ","code":"not_symmetric"}`},
+		{"eigen invalid limit", "/v1/matrix/eigen", `{"a": [[1]], "max_iterations": -1}`, http.StatusBadRequest,
+			`{"error":"invalid iteration limit: -1, want 1 to 100000","code":"invalid_iteration_limit"}`},
+		{"dot", "/v1/vector/dot", `{"a": [1, 2, 3], "b": [4, 5, 6]}`, http.StatusOK, `{"result":32}`},
+		{"dot mismatch", "/v1/vector/dot", `{"a": [1, 2], "b": [4, 5, 6]}`, http.StatusBadRequest,
+			`{"error":"dimensions do not match: vectors of 2 and 3 elements","code":"dimension_mismatch"}`},
+		{"cross", "/v1/vector/cross", `{"a": [1, 0, 0], "b": [0, 1, 0]}`, http.StatusOK, `{"result":[0,0,1]}`},
+		{"cross not 3-D", "/v1/vector/cross", `{"a": [1, 0], "b": [0, 1]}`, http.StatusBadRequest,
+			`{"error":"dimensions do not match: cross product needs 3 elements, got 2 and 2","code":"dimension_mismatch"}`},
+		{"empty vector", "/v1/vector/dot", `{"a": [], "b": [1]}`, http.StatusBadRequest,
+			`{"error":"a: invalid matrix: vector of 0 elements, want 1 to 1000","code":"invalid_matrix"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := post(t, srv.URL+tt.path, tt.body)
+			assertBody(t, resp, tt.wantStatus, tt.wantBody)
+		})
+	}
+}
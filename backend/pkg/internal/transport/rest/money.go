@@ -0,0 +1,380 @@
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/igorgatis/sezzle/backend/pkg/internal/calculator"
+	"github.com/igorgatis/sezzle/backend/pkg/internal/money"
+)
+
+var errInvalidDate = &calculator.Error{Code: "invalid_date", Message: "invalid date, want YYYY-MM-DD"}
+
+// Money is an amount of a currency. Responses always return the amount as
+// a string with the minor units of the currency, e.g. "10.50".
+type Money struct {
+	Amount   Amount `json:"amount" binding:"required" swaggertype:"string" example:"10.50"`
+	Currency string `json:"currency" binding:"required" example:"USD"`
+}
+
+// Amount is a decimal amount given as a JSON number or string. It keeps the
+// digits as written so that no precision is lost to floating point.
+type Amount string
+
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return err
+	}
+	*a = Amount(n)
+	return nil
+}
+
+type CurrencyInfo struct {
+	Code       string `json:"code" example:"USD"`
+	Name       string `json:"name" example:"US Dollar"`
+	MinorUnits int    `json:"minor_units" example:"2"`
+}
+
+type MoneyBinaryRequest struct {
+	A *Money `json:"a" binding:"required"`
+	B *Money `json:"b" binding:"required"`
+}
+
+type MoneyMultiplyRequest struct {
+	A      *Money      `json:"a" binding:"required"`
+	Factor json.Number `json:"factor" binding:"required" swaggertype:"string" example:"1.075"`
+	// RoundingMode rounds the product to minor units.
+	RoundingMode string `json:"rounding_mode" enums:"half_up,half_even,down,up,ceiling,floor" default:"half_even"`
+}
+
+type MoneyAllocateRequest struct {
+	A      *Money        `json:"a" binding:"required"`
+	Ratios []json.Number `json:"ratios" binding:"required" swaggertype:"array,string" example:"1,1,2"`
+}
+
+type MoneySplitRequest struct {
+	A     *Money `json:"a" binding:"required"`
+	Parts int    `json:"parts" binding:"required" example:"3"`
+}
+
+type MoneyConvertRequest struct {
+	A  *Money `json:"a" binding:"required"`
+	To string `json:"to" binding:"required" example:"EUR"`
+	// Date selects the rates effective on that day. Defaults to today.
+	Date         string `json:"date" example:"2026-06-01"`
+	RoundingMode string `json:"rounding_mode" enums:"half_up,half_even,down,up,ceiling,floor" default:"half_even"`
+}
+
+// RateInfo is the exchange rate used by a conversion. Via names the base
+// currency of a triangulated rate, whose date is that of its older leg.
+type RateInfo struct {
+	From string `json:"from" example:"USD"`
+	To   string `json:"to" example:"EUR"`
+	// Rate is rounded to 12 decimal places; conversions use its exact value.
+	Rate string `json:"rate" example:"0.92"`
+	Date string `json:"date" example:"2026-06-01"`
+	Via  string `json:"via,omitempty"`
+}
+
+type MoneyConvertResponse struct {
+	Result       Money    `json:"result"`
+	Rate         RateInfo `json:"rate"`
+	RoundingMode string   `json:"rounding_mode" example:"half_even"`
+}
+
+type RatesReloadResponse struct {
+	Rates int `json:"rates" example:"42"`
+}
+
+type MoneyResponse struct {
+	Result       Money  `json:"result"`
+	RoundingMode string `json:"rounding_mode,omitempty" example:"half_even"`
+}
+
+// MoneyPartsResponse holds the parts of an allocation, which always add up
+// to the allocated amount.
+type MoneyPartsResponse struct {
+	Parts []Money `json:"parts"`
+}
+
+// reloadableRates is a RatesProvider whose rates can be reloaded.
+type reloadableRates interface {
+	money.RatesProvider
+	Reload() error
+	Len() int
+}
+
+// RegisterMoneyV1 serves currency-aware money arithmetic at /v1/money,
+// converting between currencies with rates. POST /v1/money/rates/reload is
+// served when rates can be reloaded. The tax, discount, pricing and tip
+// operations take percents as exact decimals, given as JSON numbers or
+// strings, e.g. 8.25 for 8.25%, and round amounts to minor units with
+// rounding_mode, which defaults to half_even.
+func RegisterMoneyV1(r gin.IRouter, rates money.RatesProvider) {
+	g := r.Group("/v1/money")
+	g.GET("/currencies", currenciesHandler)
+	g.POST("/add", moneyBinaryHandler(money.Money.Add))
+	g.POST("/subtract", moneyBinaryHandler(money.Money.Sub))
+	g.POST("/multiply", moneyMultiplyHandler)
+	g.POST("/allocate", moneyAllocateHandler)
+	g.POST("/split", moneySplitHandler)
+	g.POST("/convert", moneyConvertHandler(rates))
+	g.POST("/tax", taxHandler)
+	g.POST("/discount", discountHandler)
+	g.POST("/markup", pricingHandler(money.Markup))
+	g.POST("/margin", pricingHandler(money.Margin))
+	g.POST("/percent-change", percentChangeHandler)
+	g.POST("/tip", tipHandler)
+	if rr, ok := rates.(reloadableRates); ok {
+		g.POST("/rates/reload", ratesReloadHandler(rr))
+	}
+}
+
+// @Summary List supported ISO 4217 currencies and their minor units
+// @Success 200 {array} CurrencyInfo
+// @Router /v1/money/currencies [get]
+func currenciesHandler(c *gin.Context) {
+	var out []CurrencyInfo
+	for _, cur := range money.Currencies() {
+		out = append(out, CurrencyInfo{Code: cur.Code, Name: cur.Name, MinorUnits: cur.MinorUnits})
+	}
+	c.JSON(http.StatusOK, out)
+}
+
+// @Summary Add or subtract amounts of the same currency
+// @Description Amounts with more decimal places than the currency allows are rejected.
+// @Param op path string true "Operation" Enums(add, subtract)
+// @Param input body MoneyBinaryRequest true "Amounts"
+// @Success 200 {object} MoneyResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /v1/money/{op} [post]
+func moneyBinaryHandler(eval func(money.Money, money.Money) (money.Money, error)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req MoneyBinaryRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			writeErrorResponse(c, err)
+			return
+		}
+		a, err := parseMoney("a", req.A)
+		if err != nil {
+			writeErrorResponse(c, err)
+			return
+		}
+		b, err := parseMoney("b", req.B)
+		if err != nil {
+			writeErrorResponse(c, err)
+			return
+		}
+		result, err := eval(a, b)
+		if err != nil {
+			writeErrorResponse(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, MoneyResponse{Result: formatMoney(result)})
+	}
+}
+
+// @Summary Multiply an amount by a factor
+// @Description The product is rounded to the minor units of the currency.
+// @Param input body MoneyMultiplyRequest true "Amount, factor and rounding mode"
+// @Success 200 {object} MoneyResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /v1/money/multiply [post]
+func moneyMultiplyHandler(c *gin.Context) {
+	var req MoneyMultiplyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	a, err := parseMoney("a", req.A)
+	if err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	factor, err := parseDecimal("factor", req.Factor)
+	if err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	mode, err := calculator.ParseRoundingMode(req.RoundingMode)
+	if err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	result, err := a.Mul(factor, mode)
+	if err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, MoneyResponse{Result: formatMoney(result), RoundingMode: string(mode)})
+}
+
+// @Summary Allocate an amount in proportion to ratios
+// @Description Minor units left over by rounding go to the parts with the largest remainders.
+// @Param input body MoneyAllocateRequest true "Amount and ratios"
+// @Success 200 {object} MoneyPartsResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /v1/money/allocate [post]
+func moneyAllocateHandler(c *gin.Context) {
+	var req MoneyAllocateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	a, err := parseMoney("a", req.A)
+	if err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	ratios := make([]*big.Rat, len(req.Ratios))
+	for i, r := range req.Ratios {
+		if ratios[i], err = parseDecimal(fmt.Sprintf("ratios[%d]", i), r); err != nil {
+			writeErrorResponse(c, err)
+			return
+		}
+	}
+	parts, err := a.Allocate(ratios)
+	writeParts(c, parts, err)
+}
+
+// @Summary Split an amount in equal parts
+// @Description Parts differ by at most one minor unit, larger parts first.
+// @Param input body MoneySplitRequest true "Amount and number of parts"
+// @Success 200 {object} MoneyPartsResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /v1/money/split [post]
+func moneySplitHandler(c *gin.Context) {
+	var req MoneySplitRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	a, err := parseMoney("a", req.A)
+	if err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	parts, err := a.Split(req.Parts)
+	writeParts(c, parts, err)
+}
+
+// @Summary Convert an amount to another currency
+// @Description Uses the latest rate effective on the date: the direct pair, the inverse pair or,
+// @Description failing both, a rate triangulated through the base currency.
+// @Param input body MoneyConvertRequest true "Amount, target currency, date and rounding mode"
+// @Success 200 {object} MoneyConvertResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /v1/money/convert [post]
+func moneyConvertHandler(rates money.RatesProvider) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req MoneyConvertRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			writeErrorResponse(c, err)
+			return
+		}
+		a, err := parseMoney("a", req.A)
+		if err != nil {
+			writeErrorResponse(c, err)
+			return
+		}
+		to, err := money.LookupCurrency(req.To)
+		if err != nil {
+			writeErrorResponse(c, fmt.Errorf("to: %w", err))
+			return
+		}
+		at := time.Now().UTC().Truncate(24 * time.Hour)
+		if req.Date != "" {
+			if at, err = time.Parse(money.DateLayout, req.Date); err != nil {
+				writeErrorResponse(c, fmt.Errorf("%w: %q", errInvalidDate, req.Date))
+				return
+			}
+		}
+		mode, err := calculator.ParseRoundingMode(req.RoundingMode)
+		if err != nil {
+			writeErrorResponse(c, err)
+			return
+		}
+		rate, err := rates.Rate(a.Currency, to, at)
+		if err != nil {
+			writeErrorResponse(c, err)
+			return
+		}
+		result, err := a.Convert(rate, mode)
+		if err != nil {
+			writeErrorResponse(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, MoneyConvertResponse{
+			Result: formatMoney(result),
+			Rate: RateInfo{
+				From: rate.From.Code,
+				To:   rate.To.Code,
+				Rate: money.FormatDecimal(rate.Rate, decimalPlaces),
+				Date: rate.Date.Format(money.DateLayout),
+				Via:  rate.Via,
+			},
+			RoundingMode: string(mode),
+		})
+	}
+}
+
+// @Summary Reload exchange rates from their file
+// @Description On error the previous rates stay in use.
+// @Success 200 {object} RatesReloadResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /v1/money/rates/reload [post]
+func ratesReloadHandler(rates reloadableRates) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := rates.Reload(); err != nil {
+			writeErrorResponse(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, RatesReloadResponse{Rates: rates.Len()})
+	}
+}
+
+// decimalPlaces bounds the decimal places of rates and percents in
+// responses.
+const decimalPlaces = 12
+
+// parseDecimal parses the exact decimal field name.
+func parseDecimal(name string, n json.Number) (*big.Rat, error) {
+	x, err := money.ParseDecimal(n.String())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return x, nil
+}
+
+func writeParts(c *gin.Context, parts []money.Money, err error) {
+	if err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	resp := MoneyPartsResponse{Parts: make([]Money, len(parts))}
+	for i, p := range parts {
+		resp.Parts[i] = formatMoney(p)
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func parseMoney(name string, m *Money) (money.Money, error) {
+	cur, err := money.LookupCurrency(m.Currency)
+	if err != nil {
+		return money.Money{}, fmt.Errorf("%s: %w", name, err)
+	}
+	v, err := money.Parse(string(m.Amount), cur)
+	if err != nil {
+		return money.Money{}, fmt.Errorf("%s: %w", name, err)
+	}
+	return v, nil
+}
+
+func formatMoney(m money.Money) Money {
+	return Money{Amount: Amount(m.String()), Currency: m.Currency.Code}
+}
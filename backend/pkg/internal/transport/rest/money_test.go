@@ -0,0 +1,141 @@
+package rest
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/igorgatis/sezzle/backend/pkg/internal/money"
+)
+
+func setupMoneyServer(t *testing.T) (*httptest.Server, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "rates.csv")
+	writeRates(t, path, "from,to,rate,date\nUSD,EUR,0.92,2026-06-01\nUSD,JPY,150.25,2026-06-01\nGBP,USD,1.25,2026-03-01\n")
+	usd, _ := money.LookupCurrency("USD")
+	rates, err := money.NewFileRates(path, usd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	engine := gin.New()
+	RegisterMoneyV1(engine, rates)
+	srv := httptest.NewServer(engine)
+	t.Cleanup(srv.Close)
+	return srv, path
+}
+
+func writeRates(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMoney(t *testing.T) {
+	srv, _ := setupMoneyServer(t)
+
+	tests := []struct {
+		name       string
+		path       string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{"add", "/v1/money/add", `{"a": {"amount": "10.10", "currency": "USD"}, "b": {"amount": 0.2, "currency": "usd"}}`,
+			http.StatusOK, `{"result":{"amount":"10.30","currency":"USD"}}`},
+		{"subtract", "/v1/money/subtract", `{"a": {"amount": "1", "currency": "KWD"}, "b": {"amount": "1.001", "currency": "KWD"}}`,
+			http.StatusOK, `{"result":{"amount":"-0.001","currency":"KWD"}}`},
+		{"sub-cent amount", "/v1/money/add", `{"a": {"amount": "10.005", "currency": "USD"}, "b": {"amount": 1, "currency": "USD"}}`,
+			http.StatusBadRequest, `{"error":"a: amount has more decimal places than the currency allows: 10.005 USD has 2","code":"amount_precision"}`},
+		{"fractional yen", "/v1/money/add", `{"a": {"amount": "1", "currency": "JPY"}, "b": {"amount": "0.5", "currency": "JPY"}}`,
+			http.StatusBadRequest, `{"error":"b: amount has more decimal places than the currency allows: 0.5 JPY has 0","code":"amount_precision"}`},
+		{"mixed currencies", "/v1/money/add", `{"a": {"amount": 1, "currency": "USD"}, "b": {"amount": 1, "currency": "EUR"}}`,
+			http.StatusBadRequest, `{"error":"amounts are in different currencies: USD and EUR","code":"currency_mismatch"}`},
+		{"unknown currency", "/v1/money/add", `{"a": {"amount": 1, "currency": "XYZ"}, "b": {"amount": 1, "currency": "XYZ"}}`,
+			http.StatusBadRequest, `{"error":"a: unknown currency: \"XYZ\"","code":"unknown_currency"}`},
+		{"missing operand", "/v1/money/add", `{"a": {"amount": 1, "currency": "USD"}}`, http.StatusBadRequest, ""},
+		{"multiply", "/v1/money/multiply", `{"a": {"amount": "19.99", "currency": "USD"}, "factor": "1.0825"}`,
+			http.StatusOK, `{"result":{"amount":"21.64","currency":"USD"},"rounding_mode":"half_even"}`},
+		{"multiply half up", "/v1/money/multiply", `{"a": {"amount": "0.05", "currency": "EUR"}, "factor": 0.5, "rounding_mode": "half_up"}`,
+			http.StatusOK, `{"result":{"amount":"0.03","currency":"EUR"},"rounding_mode":"half_up"}`},
+		{"invalid rounding mode", "/v1/money/multiply", `{"a": {"amount": 1, "currency": "USD"}, "factor": 2, "rounding_mode": "nearest"}`,
+			http.StatusBadRequest, `{"error":"invalid rounding mode: \"nearest\"","code":"invalid_rounding_mode"}`},
+		{"allocate", "/v1/money/allocate", `{"a": {"amount": "100", "currency": "USD"}, "ratios": [50, 30, "20"]}`,
+			http.StatusOK, `{"parts":[{"amount":"50.00","currency":"USD"},{"amount":"30.00","currency":"USD"},{"amount":"20.00","currency":"USD"}]}`},
+		{"allocate remainder", "/v1/money/allocate", `{"a": {"amount": "0.05", "currency": "USD"}, "ratios": [2, 8]}`,
+			http.StatusOK, `{"parts":[{"amount":"0.01","currency":"USD"},{"amount":"0.04","currency":"USD"}]}`},
+		{"invalid ratios", "/v1/money/allocate", `{"a": {"amount": 1, "currency": "USD"}, "ratios": [0, 0]}`,
+			http.StatusBadRequest, `{"error":"ratios must not be negative and must have a positive sum","code":"invalid_ratios"}`},
+		{"split", "/v1/money/split", `{"a": {"amount": "100", "currency": "JPY"}, "parts": 3}`,
+			http.StatusOK, `{"parts":[{"amount":"34","currency":"JPY"},{"amount":"33","currency":"JPY"},{"amount":"33","currency":"JPY"}]}`},
+		{"invalid parts", "/v1/money/split", `{"a": {"amount": "100", "currency": "JPY"}, "parts": -1}`,
+			http.StatusBadRequest, `{"error":"invalid number of parts: -1","code":"invalid_parts"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := post(t, srv.URL+tt.path, tt.body)
+			assertBody(t, resp, tt.wantStatus, tt.wantBody)
+		})
+	}
+}
+
+func TestCurrencies(t *testing.T) {
+	srv, _ := setupMoneyServer(t)
+	resp, err := http.Get(srv.URL + "/v1/money/currencies")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	for _, want := range []string{`{"code":"JPY","name":"Yen","minor_units":0}`, `{"code":"KWD","name":"Kuwaiti Dinar","minor_units":3}`} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("currencies missing %s", want)
+		}
+	}
+}
+
+func TestMoneyConvert(t *testing.T) {
+	srv, path := setupMoneyServer(t)
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{"direct", `{"a": {"amount": "100", "currency": "USD"}, "to": "EUR", "date": "2026-07-01"}`, http.StatusOK,
+			`{"result":{"amount":"92.00","currency":"EUR"},"rate":{"from":"USD","to":"EUR","rate":"0.92","date":"2026-06-01"},"rounding_mode":"half_even"}`},
+		{"inverse", `{"a": {"amount": "100", "currency": "EUR"}, "to": "USD", "date": "2026-07-01", "rounding_mode": "down"}`, http.StatusOK,
+			`{"result":{"amount":"108.69","currency":"USD"},"rate":{"from":"EUR","to":"USD","rate":"1.086956521739","date":"2026-06-01"},"rounding_mode":"down"}`},
+		{"triangulated", `{"a": {"amount": "10", "currency": "GBP"}, "to": "JPY", "date": "2026-07-01"}`, http.StatusOK,
+			`{"result":{"amount":"1878","currency":"JPY"},"rate":{"from":"GBP","to":"JPY","rate":"187.8125","date":"2026-03-01","via":"USD"},"rounding_mode":"half_even"}`},
+		{"before first rate", `{"a": {"amount": "1", "currency": "USD"}, "to": "EUR", "date": "2026-01-01"}`, http.StatusBadRequest,
+			`{"error":"no exchange rate available: USD/EUR on 2026-01-01","code":"rate_not_found"}`},
+		{"invalid date", `{"a": {"amount": "1", "currency": "USD"}, "to": "EUR", "date": "07/01/2026"}`, http.StatusBadRequest,
+			`{"error":"invalid date, want YYYY-MM-DD: \"07/01/2026\"","code":"invalid_date"}`},
+		{"unknown target", `{"a": {"amount": "1", "currency": "USD"}, "to": "XYZ"}`, http.StatusBadRequest,
+			`{"error":"to: unknown currency: \"XYZ\"","code":"unknown_currency"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := post(t, srv.URL+"/v1/money/convert", tt.body)
+			assertBody(t, resp, tt.wantStatus, tt.wantBody)
+		})
+	}
+
+	writeRates(t, path, "from,to,rate,date\nUSD,EUR,0.95,2026-06-15\n")
+	assertBody(t, post(t, srv.URL+"/v1/money/rates/reload", ""), http.StatusOK, `{"rates":1}`)
+	resp := post(t, srv.URL+"/v1/money/convert", `{"a": {"amount": "100", "currency": "USD"}, "to": "EUR", "date": "2026-07-01"}`)
+	assertBody(t, resp, http.StatusOK,
+		`{"result":{"amount":"95.00","currency":"EUR"},"rate":{"from":"USD","to":"EUR","rate":"0.95","date":"2026-06-15"},"rounding_mode":"half_even"}`)
+
+	writeRates(t, path, "not,a,rates,file\n")
+	assertBody(t, post(t, srv.URL+"/v1/money/rates/reload", ""), http.StatusBadRequest, "")
+	resp = post(t, srv.URL+"/v1/money/convert", `{"a": {"amount": "100", "currency": "USD"}, "to": "EUR", "date": "2026-07-01"}`)
+	assertBody(t, resp, http.StatusOK, "")
+}
@@ -0,0 +1,184 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/igorgatis/sezzle/backend/pkg/internal/calculator"
+	"github.com/igorgatis/sezzle/backend/pkg/internal/numfmt"
+)
+
+// numberPattern matches the numeric strings accepted in place of numbers.
+const numberPattern = `^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?$`
+
+type OperandInfo struct {
+	Name        string `json:"name" example:"b"`
+	Description string `json:"description" example:"Divisor"`
+}
+
+type OperationError struct {
+	Code    string `json:"code" example:"division_by_zero"`
+	Message string `json:"message" example:"division by zero"`
+}
+
+type OperationInfo struct {
+	Name     string           `json:"name" example:"divide"`
+	Label    string           `json:"label" example:"Division"`
+	Symbol   string           `json:"symbol" example:"/"`
+	Summary  string           `json:"summary" example:"Divide two numbers"`
+	Path     string           `json:"path" example:"/v1/divide"`
+	Arity    int              `json:"arity" example:"2"`
+	Modes    []string         `json:"modes" example:"real,complex"`
+	Operands []OperandInfo    `json:"operands"`
+	Errors   []OperationError `json:"errors"`
+	// Schema is the JSON Schema of the request body.
+	Schema map[string]any `json:"schema" swaggertype:"object"`
+}
+
+type OperationList struct {
+	Operations []OperationInfo `json:"operations"`
+}
+
+// @Summary List supported operations with their request schemas
+// @Success 200 {object} OperationList
+// @Router /v1/operations [get]
+func operationsHandler(ops *calculator.Registry) gin.HandlerFunc {
+	list := OperationList{Operations: []OperationInfo{}}
+	for _, op := range ops.Operations() {
+		list.Operations = append(list.Operations, operationInfo(op))
+	}
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, list)
+	}
+}
+
+func operationInfo(op calculator.Operation) OperationInfo {
+	info := OperationInfo{
+		Name:     op.Name,
+		Label:    op.Label,
+		Symbol:   op.Symbol,
+		Summary:  op.Summary,
+		Path:     "/v1/" + op.Name,
+		Arity:    len(op.Operands),
+		Modes:    operationModes(op),
+		Operands: make([]OperandInfo, len(op.Operands)),
+		Errors:   make([]OperationError, len(op.Errors)),
+		Schema:   requestSchema(op),
+	}
+	for i, o := range op.Operands {
+		info.Operands[i] = OperandInfo{Name: o.Name, Description: o.Description}
+	}
+	for i, e := range op.Errors {
+		info.Errors[i] = OperationError{Code: e.Code, Message: e.Message}
+	}
+	return info
+}
+
+func operationModes(op calculator.Operation) []string {
+	var modes []string
+	if op.Eval != nil {
+		modes = append(modes, string(calculator.Real))
+	}
+	if op.ComplexEval != nil {
+		modes = append(modes, string(calculator.Complex))
+	}
+	return modes
+}
+
+func requestSchema(op calculator.Operation) map[string]any {
+	props := make(map[string]any, len(op.Operands))
+	required := make([]string, len(op.Operands))
+	for i, o := range op.Operands {
+		anyOf := []any{
+			map[string]any{"type": "number"},
+			map[string]any{"type": "string", "pattern": numberPattern},
+			map[string]any{"type": "string", "description": "Number in the request locale, e.g. 1.234,5 in de-DE"},
+		}
+		if op.ComplexEval != nil {
+			anyOf = append(anyOf,
+				map[string]any{"type": "string", "description": "Complex number such as 3+4i"},
+				complexSchema())
+		}
+		props[o.Name] = map[string]any{"description": o.Description, "anyOf": anyOf}
+		required[i] = o.Name
+	}
+	if op.AngleEval != nil {
+		props[AngleUnitField] = angleUnitSchema()
+	}
+	props[FormatField] = formatSchema()
+	props[RoundingField] = roundingSchema()
+	props[LocaleField] = map[string]any{
+		"description": "Locale of operands given as strings, also the default locale of format",
+		"type":        "string",
+		"enum":        numfmt.Locales(),
+	}
+	if modes := operationModes(op); len(modes) > 1 || op.Eval == nil {
+		mode := map[string]any{"description": "Number domain", "type": "string", "enum": modes}
+		if op.Eval != nil {
+			mode["default"] = string(calculator.Real)
+		} else {
+			required = append(required, ModeField)
+		}
+		props[ModeField] = mode
+	}
+	return map[string]any{
+		"$schema":    "https://json-schema.org/draft/2020-12/schema",
+		"title":      op.Label,
+		"type":       "object",
+		"properties": props,
+		"required":   required,
+	}
+}
+
+func angleUnitSchema() map[string]any {
+	units := make([]string, len(calculator.AngleUnits))
+	for i, u := range calculator.AngleUnits {
+		units[i] = string(u)
+	}
+	return map[string]any{
+		"description": "Unit of angles",
+		"type":        "string",
+		"enum":        units,
+		"default":     string(calculator.Radians),
+	}
+}
+
+func complexSchema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"re": map[string]any{"type": "number"},
+			"im": map[string]any{"type": "number"},
+		},
+		"required": []string{"re", "im"},
+	}
+}
+
+func formatSchema() map[string]any {
+	return map[string]any{
+		"description": "Formatted variants to include in the response",
+		"type":        "object",
+		"properties": map[string]any{
+			"base":        map[string]any{"type": "integer", "minimum": 2, "maximum": 36},
+			"decimals":    map[string]any{"type": "integer", "minimum": 0, "maximum": numfmt.MaxPrecision},
+			"significant": map[string]any{"type": "integer", "minimum": 1, "maximum": numfmt.MaxPrecision},
+			"locale":      map[string]any{"type": "string", "enum": numfmt.Locales()},
+		},
+	}
+}
+
+func roundingSchema() map[string]any {
+	modes := make([]string, len(calculator.RoundingModes))
+	for i, m := range calculator.RoundingModes {
+		modes[i] = string(m)
+	}
+	return map[string]any{
+		"description": "Rounding of the result, by decimal places or significant digits",
+		"type":        "object",
+		"properties": map[string]any{
+			"mode":        map[string]any{"type": "string", "enum": modes, "default": string(calculator.HalfEven)},
+			"decimals":    map[string]any{"type": "integer", "minimum": 0, "maximum": calculator.MaxRoundingDigits},
+			"significant": map[string]any{"type": "integer", "minimum": 1, "maximum": calculator.MaxRoundingDigits},
+		},
+	}
+}
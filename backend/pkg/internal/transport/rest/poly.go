@@ -0,0 +1,230 @@
+package rest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/igorgatis/sezzle/backend/pkg/internal/numeric"
+	"github.com/igorgatis/sezzle/backend/pkg/internal/poly"
+)
+
+type PolynomialBinaryRequest struct {
+	A []float64 `json:"a" binding:"required" example:"-1,0,1"`
+	B []float64 `json:"b" binding:"required" example:"1,1"`
+}
+
+type PolynomialEvaluateRequest struct {
+	Coefficients []float64 `json:"coefficients" binding:"required" example:"1,0,2"`
+	X            []float64 `json:"x" binding:"required" example:"0,1,2"`
+}
+
+type PolynomialDerivativeRequest struct {
+	Coefficients []float64 `json:"coefficients" binding:"required" example:"1,0,2"`
+	// Order is the number of times to differentiate, 1 when omitted.
+	Order int `json:"order" example:"1" default:"1"`
+}
+
+type PolynomialRootsRequest struct {
+	Coefficients []float64 `json:"coefficients" binding:"required" example:"2,-3,1"`
+	// MaxIterations limits the Durand-Kerner iterations, 500 when omitted.
+	MaxIterations int `json:"max_iterations" example:"500"`
+}
+
+type PolynomialResponse struct {
+	Coefficients []float64 `json:"coefficients" example:"0,1"`
+	Degree       int       `json:"degree" example:"1"`
+}
+
+type PolynomialDivisionResponse struct {
+	Quotient  PolynomialResponse `json:"quotient"`
+	Remainder PolynomialResponse `json:"remainder"`
+}
+
+type PolynomialRootsResponse struct {
+	Roots      []ComplexNumber `json:"roots"`
+	Iterations int             `json:"iterations" example:"6"`
+}
+
+// RegisterPolynomialV1 serves polynomial operations at /v1/polynomial.
+// Polynomials are arrays of at most 1001 coefficients from the constant term
+// up, so [1, 0, 2] is 1 + 2x². Results have no trailing zero coefficients,
+// the zero polynomial being [].
+func RegisterPolynomialV1(r gin.IRouter) {
+	g := r.Group("/v1/polynomial")
+	g.POST("/add", polynomialBinaryHandler(poly.Poly.Add))
+	g.POST("/subtract", polynomialBinaryHandler(poly.Poly.Sub))
+	g.POST("/multiply", polynomialBinaryHandler(poly.Poly.Mul))
+	g.POST("/divide", polynomialDivideHandler)
+	g.POST("/evaluate", polynomialEvaluateHandler)
+	g.POST("/derivative", polynomialDerivativeHandler)
+	g.POST("/roots", polynomialRootsHandler)
+}
+
+// @Summary Add, subtract or multiply two polynomials
+// @Param operation path string true "Operation" Enums(add, subtract, multiply)
+// @Param input body PolynomialBinaryRequest true "Coefficients, constant term first"
+// @Success 200 {object} PolynomialResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /v1/polynomial/{operation} [post]
+func polynomialBinaryHandler(op func(a, b poly.Poly) (poly.Poly, error)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		a, b, ok := bindPolynomials(c)
+		if !ok {
+			return
+		}
+		p, err := op(a, b)
+		if err != nil {
+			writeErrorResponse(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, polynomialResponse(p))
+	}
+}
+
+// @Summary Long division of two polynomials
+// @Description Quotient and remainder with a = quotient b + remainder, the remainder of lower degree than b. Dividing by the zero polynomial fails with division_by_zero.
+// @Param input body PolynomialBinaryRequest true "Dividend a and divisor b, constant term first"
+// @Success 200 {object} PolynomialDivisionResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /v1/polynomial/divide [post]
+func polynomialDivideHandler(c *gin.Context) {
+	a, b, ok := bindPolynomials(c)
+	if !ok {
+		return
+	}
+	quo, rem, err := a.Div(b)
+	if err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, PolynomialDivisionResponse{Quotient: polynomialResponse(quo), Remainder: polynomialResponse(rem)})
+}
+
+// @Summary Evaluate a polynomial at points
+// @Description Evaluates by Horner's rule at each of up to 1000 points.
+// @Param input body PolynomialEvaluateRequest true "Coefficients, constant term first, and points"
+// @Success 200 {object} VectorResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /v1/polynomial/evaluate [post]
+func polynomialEvaluateHandler(c *gin.Context) {
+	var req PolynomialEvaluateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	p, err := parsePolynomial("coefficients", req.Coefficients)
+	if err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	x, err := parseVector("x", req.X)
+	if err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	y := make([]float64, len(x))
+	for i, xi := range x {
+		if y[i], err = p.Eval(xi); err != nil {
+			writeErrorResponse(c, err)
+			return
+		}
+	}
+	c.JSON(http.StatusOK, VectorResponse{Result: y})
+}
+
+// @Summary Derivative of a polynomial
+// @Param input body PolynomialDerivativeRequest true "Coefficients, constant term first, and order"
+// @Success 200 {object} PolynomialResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /v1/polynomial/derivative [post]
+func polynomialDerivativeHandler(c *gin.Context) {
+	var req PolynomialDerivativeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	if req.Order == 0 {
+		req.Order = 1
+	}
+	if req.Order < 1 {
+		writeErrorResponse(c, fmt.Errorf("%w: %d, want at least 1", numeric.ErrInvalidOrder, req.Order))
+		return
+	}
+	p, err := parsePolynomial("coefficients", req.Coefficients)
+	if err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	// Orders beyond the degree give the zero polynomial.
+	for range min(req.Order, len(p)) {
+		if p, err = p.Derivative(); err != nil {
+			writeErrorResponse(c, err)
+			return
+		}
+	}
+	c.JSON(http.StatusOK, polynomialResponse(p))
+}
+
+// @Summary All roots of a polynomial
+// @Description Real and complex roots by the Durand-Kerner method, repeated by multiplicity and sorted by real and then imaginary part. Non-real roots come in conjugate pairs. Roots of multiplicity m are accurate to about the m-th root of the machine epsilon. The zero polynomial fails with zero_polynomial, reaching max_iterations, 1 to 10000, with no_convergence and taking more than 2 seconds with timeout.
+// @Param input body PolynomialRootsRequest true "Coefficients, constant term first, and iteration limit"
+// @Success 200 {object} PolynomialRootsResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /v1/polynomial/roots [post]
+func polynomialRootsHandler(c *gin.Context) {
+	var req PolynomialRootsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	p, err := parsePolynomial("coefficients", req.Coefficients)
+	if err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	ctx, cancel := context.WithTimeout(c.Request.Context(), numericTimeout)
+	defer cancel()
+	r, err := p.Roots(ctx, req.MaxIterations)
+	if err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	roots := make([]ComplexNumber, len(r.Values))
+	for i, z := range r.Values {
+		roots[i] = ComplexNumber{Re: formatNumber(real(z)), Im: formatNumber(imag(z))}
+	}
+	c.JSON(http.StatusOK, PolynomialRootsResponse{Roots: roots, Iterations: r.Iterations})
+}
+
+func parsePolynomial(name string, c []float64) (poly.Poly, error) {
+	p, err := poly.New(c)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return p, nil
+}
+
+// bindPolynomials binds a PolynomialBinaryRequest, writing the error
+// response on failure.
+func bindPolynomials(c *gin.Context) (a, b poly.Poly, ok bool) {
+	var req PolynomialBinaryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeErrorResponse(c, err)
+		return nil, nil, false
+	}
+	var err error
+	if a, err = parsePolynomial("a", req.A); err == nil {
+		b, err = parsePolynomial("b", req.B)
+	}
+	if err != nil {
+		writeErrorResponse(c, err)
+		return nil, nil, false
+	}
+	return a, b, true
+}
+
+func polynomialResponse(p poly.Poly) PolynomialResponse {
+	return PolynomialResponse{Coefficients: append([]float64{}, p...), Degree: p.Degree()}
+}
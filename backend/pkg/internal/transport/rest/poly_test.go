@@ -0,0 +1,70 @@
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestPolynomial(t *testing.T) {
+	engine := gin.New()
+	RegisterPolynomialV1(engine)
+	srv := httptest.NewServer(engine)
+	defer srv.Close()
+
+	tests := []struct {
+		name       string
+		path       string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{"add", "/v1/polynomial/add", `{"a": [1, 2, 3], "b": [1, 1]}`, http.StatusOK,
+			`{"coefficients":[2,3,3],"degree":2}`},
+		{"subtract cancels", "/v1/polynomial/subtract", `{"a": [1, 2, 3], "b": [0, 2, 3]}`, http.StatusOK,
+			`{"coefficients":[1],"degree":0}`},
+		{"zero result", "/v1/polynomial/subtract", `{"a": [1, 2], "b": [1, 2]}`, http.StatusOK,
+			`{"coefficients":[],"degree":-1}`},
+		{"multiply", "/v1/polynomial/multiply", `{"a": [-1, 1], "b": [1, 1]}`, http.StatusOK,
+			`{"coefficients":[-1,0,1],"degree":2}`},
+		{"divide", "/v1/polynomial/divide", `{"a": [-4, 0, -2, 1], "b": [-3, 1]}`, http.StatusOK,
+			`{"quotient":{"coefficients":[3,1,1],"degree":2},"remainder":{"coefficients":[5],"degree":0}}`},
+		{"divide by zero", "/v1/polynomial/divide", `{"a": [1], "b": [0, 0]}`, http.StatusBadRequest,
+			`{"error":"division by zero: polynomial division","code":"division_by_zero"}`},
+		{"evaluate", "/v1/polynomial/evaluate", `{"coefficients": [1, 0, 2], "x": [0, 1, -2]}`, http.StatusOK,
+			`{"result":[1,3,9]}`},
+		{"evaluate overflow", "/v1/polynomial/evaluate", `{"coefficients": [0, 0, 1], "x": [1e200]}`,
+			http.StatusBadRequest, `{"error":"result overflows: p(1e+200)","code":"overflow"}`},
+		{"derivative", "/v1/polynomial/derivative", `{"coefficients": [5, 3, 0, 2]}`, http.StatusOK,
+			`{"coefficients":[3,0,6],"degree":2}`},
+		{"second derivative", "/v1/polynomial/derivative", `{"coefficients": [5, 3, 0, 2], "order": 2}`,
+			http.StatusOK, `{"coefficients":[0,12],"degree":1}`},
+		{"derivative beyond degree", "/v1/polynomial/derivative", `{"coefficients": [5, 3], "order": 1000000}`,
+			http.StatusOK, `{"coefficients":[],"degree":-1}`},
+		{"invalid order", "/v1/polynomial/derivative", `{"coefficients": [1], "order": -1}`, http.StatusBadRequest,
+			`{"error":"invalid derivative order: -1, want at least 1","code":"invalid_order"}`},
+		{"real roots", "/v1/polynomial/roots", `{"coefficients": [2, -3, 1]}`, http.StatusOK, ""},
+		{"complex roots", "/v1/polynomial/roots", `{"coefficients": [0, 1, 0, 1]}`, http.StatusOK,
+			`{"roots":[{"re":0,"im":-1},{"re":0,"im":0},{"re":0,"im":1}],"iterations":6}`},
+		{"constant", "/v1/polynomial/roots", `{"coefficients": [3]}`, http.StatusOK, `{"roots":[],"iterations":0}`},
+		{"zero polynomial", "/v1/polynomial/roots", `{"coefficients": [0]}`, http.StatusBadRequest,
+			`{"error":"polynomial is zero","code":"zero_polynomial"}`},
+		{"iteration limit", "/v1/polynomial/roots", `{"coefficients": [1, 2, 3, 4, 5, 6], "max_iterations": 2}`,
+			http.StatusBadRequest,
+			`{"error":"root finding did not converge: 2 iterations of Durand-Kerner","code":"no_convergence"}`},
+		{"invalid limit", "/v1/polynomial/roots", `{"coefficients": [1, 1], "max_iterations": -1}`,
+			http.StatusBadRequest,
+			`{"error":"invalid iteration limit: -1, want 1 to 10000","code":"invalid_iteration_limit"}`},
+		{"empty", "/v1/polynomial/add", `{"a": [], "b": [1]}`, http.StatusBadRequest,
+			`{"error":"a: invalid polynomial: 0 coefficients, want 1 to 1001","code":"invalid_polynomial"}`},
+		{"missing coefficients", "/v1/polynomial/roots", `{}`, http.StatusBadRequest, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := post(t, srv.URL+tt.path, tt.body)
+			assertBody(t, resp, tt.wantStatus, tt.wantBody)
+		})
+	}
+}
@@ -0,0 +1,317 @@
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/igorgatis/sezzle/backend/pkg/internal/calculator"
+	"github.com/igorgatis/sezzle/backend/pkg/internal/money"
+)
+
+type TaxRequest struct {
+	A    *Money      `json:"a" binding:"required"`
+	Rate json.Number `json:"rate" binding:"required" swaggertype:"string" example:"8.25"`
+	// Inclusive tells that a already includes the tax, which is then
+	// removed rather than added.
+	Inclusive    bool   `json:"inclusive" example:"false"`
+	RoundingMode string `json:"rounding_mode" enums:"half_up,half_even,down,up,ceiling,floor" default:"half_even"`
+}
+
+type TaxResponse struct {
+	Net          Money  `json:"net"`
+	Tax          Money  `json:"tax"`
+	Gross        Money  `json:"gross"`
+	Rate         string `json:"rate" example:"8.25"`
+	Inclusive    bool   `json:"inclusive" example:"false"`
+	RoundingMode string `json:"rounding_mode" example:"half_even"`
+}
+
+type DiscountRequest struct {
+	A        *Money        `json:"a" binding:"required"`
+	Percents []json.Number `json:"percents" binding:"required" swaggertype:"array,string" example:"10,20"`
+	// Stacking is sequential (10% then 20% is 28% off) or additive (30%
+	// off).
+	Stacking     string `json:"stacking" enums:"sequential,additive" default:"sequential"`
+	RoundingMode string `json:"rounding_mode" enums:"half_up,half_even,down,up,ceiling,floor" default:"half_even"`
+}
+
+type DiscountStepInfo struct {
+	Percent string `json:"percent" example:"10"`
+	Before  Money  `json:"before"`
+	Amount  Money  `json:"amount"`
+	After   Money  `json:"after"`
+}
+
+type DiscountResponse struct {
+	Price Money `json:"price"`
+	Total Money `json:"total"`
+	Final Money `json:"final"`
+	// Percent is the effective discount on price.
+	Percent      string             `json:"percent" example:"28"`
+	Stacking     string             `json:"stacking" example:"sequential"`
+	Steps        []DiscountStepInfo `json:"steps"`
+	RoundingMode string             `json:"rounding_mode" example:"half_even"`
+}
+
+type PricingRequest struct {
+	Cost         *Money      `json:"cost" binding:"required"`
+	Percent      json.Number `json:"percent" binding:"required" swaggertype:"string" example:"25"`
+	RoundingMode string      `json:"rounding_mode" enums:"half_up,half_even,down,up,ceiling,floor" default:"half_even"`
+}
+
+// PricingResponse reports the markup (profit over cost) and margin (profit
+// over price) of the rounded price.
+type PricingResponse struct {
+	Cost         Money  `json:"cost"`
+	Price        Money  `json:"price"`
+	Profit       Money  `json:"profit"`
+	Markup       string `json:"markup" example:"25"`
+	Margin       string `json:"margin" example:"20"`
+	RoundingMode string `json:"rounding_mode" example:"half_even"`
+}
+
+type PercentChangeRequest struct {
+	From json.Number `json:"from" binding:"required" swaggertype:"string" example:"80"`
+	To   json.Number `json:"to" binding:"required" swaggertype:"string" example:"100"`
+}
+
+type PercentChangeResponse struct {
+	Change  string `json:"change" example:"20"`
+	Percent string `json:"percent" example:"25"`
+}
+
+type TipRequest struct {
+	Bill    *Money      `json:"bill" binding:"required"`
+	Percent json.Number `json:"percent" binding:"required" swaggertype:"string" example:"18"`
+	// People defaults to 1.
+	People       int    `json:"people" example:"3"`
+	RoundingMode string `json:"rounding_mode" enums:"half_up,half_even,down,up,ceiling,floor" default:"half_even"`
+}
+
+// TipResponse holds the share of each person, which add up to total and
+// differ by at most one minor unit.
+type TipResponse struct {
+	Bill         Money   `json:"bill"`
+	Tip          Money   `json:"tip"`
+	Total        Money   `json:"total"`
+	Shares       []Money `json:"shares"`
+	RoundingMode string  `json:"rounding_mode" example:"half_even"`
+}
+
+// @Summary Add or remove tax
+// @Description Exclusive amounts get rate% added; inclusive amounts are split into net and tax.
+// @Param input body TaxRequest true "Amount, tax rate and whether the amount includes it"
+// @Success 200 {object} TaxResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /v1/money/tax [post]
+func taxHandler(c *gin.Context) {
+	var req TaxRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	a, rate, mode, err := parsePercentRequest("a", req.A, "rate", req.Rate, req.RoundingMode)
+	if err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	tax := money.AddTax
+	if req.Inclusive {
+		tax = money.RemoveTax
+	}
+	t, err := tax(a, rate, mode)
+	if err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, TaxResponse{
+		Net:          formatMoney(t.Net),
+		Tax:          formatMoney(t.Tax),
+		Gross:        formatMoney(t.Gross),
+		Rate:         money.FormatDecimal(rate, decimalPlaces),
+		Inclusive:    req.Inclusive,
+		RoundingMode: string(mode),
+	})
+}
+
+// @Summary Apply one or more percent discounts
+// @Param input body DiscountRequest true "Price, discounts and how they stack"
+// @Success 200 {object} DiscountResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /v1/money/discount [post]
+func discountHandler(c *gin.Context) {
+	var req DiscountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	a, err := parseMoney("a", req.A)
+	if err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	percents := make([]*big.Rat, len(req.Percents))
+	for i, p := range req.Percents {
+		if percents[i], err = parseDecimal(fmt.Sprintf("percents[%d]", i), p); err != nil {
+			writeErrorResponse(c, err)
+			return
+		}
+	}
+	stacking, err := money.ParseStacking(req.Stacking)
+	if err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	mode, err := calculator.ParseRoundingMode(req.RoundingMode)
+	if err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	d, err := money.ApplyDiscounts(a, percents, stacking, mode)
+	if err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	resp := DiscountResponse{
+		Price:        formatMoney(d.Price),
+		Total:        formatMoney(d.Total),
+		Final:        formatMoney(d.Final),
+		Percent:      money.FormatDecimal(d.Percent, decimalPlaces),
+		Stacking:     string(stacking),
+		Steps:        make([]DiscountStepInfo, len(d.Steps)),
+		RoundingMode: string(mode),
+	}
+	for i, s := range d.Steps {
+		resp.Steps[i] = DiscountStepInfo{
+			Percent: money.FormatDecimal(s.Percent, decimalPlaces),
+			Before:  formatMoney(s.Before),
+			Amount:  formatMoney(s.Amount),
+			After:   formatMoney(s.After),
+		}
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// @Summary Price a cost with a markup or a margin
+// @Description markup: price = cost × (1 + percent/100). margin: price = cost / (1 − percent/100), percent below 100.
+// @Param basis path string true "Pricing basis" Enums(markup, margin)
+// @Param input body PricingRequest true "Cost and percent"
+// @Success 200 {object} PricingResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /v1/money/{basis} [post]
+func pricingHandler(price func(money.Money, *big.Rat, calculator.RoundingMode) (money.Pricing, error)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req PricingRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			writeErrorResponse(c, err)
+			return
+		}
+		cost, p, mode, err := parsePercentRequest("cost", req.Cost, "percent", req.Percent, req.RoundingMode)
+		if err != nil {
+			writeErrorResponse(c, err)
+			return
+		}
+		pr, err := price(cost, p, mode)
+		if err != nil {
+			writeErrorResponse(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, PricingResponse{
+			Cost:         formatMoney(pr.Cost),
+			Price:        formatMoney(pr.Price),
+			Profit:       formatMoney(pr.Profit),
+			Markup:       money.FormatDecimal(pr.Markup, decimalPlaces),
+			Margin:       money.FormatDecimal(pr.Margin, decimalPlaces),
+			RoundingMode: string(mode),
+		})
+	}
+}
+
+// @Summary Compute the percent change between two values
+// @Description The change is relative to the magnitude of from, so -50 to -25 is +50%.
+// @Param input body PercentChangeRequest true "Values"
+// @Success 200 {object} PercentChangeResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /v1/money/percent-change [post]
+func percentChangeHandler(c *gin.Context) {
+	var req PercentChangeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	from, err := parseDecimal("from", req.From)
+	if err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	to, err := parseDecimal("to", req.To)
+	if err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	change, pct, err := money.PercentChange(from, to)
+	if err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, PercentChangeResponse{
+		Change:  money.FormatDecimal(change, decimalPlaces),
+		Percent: money.FormatDecimal(pct, decimalPlaces),
+	})
+}
+
+// @Summary Add a tip and split the bill
+// @Param input body TipRequest true "Bill, tip percent and number of people"
+// @Success 200 {object} TipResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /v1/money/tip [post]
+func tipHandler(c *gin.Context) {
+	var req TipRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	bill, p, mode, err := parsePercentRequest("bill", req.Bill, "percent", req.Percent, req.RoundingMode)
+	if err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	people := req.People
+	if people == 0 {
+		people = 1
+	}
+	t, err := money.AddTip(bill, p, people, mode)
+	if err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	resp := TipResponse{
+		Bill:         formatMoney(t.Bill),
+		Tip:          formatMoney(t.Tip),
+		Total:        formatMoney(t.Total),
+		Shares:       make([]Money, len(t.Shares)),
+		RoundingMode: string(mode),
+	}
+	for i, s := range t.Shares {
+		resp.Shares[i] = formatMoney(s)
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// parsePercentRequest parses the amount, percent and rounding mode shared
+// by most pricing requests.
+func parsePercentRequest(amountName string, amount *Money, percentName string, percent json.Number, roundingMode string) (money.Money, *big.Rat, calculator.RoundingMode, error) {
+	m, err := parseMoney(amountName, amount)
+	if err != nil {
+		return money.Money{}, nil, "", err
+	}
+	p, err := parseDecimal(percentName, percent)
+	if err != nil {
+		return money.Money{}, nil, "", err
+	}
+	mode, err := calculator.ParseRoundingMode(roundingMode)
+	return m, p, mode, err
+}
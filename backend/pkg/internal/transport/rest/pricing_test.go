@@ -0,0 +1,64 @@
+package rest
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestPricing(t *testing.T) {
+	srv, _ := setupMoneyServer(t)
+
+	tests := []struct {
+		name       string
+		path       string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{"add tax", "/v1/money/tax", `{"a": {"amount": "19.99", "currency": "USD"}, "rate": 8.25}`, http.StatusOK,
+			`{"net":{"amount":"19.99","currency":"USD"},"tax":{"amount":"1.65","currency":"USD"},` +
+				`"gross":{"amount":"21.64","currency":"USD"},"rate":"8.25","inclusive":false,"rounding_mode":"half_even"}`},
+		{"remove tax", "/v1/money/tax", `{"a": {"amount": "10", "currency": "EUR"}, "rate": "19", "inclusive": true}`, http.StatusOK,
+			`{"net":{"amount":"8.40","currency":"EUR"},"tax":{"amount":"1.60","currency":"EUR"},` +
+				`"gross":{"amount":"10.00","currency":"EUR"},"rate":"19","inclusive":true,"rounding_mode":"half_even"}`},
+		{"negative tax", "/v1/money/tax", `{"a": {"amount": "10", "currency": "EUR"}, "rate": -5}`, http.StatusBadRequest,
+			`{"error":"percent out of range: -5","code":"invalid_percent"}`},
+		{"sequential discounts", "/v1/money/discount", `{"a": {"amount": "50", "currency": "USD"}, "percents": [10, "20"]}`, http.StatusOK,
+			`{"price":{"amount":"50.00","currency":"USD"},"total":{"amount":"14.00","currency":"USD"},` +
+				`"final":{"amount":"36.00","currency":"USD"},"percent":"28","stacking":"sequential","steps":[` +
+				`{"percent":"10","before":{"amount":"50.00","currency":"USD"},"amount":{"amount":"5.00","currency":"USD"},"after":{"amount":"45.00","currency":"USD"}},` +
+				`{"percent":"20","before":{"amount":"45.00","currency":"USD"},"amount":{"amount":"9.00","currency":"USD"},"after":{"amount":"36.00","currency":"USD"}}],` +
+				`"rounding_mode":"half_even"}`},
+		{"additive discounts", "/v1/money/discount", `{"a": {"amount": "50", "currency": "USD"}, "percents": [10, 20], "stacking": "additive"}`, http.StatusOK,
+			`{"price":{"amount":"50.00","currency":"USD"},"total":{"amount":"15.00","currency":"USD"},` +
+				`"final":{"amount":"35.00","currency":"USD"},"percent":"30","stacking":"additive","steps":[` +
+				`{"percent":"30","before":{"amount":"50.00","currency":"USD"},"amount":{"amount":"15.00","currency":"USD"},"after":{"amount":"35.00","currency":"USD"}}],` +
+				`"rounding_mode":"half_even"}`},
+		{"invalid stacking", "/v1/money/discount", `{"a": {"amount": "50", "currency": "USD"}, "percents": [10], "stacking": "max"}`,
+			http.StatusBadRequest, `{"error":"invalid discount stacking: \"max\"","code":"invalid_stacking"}`},
+		{"markup", "/v1/money/markup", `{"cost": {"amount": "80", "currency": "USD"}, "percent": 25}`, http.StatusOK,
+			`{"cost":{"amount":"80.00","currency":"USD"},"price":{"amount":"100.00","currency":"USD"},` +
+				`"profit":{"amount":"20.00","currency":"USD"},"markup":"25","margin":"20","rounding_mode":"half_even"}`},
+		{"margin", "/v1/money/margin", `{"cost": {"amount": "80", "currency": "USD"}, "percent": 20}`, http.StatusOK,
+			`{"cost":{"amount":"80.00","currency":"USD"},"price":{"amount":"100.00","currency":"USD"},` +
+				`"profit":{"amount":"20.00","currency":"USD"},"markup":"25","margin":"20","rounding_mode":"half_even"}`},
+		{"percent change", "/v1/money/percent-change", `{"from": 80, "to": "100"}`, http.StatusOK,
+			`{"change":"20","percent":"25"}`},
+		{"percent change from zero", "/v1/money/percent-change", `{"from": 0, "to": 1}`, http.StatusBadRequest,
+			`{"error":"division by zero","code":"division_by_zero"}`},
+		{"tip", "/v1/money/tip", `{"bill": {"amount": "87.35", "currency": "USD"}, "percent": 18, "people": 3}`, http.StatusOK,
+			`{"bill":{"amount":"87.35","currency":"USD"},"tip":{"amount":"15.72","currency":"USD"},"total":{"amount":"103.07","currency":"USD"},` +
+				`"shares":[{"amount":"34.36","currency":"USD"},{"amount":"34.36","currency":"USD"},{"amount":"34.35","currency":"USD"}],"rounding_mode":"half_even"}`},
+		{"tip yen", "/v1/money/tip", `{"bill": {"amount": "5000", "currency": "JPY"}, "percent": 15, "rounding_mode": "up"}`, http.StatusOK,
+			`{"bill":{"amount":"5000","currency":"JPY"},"tip":{"amount":"750","currency":"JPY"},"total":{"amount":"5750","currency":"JPY"},` +
+				`"shares":[{"amount":"5750","currency":"JPY"}],"rounding_mode":"up"}`},
+		{"tip invalid people", "/v1/money/tip", `{"bill": {"amount": "10", "currency": "USD"}, "percent": 15, "people": -2}`,
+			http.StatusBadRequest, `{"error":"invalid number of parts: -2","code":"invalid_parts"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := post(t, srv.URL+tt.path, tt.body)
+			assertBody(t, resp, tt.wantStatus, tt.wantBody)
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/igorgatis/sezzle/backend/pkg/internal/calculator"
+)
+
+// ProgrammerRequest holds the operands of a programmer mode operation as
+// JSON integers or as decimal, 0b, 0o or 0x prefixed strings.
+type ProgrammerRequest struct {
+	A        json.RawMessage `json:"a" swaggertype:"string" example:"0xff"`
+	B        json.RawMessage `json:"b" swaggertype:"string" example:"0x0f"`
+	Type     string          `json:"type" example:"uint8" enums:"int8,int16,int32,int64,uint8,uint16,uint32,uint64,big" default:"int64"`
+	Overflow string          `json:"overflow" example:"wrap" enums:"wrap,saturate,error" default:"wrap"`
+}
+
+// ProgrammerResponse holds the result in every supported base. Result is a
+// decimal string since it may not fit a JSON number.
+type ProgrammerResponse struct {
+	Result string `json:"result" example:"15"`
+	Hex    string `json:"hex" example:"0x0f"`
+	Octal  string `json:"octal" example:"0o17"`
+	Binary string `json:"binary" example:"0b00001111"`
+	Type   string `json:"type" example:"uint8"`
+	// Overflowed reports that the result was wrapped or saturated.
+	Overflowed bool `json:"overflowed" example:"false"`
+}
+
+type ProgrammerOperationInfo struct {
+	Name     string           `json:"name" example:"xor"`
+	Summary  string           `json:"summary" example:"Bitwise XOR"`
+	Path     string           `json:"path" example:"/v1/programmer/xor"`
+	Operands []OperandInfo    `json:"operands"`
+	Errors   []OperationError `json:"errors"`
+}
+
+type ProgrammerInfo struct {
+	Types      []string                  `json:"types" example:"int8,uint8,big"`
+	Overflows  []string                  `json:"overflows" example:"wrap,saturate,error"`
+	Operations []ProgrammerOperationInfo `json:"operations"`
+}
+
+// RegisterProgrammerV1 serves the integer operations of programmer mode at
+// POST /v1/programmer/{name}.
+func RegisterProgrammerV1(r gin.IRouter, ops []calculator.IntOperation) {
+	g := r.Group("/v1/programmer")
+	g.GET("", programmerInfoHandler(ops))
+	index := make(map[string]calculator.IntOperation, len(ops))
+	for _, op := range ops {
+		index[op.Name] = op
+	}
+	g.POST("/:op", programmerHandler(index))
+}
+
+// @Summary List programmer mode integer types, overflow modes and operations
+// @Success 200 {object} ProgrammerInfo
+// @Router /v1/programmer [get]
+func programmerInfoHandler(ops []calculator.IntOperation) gin.HandlerFunc {
+	info := ProgrammerInfo{}
+	for _, t := range calculator.IntTypes {
+		info.Types = append(info.Types, t.Name)
+	}
+	for _, o := range calculator.Overflows {
+		info.Overflows = append(info.Overflows, string(o))
+	}
+	for _, op := range ops {
+		oi := ProgrammerOperationInfo{
+			Name:     op.Name,
+			Summary:  op.Summary,
+			Path:     "/v1/programmer/" + op.Name,
+			Operands: make([]OperandInfo, len(op.Operands)),
+			Errors:   make([]OperationError, len(op.Errors)),
+		}
+		for i, o := range op.Operands {
+			oi.Operands[i] = OperandInfo{Name: o.Name, Description: o.Description}
+		}
+		for i, e := range op.Errors {
+			oi.Errors[i] = OperationError{Code: e.Code, Message: e.Message}
+		}
+		info.Operations = append(info.Operations, oi)
+	}
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, info)
+	}
+}
+
+// @Summary Evaluate an integer operation in programmer mode
+// @Description Operations: add, subtract, multiply, div, mod, negate, and, or, xor, not, shl, shr, rotl, rotr.
+// @Description Unary operations ignore b; for shifts and rotates b is the bit count.
+// @Param op path string true "Operation name"
+// @Param input body ProgrammerRequest true "Operands, integer type and overflow mode"
+// @Success 200 {object} ProgrammerResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /v1/programmer/{op} [post]
+func programmerHandler(ops map[string]calculator.IntOperation) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		op, ok := ops[c.Param("op")]
+		if !ok {
+			c.JSON(http.StatusNotFound, ErrorResponse{Error: "operation not found"})
+			return
+		}
+		var req ProgrammerRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			writeErrorResponse(c, err)
+			return
+		}
+		typ, err := calculator.ParseIntType(req.Type)
+		if err != nil {
+			writeErrorResponse(c, err)
+			return
+		}
+		overflow, err := calculator.ParseOverflow(req.Overflow)
+		if err != nil {
+			writeErrorResponse(c, err)
+			return
+		}
+		raw := []json.RawMessage{req.A, req.B}
+		args := make([]*big.Int, len(op.Operands))
+		for i, o := range op.Operands {
+			if len(raw[i]) == 0 || string(raw[i]) == "null" {
+				writeErrorResponse(c, fmt.Errorf("%w: %s", errMissingOperand, o.Name))
+				return
+			}
+			if args[i], err = parseInteger(raw[i], op.OperandType(i, typ)); err != nil {
+				writeErrorResponse(c, fmt.Errorf("%s: %w", o.Name, err))
+				return
+			}
+		}
+		result, err := op.Apply(typ, overflow, args)
+		if err != nil {
+			writeErrorResponse(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, ProgrammerResponse{
+			Result:     typ.Format(result.Value, 10),
+			Hex:        typ.Format(result.Value, 16),
+			Octal:      typ.Format(result.Value, 8),
+			Binary:     typ.Format(result.Value, 2),
+			Type:       typ.Name,
+			Overflowed: result.Overflowed,
+		})
+	}
+}
+
+func parseInteger(raw json.RawMessage, typ calculator.IntType) (*big.Int, error) {
+	var s string
+	if json.Unmarshal(raw, &s) != nil {
+		var n json.Number
+		if err := json.Unmarshal(raw, &n); err != nil {
+			return nil, calculator.ErrInvalidInteger
+		}
+		s = n.String()
+	}
+	return typ.Parse(s)
+}
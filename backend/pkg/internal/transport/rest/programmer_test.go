@@ -0,0 +1,64 @@
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/igorgatis/sezzle/backend/pkg/internal/calculator"
+)
+
+func TestProgrammer(t *testing.T) {
+	engine := gin.New()
+	RegisterProgrammerV1(engine, calculator.IntOperations())
+	srv := httptest.NewServer(engine)
+	defer srv.Close()
+
+	tests := []struct {
+		name       string
+		op         string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{"above 2^53", "/v1/programmer/add", `{"a": 9007199254740993, "b": "2"}`, http.StatusOK,
+			`{"result":"9007199254740995","hex":"0x0020000000000003","octal":"0o400000000000000003",` +
+				`"binary":"0b0000000000100000000000000000000000000000000000000000000000000011","type":"int64","overflowed":false}`},
+		{"hex mask", "/v1/programmer/and", `{"a": "0xff", "b": "0b1010", "type": "uint8"}`, http.StatusOK,
+			`{"result":"10","hex":"0x0a","octal":"0o12","binary":"0b00001010","type":"uint8","overflowed":false}`},
+		{"wrap", "/v1/programmer/add", `{"a": "127", "b": 1, "type": "int8"}`, http.StatusOK,
+			`{"result":"-128","hex":"0x80","octal":"0o200","binary":"0b10000000","type":"int8","overflowed":true}`},
+		{"saturate", "/v1/programmer/add", `{"a": "127", "b": 1, "type": "int8", "overflow": "saturate"}`, http.StatusOK,
+			`{"result":"127","hex":"0x7f","octal":"0o177","binary":"0b01111111","type":"int8","overflowed":true}`},
+		{"overflow error", "/v1/programmer/add", `{"a": "127", "b": 1, "type": "int8", "overflow": "error"}`,
+			http.StatusBadRequest, `{"error":"integer overflow","code":"integer_overflow"}`},
+		{"unary ignores b", "/v1/programmer/not", `{"a": "0x0f", "type": "uint8"}`, http.StatusOK,
+			`{"result":"240","hex":"0xf0","octal":"0o360","binary":"0b11110000","type":"uint8","overflowed":false}`},
+		{"shift count beyond type", "/v1/programmer/shr", `{"a": "0x80", "b": 300, "type": "uint8"}`, http.StatusOK,
+			`{"result":"0","hex":"0x00","octal":"0o0","binary":"0b00000000","type":"uint8","overflowed":false}`},
+		{"big", "/v1/programmer/shl", `{"a": 1, "b": 70, "type": "big"}`, http.StatusOK,
+			`{"result":"1180591620717411303424","hex":"0x400000000000000000","octal":"0o200000000000000000000000",` +
+				`"binary":"0b10000000000000000000000000000000000000000000000000000000000000000000000","type":"big","overflowed":false}`},
+		{"division by zero", "/v1/programmer/mod", `{"a": 1, "b": 0}`, http.StatusBadRequest,
+			`{"error":"division by zero","code":"division_by_zero"}`},
+		{"out of range", "/v1/programmer/add", `{"a": 256, "b": 0, "type": "uint8"}`, http.StatusBadRequest,
+			`{"error":"a: operand out of range for type: \"256\" for uint8","code":"integer_range"}`},
+		{"not an integer", "/v1/programmer/add", `{"a": 1.5, "b": 0}`, http.StatusBadRequest,
+			`{"error":"a: invalid integer: \"1.5\"","code":"invalid_integer"}`},
+		{"missing operand", "/v1/programmer/xor", `{"a": 1}`, http.StatusBadRequest,
+			`{"error":"missing operand: b"}`},
+		{"invalid type", "/v1/programmer/add", `{"a": 1, "b": 2, "type": "int128"}`, http.StatusBadRequest,
+			`{"error":"invalid integer type: \"int128\"","code":"invalid_int_type"}`},
+		{"invalid overflow", "/v1/programmer/add", `{"a": 1, "b": 2, "overflow": "ignore"}`, http.StatusBadRequest,
+			`{"error":"invalid overflow mode: \"ignore\"","code":"invalid_overflow"}`},
+		{"unknown operation", "/v1/programmer/nand", `{"a": 1, "b": 2}`, http.StatusNotFound,
+			`{"error":"operation not found"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := post(t, srv.URL+tt.op, tt.body)
+			assertBody(t, resp, tt.wantStatus, tt.wantBody)
+		})
+	}
+}
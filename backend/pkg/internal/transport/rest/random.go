@@ -0,0 +1,190 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/igorgatis/sezzle/backend/pkg/internal/random"
+)
+
+// RandomOptions are shared by the random requests. Identical requests with
+// the same seed return identical output.
+type RandomOptions struct {
+	// Seed makes the output reproducible. Without it values come from a
+	// cryptographically secure source.
+	Seed *uint64 `json:"seed" example:"42"`
+	// Count defaults to 1.
+	Count int `json:"count" example:"3" default:"1"`
+}
+
+// RandomSource tells where the output came from: "seeded" with the
+// request's seed, or "crypto" for a cryptographically secure source.
+type RandomSource struct {
+	Source string  `json:"source" enums:"seeded,crypto" example:"seeded"`
+	Seed   *uint64 `json:"seed,omitempty" example:"42"`
+}
+
+type RandomNumbersRequest struct {
+	Distribution string `json:"distribution" binding:"required" enums:"normal,binomial,poisson,exponential,uniform,t" example:"normal"`
+	// Params are named as in GET /v1/random/distributions.
+	Params map[string]float64 `json:"params" example:"mu:0,sigma:1"`
+	RandomOptions
+}
+
+type RandomNumbersResponse struct {
+	Values []float64 `json:"values" example:"0.3,-1.2,0.8"`
+	RandomSource
+}
+
+type RandomIntegersRequest struct {
+	// Min and Max are inclusive.
+	Min *int64 `json:"min" binding:"required" example:"1"`
+	Max *int64 `json:"max" binding:"required" example:"6"`
+	RandomOptions
+}
+
+type RandomIntegersResponse struct {
+	Values []int64 `json:"values" example:"4,1,6"`
+	RandomSource
+}
+
+type ShuffleRequest struct {
+	// Items may be any JSON values.
+	Items []json.RawMessage `json:"items" binding:"required" swaggertype:"array,string" example:"a,b,c"`
+	Seed  *uint64           `json:"seed" example:"42"`
+}
+
+type SampleRequest struct {
+	Items []json.RawMessage `json:"items" binding:"required" swaggertype:"array,string" example:"a,b,c"`
+	// Replace allows an item to be drawn more than once.
+	Replace bool `json:"replace" example:"false"`
+	RandomOptions
+}
+
+type ItemsResponse struct {
+	Items []json.RawMessage `json:"items" swaggertype:"array,string" example:"c,a,b"`
+	RandomSource
+}
+
+type DistributionInfo struct {
+	Name   string   `json:"name" example:"normal"`
+	Params []string `json:"params" example:"mu,sigma"`
+}
+
+func RegisterRandomV1(r gin.IRouter) {
+	g := r.Group("/v1/random")
+	g.GET("/distributions", distributionsHandler)
+	g.POST("/numbers", randomNumbersHandler)
+	g.POST("/integers", randomIntegersHandler)
+	g.POST("/shuffle", shuffleHandler)
+	g.POST("/sample", sampleHandler)
+}
+
+// @Summary List the distributions random numbers can be drawn from
+// @Success 200 {array} DistributionInfo
+// @Router /v1/random/distributions [get]
+func distributionsHandler(c *gin.Context) {
+	var infos []DistributionInfo
+	for _, d := range random.Distributions() {
+		infos = append(infos, DistributionInfo{Name: d.Name, Params: d.Params})
+	}
+	c.JSON(http.StatusOK, infos)
+}
+
+// @Summary Draw random numbers from a distribution
+// @Param input body RandomNumbersRequest true "Distribution, parameters, count and seed"
+// @Success 200 {object} RandomNumbersResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /v1/random/numbers [post]
+func randomNumbersHandler(c *gin.Context) {
+	var req RandomNumbersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	d, err := random.LookupDistribution(req.Distribution)
+	if err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	g, src := newGenerator(req.Seed)
+	values, err := g.Numbers(d, req.Params, req.count())
+	if err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, RandomNumbersResponse{Values: values, RandomSource: src})
+}
+
+// @Summary Draw random integers in a range
+// @Param input body RandomIntegersRequest true "Inclusive range, count and seed"
+// @Success 200 {object} RandomIntegersResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /v1/random/integers [post]
+func randomIntegersHandler(c *gin.Context) {
+	var req RandomIntegersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	g, src := newGenerator(req.Seed)
+	values, err := g.Integers(*req.Min, *req.Max, req.count())
+	if err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, RandomIntegersResponse{Values: values, RandomSource: src})
+}
+
+// @Summary Shuffle a list
+// @Param input body ShuffleRequest true "Items and seed"
+// @Success 200 {object} ItemsResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /v1/random/shuffle [post]
+func shuffleHandler(c *gin.Context) {
+	var req ShuffleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	g, src := newGenerator(req.Seed)
+	items, err := random.Shuffle(g, req.Items)
+	if err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, ItemsResponse{Items: items, RandomSource: src})
+}
+
+// @Summary Sample items from a list, with or without replacement
+// @Param input body SampleRequest true "Items, count, replacement and seed"
+// @Success 200 {object} ItemsResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /v1/random/sample [post]
+func sampleHandler(c *gin.Context) {
+	var req SampleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	g, src := newGenerator(req.Seed)
+	items, err := random.Sample(g, req.Items, req.count(), req.Replace)
+	if err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, ItemsResponse{Items: items, RandomSource: src})
+}
+
+func (o RandomOptions) count() int {
+	if o.Count == 0 {
+		return 1
+	}
+	return o.Count
+}
+
+func newGenerator(seed *uint64) (*random.Generator, RandomSource) {
+	g := random.New(seed)
+	return g, RandomSource{Source: string(g.Source), Seed: seed}
+}
@@ -0,0 +1,108 @@
+package rest
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRandom(t *testing.T) {
+	engine := gin.New()
+	RegisterRandomV1(engine)
+	srv := httptest.NewServer(engine)
+	defer srv.Close()
+
+	tests := []struct {
+		name       string
+		path       string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{"single value range", "/v1/random/integers", `{"min": 5, "max": 5, "count": 2, "seed": 1}`, http.StatusOK,
+			`{"values":[5,5],"source":"seeded","seed":1}`},
+		{"sample everything", "/v1/random/sample", `{"items": [{"id": 1}], "count": 3, "replace": true, "seed": 1}`,
+			http.StatusOK, `{"items":[{"id":1},{"id":1},{"id":1}],"source":"seeded","seed":1}`},
+		{"unknown distribution", "/v1/random/numbers", `{"distribution": "cauchy"}`, http.StatusBadRequest,
+			`{"error":"unknown distribution: \"cauchy\"","code":"unknown_distribution"}`},
+		{"missing parameter", "/v1/random/numbers", `{"distribution": "poisson", "params": {}}`, http.StatusBadRequest,
+			`{"error":"invalid distribution parameter: missing lambda","code":"invalid_distribution_parameter"}`},
+		{"invalid parameter", "/v1/random/numbers", `{"distribution": "poisson", "params": {"lambda": -1}}`,
+			http.StatusBadRequest,
+			`{"error":"invalid distribution parameter: lambda must be positive, got -1","code":"invalid_distribution_parameter"}`},
+		{"overflow", "/v1/random/numbers", `{"distribution": "uniform", "params": {"a": -1.7e308, "b": 1.7e308}, "seed": 1}`,
+			http.StatusBadRequest, `{"error":"result overflows: drawing from uniform","code":"overflow"}`},
+		{"invalid range", "/v1/random/integers", `{"min": 2, "max": 1}`, http.StatusBadRequest,
+			`{"error":"min must not exceed max: min 2, max 1","code":"invalid_range"}`},
+		{"missing max", "/v1/random/integers", `{"min": 1}`, http.StatusBadRequest, ""},
+		{"negative count", "/v1/random/integers", `{"min": 1, "max": 2, "count": -1}`, http.StatusBadRequest,
+			`{"error":"invalid count: -1, want 1 to 10000","code":"invalid_count"}`},
+		{"sample too large", "/v1/random/sample", `{"items": [1, 2], "count": 3}`, http.StatusBadRequest,
+			`{"error":"invalid count: 3 of 2 items without replacement","code":"invalid_count"}`},
+		{"negative seed", "/v1/random/shuffle", `{"items": [1], "seed": -1}`, http.StatusBadRequest, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := post(t, srv.URL+tt.path, tt.body)
+			assertBody(t, resp, tt.wantStatus, tt.wantBody)
+		})
+	}
+}
+
+func TestRandomReproducible(t *testing.T) {
+	engine := gin.New()
+	RegisterRandomV1(engine)
+	srv := httptest.NewServer(engine)
+	defer srv.Close()
+
+	read := func(path, body string) string {
+		resp := post(t, srv.URL+path, body)
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("%s status = %d", path, resp.StatusCode)
+		}
+		b, _ := io.ReadAll(resp.Body)
+		return string(b)
+	}
+	requests := []struct{ path, body string }{
+		{"/v1/random/numbers", `{"distribution": "normal", "params": {"mu": 0, "sigma": 1}, "count": 5, "seed": 42}`},
+		{"/v1/random/integers", `{"min": 1, "max": 1000000, "count": 5, "seed": 42}`},
+		{"/v1/random/shuffle", `{"items": ["a", "b", "c", "d", "e", "f", "g", "h"], "seed": 42}`},
+		{"/v1/random/sample", `{"items": ["a", "b", "c", "d", "e", "f", "g", "h"], "count": 4, "seed": 42}`},
+	}
+	for _, r := range requests {
+		t.Run(r.path, func(t *testing.T) {
+			if a, b := read(r.path, r.body), read(r.path, r.body); a != b {
+				t.Errorf("seeded responses differ: %s and %s", a, b)
+			}
+		})
+	}
+
+	var resp RandomIntegersResponse
+	body := `{"min": 1, "max": 1000000, "count": 5}`
+	if err := json.Unmarshal([]byte(read("/v1/random/integers", body)), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Source != "crypto" || resp.Seed != nil || len(resp.Values) != 5 {
+		t.Errorf("unseeded response = %+v", resp)
+	}
+}
+
+func TestDistributionsList(t *testing.T) {
+	engine := gin.New()
+	RegisterRandomV1(engine)
+	srv := httptest.NewServer(engine)
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/v1/random/distributions")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertBody(t, resp, http.StatusOK, `[{"name":"normal","params":["mu","sigma"]},{"name":"binomial","params":["n","prob"]},`+
+		`{"name":"poisson","params":["lambda"]},{"name":"exponential","params":["lambda"]},`+
+		`{"name":"uniform","params":["a","b"]},{"name":"t","params":["df"]}]`)
+}
@@ -0,0 +1,56 @@
+package rest
+
+import (
+	"fmt"
+
+	"github.com/igorgatis/sezzle/backend/pkg/internal/calculator"
+)
+
+// RoundingField is the optional request field selecting how results are
+// rounded.
+const RoundingField = "rounding"
+
+// RoundingOptions selects a rounding mode and either decimal places or
+// significant digits. Responses echo the policy applied.
+type RoundingOptions struct {
+	Mode        string `json:"mode,omitempty" enums:"half_up,half_even,down,up,ceiling,floor" example:"half_even"`
+	Decimals    *int   `json:"decimals,omitempty" example:"2"`
+	Significant *int   `json:"significant,omitempty"`
+}
+
+// resolveRounding applies opts, if any, over the default rounding def.
+func resolveRounding(def calculator.Rounding, opts *RoundingOptions) (calculator.Rounding, error) {
+	r := def
+	if opts != nil {
+		if opts.Decimals != nil && opts.Significant != nil {
+			return r, fmt.Errorf("%w: decimals and significant are exclusive", calculator.ErrInvalidRounding)
+		}
+		if opts.Mode != "" {
+			r.Mode = calculator.RoundingMode(opts.Mode)
+		}
+		switch {
+		case opts.Decimals != nil:
+			r.Scale, r.Digits = calculator.Decimals, *opts.Decimals
+		case opts.Significant != nil:
+			r.Scale, r.Digits = calculator.Significant, *opts.Significant
+		}
+	}
+	if r.Mode == "" {
+		r.Mode = calculator.HalfEven
+	}
+	return r, r.Validate()
+}
+
+func roundingInfo(r calculator.Rounding) *RoundingOptions {
+	if r.Scale == "" {
+		return nil
+	}
+	info := &RoundingOptions{Mode: string(r.Mode)}
+	digits := r.Digits
+	if r.Scale == calculator.Significant {
+		info.Significant = &digits
+	} else {
+		info.Decimals = &digits
+	}
+	return info
+}
@@ -0,0 +1,68 @@
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/igorgatis/sezzle/backend/pkg/internal/calculator"
+	"github.com/igorgatis/sezzle/backend/pkg/internal/expr"
+	"github.com/igorgatis/sezzle/backend/pkg/internal/storage"
+)
+
+func TestRounding(t *testing.T) {
+	ops := calculator.DefaultRegistry(calculator.New())
+	registry := expr.NewRegistry(expr.NewEnv(ops), storage.NewMemory[expr.Definition]())
+	def := calculator.Rounding{Mode: calculator.HalfUp, Scale: calculator.Decimals, Digits: 4}
+	rounded := gin.New()
+	RegisterCalculatorV1(rounded.Group("/default"), ops, def)
+	RegisterExpressionsV1(rounded.Group("/default"), registry, storage.NewMemory[*expr.Program](), def)
+	RegisterCalculatorV1(rounded, ops, calculator.Rounding{})
+	RegisterExpressionsV1(rounded, registry, storage.NewMemory[*expr.Program](), calculator.Rounding{})
+	srv := httptest.NewServer(rounded)
+	defer srv.Close()
+
+	tests := []struct {
+		name       string
+		url        string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{"no rounding", "/v1/divide", `{"a": 100, "b": 3}`, http.StatusOK, `{"result":33.333333333333336}`},
+		{"decimals", "/v1/divide", `{"a": 100, "b": 3, "rounding": {"decimals": 2}}`, http.StatusOK,
+			`{"result":33.33,"rounding":{"mode":"half_even","decimals":2}}`},
+		{"half up", "/v1/add", `{"a": 2.67, "b": 0.005, "rounding": {"mode": "half_up", "decimals": 2}}`,
+			http.StatusOK, `{"result":2.68,"rounding":{"mode":"half_up","decimals":2}}`},
+		{"ceiling", "/v1/divide", `{"a": -10, "b": 3, "rounding": {"mode": "ceiling", "decimals": 0}}`,
+			http.StatusOK, `{"result":-3,"rounding":{"mode":"ceiling","decimals":0}}`},
+		{"significant", "/v1/percentage", `{"a": 33, "b": 12345, "rounding": {"significant": 3}}`,
+			http.StatusOK, `{"result":4070,"rounding":{"mode":"half_even","significant":3}}`},
+		{"formatted after rounding", "/v1/divide",
+			`{"a": 1, "b": 3, "rounding": {"decimals": 3}, "format": {"decimals": 5}}`, http.StatusOK,
+			`{"result":0.333,"rounding":{"mode":"half_even","decimals":3},` +
+				`"formatted":{"scientific":"3.33e-1","engineering":"333e-3","fixed":"0.33300"}}`},
+		{"evaluate", "/v1/evaluate", `{"expression": "2/3", "rounding": {"mode": "down", "decimals": 2}}`,
+			http.StatusOK, `{"result":0.66,"rounding":{"mode":"down","decimals":2}}`},
+		{"default", "/default/v1/divide", `{"a": 2, "b": 3}`, http.StatusOK,
+			`{"result":0.6667,"rounding":{"mode":"half_up","decimals":4}}`},
+		{"default mode with request digits", "/default/v1/divide", `{"a": 2, "b": 3, "rounding": {"significant": 2}}`,
+			http.StatusOK, `{"result":0.67,"rounding":{"mode":"half_up","significant":2}}`},
+		{"default evaluate", "/default/v1/evaluate", `{"expression": "pi"}`, http.StatusOK,
+			`{"result":3.1416,"rounding":{"mode":"half_up","decimals":4}}`},
+		{"both", "/v1/divide", `{"a": 1, "b": 3, "rounding": {"decimals": 2, "significant": 2}}`,
+			http.StatusBadRequest,
+			`{"error":"invalid rounding precision: decimals and significant are exclusive","code":"invalid_rounding"}`},
+		{"invalid mode", "/v1/divide", `{"a": 1, "b": 3, "rounding": {"mode": "nearest", "decimals": 2}}`,
+			http.StatusBadRequest, `{"error":"invalid rounding mode: \"nearest\"","code":"invalid_rounding_mode"}`},
+		{"invalid digits", "/v1/divide", `{"a": 1, "b": 3, "rounding": {"decimals": -1}}`,
+			http.StatusBadRequest, `{"error":"invalid rounding precision: -1 decimals","code":"invalid_rounding"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := post(t, srv.URL+tt.url, tt.body)
+			assertBody(t, resp, tt.wantStatus, tt.wantBody)
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package rest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/igorgatis/sezzle/backend/pkg/internal/expr"
+	"github.com/igorgatis/sezzle/backend/pkg/internal/numeric"
+)
+
+// numericTimeout bounds the time the iterative numeric methods spend on
+// one request, on top of their iteration limits.
+const numericTimeout = 2 * time.Second
+
+type RootRequest struct {
+	Expression string `json:"expression" binding:"required" example:"x^3 - 2*x - 5"`
+	// Variable is the unknown, x when omitted.
+	Variable string `json:"variable" example:"x" default:"x"`
+	// Method defaults to brent when a bracket is given and newton
+	// otherwise.
+	Method string `json:"method" enums:"bisection,newton,secant,brent"`
+	// Bracket holds two points where the expression has opposite signs,
+	// required by bisection and brent.
+	Bracket []float64 `json:"bracket" example:"2,3"`
+	// Guess is the starting point of newton and secant.
+	Guess *float64 `json:"guess" example:"2"`
+	// Tolerance is the absolute error to reach, 1e-12 when omitted.
+	Tolerance float64 `json:"tolerance" example:"1e-12"`
+	// MaxIterations is 200 when omitted, up to 10000.
+	MaxIterations int    `json:"max_iterations" example:"200"`
+	AngleUnit     string `json:"angle_unit" enums:"radians,degrees,gradians" default:"radians"`
+}
+
+type RootResponse struct {
+	Root float64 `json:"root" example:"2.094551481542327"`
+	// Value is the expression at the root.
+	Value      float64 `json:"value" example:"3.552713678800501e-15"`
+	Method     string  `json:"method" example:"brent"`
+	Iterations int     `json:"iterations" example:"6"`
+	// Tolerance bounds the error in root: the half-width of the final
+	// bracket for bisection and brent, the last step for newton and
+	// secant, and 0 when value is exactly 0.
+	Tolerance float64 `json:"tolerance" example:"2.504663143554353e-13"`
+}
+
+// RegisterSolveV1 serves equation solving over expressions, which may call
+// the tenant's functions.
+func RegisterSolveV1(r gin.IRouter, registry *expr.Registry) {
+	r.POST("/v1/solve", solveExpressionHandler(registry))
+}
+
+// @Summary Find x where an expression is zero
+// @Description Solves expression = 0 for variable by bisection, Newton's method with a central-difference derivative, the secant method or Brent's method. Fails with no_convergence when max_iterations is reached or the method breaks down, and with timeout after 2 seconds.
+// @Param X-Tenant-ID header string false "Tenant scope of user-defined functions" default(default)
+// @Param input body RootRequest true "Expression, starting points and limits"
+// @Success 200 {object} RootResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /v1/solve [post]
+func solveExpressionHandler(registry *expr.Registry) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var input RootRequest
+		if err := c.ShouldBindJSON(&input); err != nil {
+			writeErrorResponse(c, err)
+			return
+		}
+		p := numeric.Problem{
+			Method:        numeric.Method(input.Method),
+			Guess:         input.Guess,
+			Tolerance:     input.Tolerance,
+			MaxIterations: input.MaxIterations,
+		}
+		if input.Bracket != nil {
+			if len(input.Bracket) != 2 {
+				writeErrorResponse(c, fmt.Errorf("%w: want 2 points, got %d", numeric.ErrInvalidBracket, len(input.Bracket)))
+				return
+			}
+			p.Bracket = (*[2]float64)(input.Bracket)
+		}
+		if p.Method == "" {
+			p.Method = numeric.Newton
+			if p.Bracket != nil {
+				p.Method = numeric.Brent
+			}
+		}
+		f, err := compileFunc(c, registry, input.Expression, input.Variable, input.AngleUnit)
+		if err != nil {
+			writeErrorResponse(c, err)
+			return
+		}
+		ctx, cancel := context.WithTimeout(c.Request.Context(), numericTimeout)
+		defer cancel()
+		root, err := numeric.Solve(ctx, f, p)
+		if err != nil {
+			writeErrorResponse(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, RootResponse{
+			Root:       root.X,
+			Value:      root.FX,
+			Method:     string(p.Method),
+			Iterations: root.Iterations,
+			Tolerance:  root.Tolerance,
+		})
+	}
+}
+
+// compileFunc compiles expression as a function of variable, x when empty,
+// in the tenant's environment.
+func compileFunc(c *gin.Context, registry *expr.Registry, expression, variable, angleUnit string) (numeric.Func, error) {
+	if variable == "" {
+		variable = "x"
+	}
+	env, err := tenantEnv(c, registry, angleUnit)
+	if err != nil {
+		return nil, err
+	}
+	prog, err := env.Compile(expression, []string{variable})
+	if err != nil {
+		return nil, err
+	}
+	return func(x float64) (float64, error) { return prog.Eval([]float64{x}) }, nil
+}
@@ -0,0 +1,61 @@
+package rest
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestSolve(t *testing.T) {
+	srv := setupExprServer()
+	defer srv.Close()
+	tests := []struct {
+		name       string
+		tenant     string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{"brent by default", "", `{"expression": "x^3 - 2*x - 5", "bracket": [2, 3]}`, http.StatusOK,
+			`{"root":2.094551481542327,"value":3.552713678800501e-15,"method":"brent","iterations":6,"tolerance":2.504663143554353e-13}`},
+		{"newton by default", "", `{"expression": "cos(x) - x", "guess": 1}`, http.StatusOK,
+			`{"root":0.7390851332151607,"value":0,"method":"newton","iterations":4,"tolerance":0}`},
+		{"bisection", "", `{"expression": "x^2 - 2", "method": "bisection", "bracket": [0, 2], "tolerance": 1e-6}`,
+			http.StatusOK,
+			`{"root":1.4142141342163086,"value":0.0000016174171832972206,"method":"bisection","iterations":20,"tolerance":9.5367431640625e-7}`},
+		{"secant", "", `{"expression": "x^2 - 2", "method": "secant", "guess": 1}`, http.StatusOK,
+			`{"root":1.4142135623730951,"value":4.440892098500626e-16,"method":"secant","iterations":7,"tolerance":1.5700924767944453e-16}`},
+		{"rate", "", `{"expression": "pv(r, 12, 90, 0) - 1000", "variable": "r", "bracket": [0, 1]}`,
+			http.StatusOK,
+			`{"root":0.012043456781418495,"value":4.433786671143025e-12,"method":"brent","iterations":7,"tolerance":2.500022758256115e-13}`},
+		{"degrees", "", `{"expression": "sin(a) - 0.5", "variable": "a", "angle_unit": "degrees", "bracket": [0, 90]}`,
+			http.StatusOK,
+			`{"root":30.000000000000004,"value":0,"method":"brent","iterations":7,"tolerance":0}`},
+		{"tenant function", "acme", `{"expression": "fee(x) - 7", "guess": 0}`, http.StatusOK,
+			`{"root":3,"value":0,"method":"newton","iterations":2,"tolerance":0}`},
+		{"same sign", "", `{"expression": "x^2 + 1", "bracket": [-1, 1]}`, http.StatusBadRequest,
+			`{"error":"invalid bracket: f(-1) = 2 and f(1) = 2 have the same sign","code":"invalid_bracket"}`},
+		{"bracket length", "", `{"expression": "x", "bracket": [1]}`, http.StatusBadRequest,
+			`{"error":"invalid bracket: want 2 points, got 1","code":"invalid_bracket"}`},
+		{"unknown method", "", `{"expression": "x", "method": "halley", "guess": 1}`, http.StatusBadRequest,
+			`{"error":"invalid method: \"halley\"","code":"invalid_method"}`},
+		{"missing guess", "", `{"expression": "x", "method": "newton"}`, http.StatusBadRequest,
+			`{"error":"an initial guess or bracket is required","code":"missing_guess"}`},
+		{"iteration limit", "", `{"expression": "x^2 - 2", "bracket": [0, 2], "method": "bisection", "max_iterations": 3}`,
+			http.StatusBadRequest,
+			`{"error":"root finding did not converge: after 3 iterations","code":"no_convergence"}`},
+		{"no root", "", `{"expression": "x^2 + 1", "guess": 0}`, http.StatusBadRequest,
+			`{"error":"root finding did not converge: zero derivative at 0","code":"no_convergence"}`},
+		{"unknown variable", "", `{"expression": "x + y", "guess": 0}`, http.StatusBadRequest,
+			`{"error":"unknown variable: y"}`},
+		{"domain error", "", `{"expression": "ln(x)", "bracket": [-1, 2]}`, http.StatusBadRequest,
+			`{"error":"f(-1): logarithm of non-positive number","code":"log_domain"}`},
+	}
+	defineFee := do(t, "PUT", srv.URL+"/v1/functions/fee", "acme", `{"params": ["x"], "body": "2*x + 1"}`)
+	assertBody(t, defineFee, http.StatusCreated, "")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := do(t, "POST", srv.URL+"/v1/solve", tt.tenant, tt.body)
+			assertBody(t, resp, tt.wantStatus, tt.wantBody)
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package rest
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/igorgatis/sezzle/backend/pkg/internal/calculator"
+	"github.com/igorgatis/sezzle/backend/pkg/internal/stats"
+)
+
+type StatsRequest struct {
+	Values []float64 `json:"values" binding:"required" example:"2,4,4,4,5,5,7,9"`
+	// Percentiles are between 0 and 100.
+	Percentiles []float64 `json:"percentiles" example:"10,90"`
+}
+
+type Quartiles struct {
+	Q1  float64 `json:"q1" example:"4"`
+	Q2  float64 `json:"q2" example:"4.5"`
+	Q3  float64 `json:"q3" example:"5.5"`
+	IQR float64 `json:"iqr" example:"1.5"`
+}
+
+type PercentileValue struct {
+	Percentile float64 `json:"percentile" example:"90"`
+	Value      float64 `json:"value" example:"7.6"`
+}
+
+// StatsResponse summarizes the values. Percentiles interpolate linearly
+// between the closest ranks. Sample variance and standard deviation are
+// null for a single value.
+type StatsResponse struct {
+	Count  int     `json:"count" example:"8"`
+	Sum    float64 `json:"sum" example:"40"`
+	Mean   float64 `json:"mean" example:"5"`
+	Median float64 `json:"median" example:"4.5"`
+	// Mode holds the most frequent values; it is empty when none repeats.
+	Mode               []float64         `json:"mode" example:"4"`
+	Min                float64           `json:"min" example:"2"`
+	Max                float64           `json:"max" example:"9"`
+	Range              float64           `json:"range" example:"7"`
+	PopulationVariance float64           `json:"population_variance" example:"4"`
+	SampleVariance     *float64          `json:"sample_variance" example:"4.571428571428571"`
+	PopulationStdDev   float64           `json:"population_std_dev" example:"2"`
+	SampleStdDev       *float64          `json:"sample_std_dev" example:"2.138089935299395"`
+	Quartiles          Quartiles         `json:"quartiles"`
+	Percentiles        []PercentileValue `json:"percentiles,omitempty"`
+}
+
+type RegressionRequest struct {
+	X []float64 `json:"x" binding:"required" example:"0,1,2,3"`
+	Y []float64 `json:"y" binding:"required" example:"1.1,2.9,5.1,6.9"`
+	// Degree is 1 for a line, up to 10.
+	Degree int `json:"degree" example:"1" default:"1"`
+	// Predict lists x values to evaluate the fit at.
+	Predict []float64 `json:"predict" example:"10"`
+}
+
+type Prediction struct {
+	X float64 `json:"x" example:"10"`
+	Y float64 `json:"y" example:"20.66"`
+}
+
+// RegressionResponse holds the coefficients in increasing order of power,
+// so a line is intercept then slope.
+type RegressionResponse struct {
+	Coefficients []float64    `json:"coefficients" example:"1.06,1.96"`
+	RSquared     float64      `json:"r_squared" example:"0.9983367983367983"`
+	Residuals    []float64    `json:"residuals" example:"0.04,-0.12,0.12,-0.04"`
+	Predictions  []Prediction `json:"predictions,omitempty"`
+}
+
+func RegisterStatsV1(r gin.IRouter) {
+	r.POST("/v1/stats", statsHandler)
+	r.POST("/v1/stats/regression", regressionHandler)
+}
+
+// @Summary Descriptive statistics of a list of numbers
+// @Param input body StatsRequest true "Values and percentiles"
+// @Success 200 {object} StatsResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /v1/stats [post]
+func statsHandler(c *gin.Context) {
+	var req StatsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	s, err := stats.Describe(req.Values)
+	if err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	resp := StatsResponse{
+		Count:              s.Count,
+		Sum:                s.Sum,
+		Mean:               s.Mean,
+		Median:             s.Median,
+		Mode:               s.Mode,
+		Min:                s.Min,
+		Max:                s.Max,
+		Range:              s.Range,
+		PopulationVariance: s.PopulationVariance,
+		PopulationStdDev:   s.PopulationStdDev,
+		Quartiles:          Quartiles{Q1: s.Q1, Q2: s.Median, Q3: s.Q3, IQR: s.IQR},
+	}
+	if resp.Mode == nil {
+		resp.Mode = []float64{}
+	}
+	if !math.IsNaN(s.SampleVariance) {
+		resp.SampleVariance, resp.SampleStdDev = &s.SampleVariance, &s.SampleStdDev
+	}
+	for _, p := range req.Percentiles {
+		v, err := s.Percentile(p)
+		if err != nil {
+			writeErrorResponse(c, err)
+			return
+		}
+		resp.Percentiles = append(resp.Percentiles, PercentileValue{Percentile: p, Value: v})
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// @Summary Least-squares linear or polynomial regression
+// @Description Fails with singular_system when the x values cannot determine the coefficients
+// @Description and with ill_conditioned when the fit is too sensitive to rounding.
+// @Param input body RegressionRequest true "Points, degree and x values to predict"
+// @Success 200 {object} RegressionResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /v1/stats/regression [post]
+func regressionHandler(c *gin.Context) {
+	var req RegressionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	if req.Degree == 0 {
+		req.Degree = 1
+	}
+	fit, err := stats.Polynomial(req.X, req.Y, req.Degree)
+	if err != nil {
+		writeErrorResponse(c, err)
+		return
+	}
+	resp := RegressionResponse{
+		Coefficients: fit.Coefficients,
+		RSquared:     fit.RSquared,
+		Residuals:    fit.Residuals,
+	}
+	for _, x := range req.Predict {
+		y := fit.Predict(x)
+		if math.IsInf(y, 0) || math.IsNaN(y) {
+			writeErrorResponse(c, fmt.Errorf("predict %g: %w", x, calculator.ErrOverflow))
+			return
+		}
+		resp.Predictions = append(resp.Predictions, Prediction{X: x, Y: y})
+	}
+	c.JSON(http.StatusOK, resp)
+}
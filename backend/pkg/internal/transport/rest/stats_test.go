@@ -0,0 +1,103 @@
+package rest
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestStats(t *testing.T) {
+	engine := gin.New()
+	RegisterStatsV1(engine)
+	srv := httptest.NewServer(engine)
+	defer srv.Close()
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{"summary", `{"values": [2, 4, 4, 4, 5, 5, 7, 9], "percentiles": [10, 90]}`, http.StatusOK,
+			`{"count":8,"sum":40,"mean":5,"median":4.5,"mode":[4],"min":2,"max":9,"range":7,` +
+				`"population_variance":4,"sample_variance":4.571428571428571,"population_std_dev":2,"sample_std_dev":2.138089935299395,` +
+				`"quartiles":{"q1":4,"q2":4.5,"q3":5.5,"iqr":1.5},"percentiles":[{"percentile":10,"value":3.4},{"percentile":90,"value":7.6}]}`},
+		{"single value", `{"values": [3]}`, http.StatusOK,
+			`{"count":1,"sum":3,"mean":3,"median":3,"mode":[],"min":3,"max":3,"range":0,` +
+				`"population_variance":0,"sample_variance":null,"population_std_dev":0,"sample_std_dev":null,` +
+				`"quartiles":{"q1":3,"q2":3,"q3":3,"iqr":0}}`},
+		{"empty", `{"values": []}`, http.StatusBadRequest, `{"error":"data set is empty","code":"empty_data"}`},
+		{"missing values", `{}`, http.StatusBadRequest, ""},
+		{"invalid percentile", `{"values": [1, 2], "percentiles": [150]}`, http.StatusBadRequest,
+			`{"error":"percentile must be between 0 and 100: 150","code":"invalid_percentile"}`},
+		{"overflow", `{"values": [1.7e308, 1.7e308]}`, http.StatusBadRequest,
+			`{"error":"result overflows","code":"overflow"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := post(t, srv.URL+"/v1/stats", tt.body)
+			assertBody(t, resp, tt.wantStatus, tt.wantBody)
+		})
+	}
+}
+
+func TestRegression(t *testing.T) {
+	engine := gin.New()
+	RegisterStatsV1(engine)
+	srv := httptest.NewServer(engine)
+	defer srv.Close()
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{"vertical line", `{"x": [2, 2, 2], "y": [1, 2, 3]}`, http.StatusBadRequest,
+			`{"error":"system is singular: need 2 distinct x values","code":"singular_system"}`},
+		{"too few points", `{"x": [1, 2], "y": [1, 2], "degree": 2}`, http.StatusBadRequest,
+			`{"error":"not enough points for the degree: degree 2 needs 3, got 2","code":"too_few_points"}`},
+		{"length mismatch", `{"x": [1, 2], "y": [1]}`, http.StatusBadRequest,
+			`{"error":"x and y must have the same length: 2 and 1","code":"length_mismatch"}`},
+		{"invalid degree", `{"x": [1, 2], "y": [1, 2], "degree": -1}`, http.StatusBadRequest,
+			`{"error":"invalid polynomial degree: -1, want 1 to 10","code":"invalid_degree"}`},
+		{"large y", `{"x": [1, 2, 3], "y": [1e308, -1e308, 1e308]}`, http.StatusOK,
+			`{"coefficients":[3.3333333333333197e+307,5.645088868149834e+292],"r_squared":-4.440892098500626e-16,` +
+				`"residuals":[6.666666666666675e+307,-1.333333333333333e+308,6.666666666666663e+307]}`},
+		{"overflow", `{"x": [1, 2, 3], "y": [1.7e308, -1.7e308, 1.7e308], "degree": 2}`, http.StatusBadRequest,
+			`{"error":"result overflows","code":"overflow"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := post(t, srv.URL+"/v1/stats/regression", tt.body)
+			assertBody(t, resp, tt.wantStatus, tt.wantBody)
+		})
+	}
+}
+
+func TestRegressionFit(t *testing.T) {
+	engine := gin.New()
+	RegisterStatsV1(engine)
+	srv := httptest.NewServer(engine)
+	defer srv.Close()
+
+	resp := post(t, srv.URL+"/v1/stats/regression", `{"x": [-1, 0, 1, 2], "y": [2, 1, 2, 5], "degree": 2, "predict": [3]}`)
+	var fit RegressionResponse
+	if err := json.NewDecoder(resp.Body).Decode(&fit); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	want := []float64{1, 0, 1}
+	for i, c := range fit.Coefficients {
+		if math.Abs(c-want[i]) > 1e-12 {
+			t.Errorf("coefficients = %v, want %v", fit.Coefficients, want)
+		}
+	}
+	if math.Abs(fit.RSquared-1) > 1e-12 || len(fit.Residuals) != 4 ||
+		len(fit.Predictions) != 1 || math.Abs(fit.Predictions[0].Y-10) > 1e-12 {
+		t.Errorf("unexpected fit: %+v", fit)
+	}
+}
@@ -0,0 +1,100 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	swaggerFiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
+
+	"github.com/igorgatis/sezzle/backend/pkg/internal/calculator"
+	"github.com/igorgatis/sezzle/backend/pkg/internal/numfmt"
+)
+
+// SwaggerHandler serves the Swagger UI for base, the swag generated
+// document, extended with the routes generated from ops. It must be mounted
+// at /swagger/*any.
+func SwaggerHandler(base string, ops *calculator.Registry) (gin.HandlerFunc, error) {
+	var doc map[string]any
+	if err := json.Unmarshal([]byte(base), &doc); err != nil {
+		return nil, err
+	}
+	paths, _ := doc["paths"].(map[string]any)
+	if paths == nil {
+		paths = make(map[string]any)
+		doc["paths"] = paths
+	}
+	for _, op := range ops.Operations() {
+		paths["/v1/"+op.Name] = operationPath(op)
+	}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	ui := ginSwagger.WrapHandler(swaggerFiles.Handler)
+	return func(c *gin.Context) {
+		if c.Param("any") == "/doc.json" {
+			c.Data(http.StatusOK, "application/json; charset=utf-8", data)
+			return
+		}
+		ui(c)
+	}, nil
+}
+
+func operationPath(op calculator.Operation) map[string]any {
+	props := make(map[string]any, len(op.Operands))
+	required := make([]string, len(op.Operands))
+	for i, o := range op.Operands {
+		props[o.Name] = map[string]any{"type": "number", "description": o.Description}
+		required[i] = o.Name
+	}
+	if op.AngleEval != nil {
+		props[AngleUnitField] = angleUnitSchema()
+	}
+	props[FormatField] = map[string]any{"$ref": "#/definitions/rest.FormatOptions"}
+	props[RoundingField] = map[string]any{"$ref": "#/definitions/rest.RoundingOptions"}
+	props[LocaleField] = map[string]any{"type": "string", "enum": numfmt.Locales(),
+		"description": "Locale of operands given as strings"}
+	ok := "OK"
+	if op.ComplexEval != nil {
+		props[ModeField] = map[string]any{"type": "string", "enum": operationModes(op)}
+		ok += "; in complex mode the result is {re, im} with text and polar forms"
+	}
+	badRequest := "Bad Request"
+	if len(op.Errors) > 0 {
+		codes := make([]string, len(op.Errors))
+		for i, e := range op.Errors {
+			codes[i] = e.Code
+		}
+		badRequest += ": invalid input or " + strings.Join(codes, ", ")
+	}
+	return map[string]any{
+		"post": map[string]any{
+			"summary": op.Summary,
+			"parameters": []any{map[string]any{
+				"in":          "body",
+				"name":        "input",
+				"description": "Operands",
+				"required":    true,
+				"schema": map[string]any{
+					"type":       "object",
+					"required":   required,
+					"properties": props,
+				},
+			}},
+			"responses": map[string]any{
+				"200": map[string]any{
+					"description": ok,
+					"schema":      map[string]any{"$ref": "#/definitions/rest.Response"},
+				},
+				"400": map[string]any{
+					"description": badRequest,
+					"schema":      map[string]any{"$ref": "#/definitions/rest.ErrorResponse"},
+				},
+			},
+		},
+	}
+}
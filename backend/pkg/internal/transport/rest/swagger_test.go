@@ -0,0 +1,66 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/igorgatis/sezzle/backend/pkg/internal/calculator"
+)
+
+func TestSwaggerHandler(t *testing.T) {
+	base := `{"swagger": "2.0", "paths": {"/v1/evaluate": {}}}`
+	h, err := SwaggerHandler(base, calculator.DefaultRegistry(calculator.New()))
+	if err != nil {
+		t.Fatalf("SwaggerHandler error = %v", err)
+	}
+	engine := gin.New()
+	engine.GET("/swagger/*any", h)
+	srv := httptest.NewServer(engine)
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/swagger/doc.json")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var doc struct {
+		Paths map[string]map[string]struct {
+			Summary    string `json:"summary"`
+			Parameters []struct {
+				Schema struct {
+					Required []string `json:"required"`
+				} `json:"schema"`
+			} `json:"parameters"`
+		} `json:"paths"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		t.Fatalf("failed to decode doc: %v", err)
+	}
+	if _, ok := doc.Paths["/v1/evaluate"]; !ok {
+		t.Error("base path /v1/evaluate missing")
+	}
+	divide := doc.Paths["/v1/divide"]["post"]
+	if divide.Summary != "Divide two numbers" {
+		t.Errorf("divide summary = %q", divide.Summary)
+	}
+	if len(divide.Parameters) != 1 || len(divide.Parameters[0].Schema.Required) != 2 {
+		t.Errorf("divide parameters = %+v", divide.Parameters)
+	}
+
+	resp, err = http.Get(srv.URL + "/swagger/index.html")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("index status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+
+	if _, err := SwaggerHandler("{invalid", calculator.NewRegistry()); err == nil {
+		t.Error("expected error for invalid base document")
+	}
+}
@@ -0,0 +1,139 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/igorgatis/sezzle/backend/pkg/internal/expr"
+	"github.com/igorgatis/sezzle/backend/pkg/internal/numeric"
+)
+
+// Symbolic derivatives grow with each order, so both their order and their
+// size before simplification are bounded.
+const (
+	maxSymbolicOrder = 10
+	maxSymbolicSize  = 5000
+)
+
+type SymbolicDerivativeRequest struct {
+	Expression string `json:"expression" binding:"required" example:"x^2*sin(x)"`
+	// Variable is the differentiation variable, x when omitted.
+	Variable string `json:"variable" example:"x" default:"x"`
+	// Order is the number of times to differentiate, up to 10.
+	Order int `json:"order" example:"1" default:"1"`
+}
+
+type SimplifyRequest struct {
+	Expression string `json:"expression" binding:"required" example:"x*x + 2*x - x + 0"`
+}
+
+type SymbolicResponse struct {
+	// Expression evaluates with /v1/evaluate given its variables.
+	Expression string `json:"expression" example:"2*x*sin(x) + x^2*cos(x)"`
+	LaTeX      string `json:"latex" example:"2x \\cdot \\sin\\left(x\\right) + x^{2} \\cdot \\cos\\left(x\\right)"`
+}
+
+// RegisterSymbolicV1 serves symbolic differentiation and simplification of
+// expressions, which may call the tenant's functions.
+func RegisterSymbolicV1(r gin.IRouter, registry *expr.Registry) {
+	g := r.Group("/v1/symbolic")
+	g.POST("/derivative", symbolicDerivativeHandler(registry))
+	g.POST("/simplify", simplifyHandler(registry))
+}
+
+// @Summary Derivative of an expression as an expression
+// @Description Differentiates by the sum, product, quotient, power and chain rules, with functions of angles in radians, and simplifies the result. Functions without a differentiation rule, such as floor or user-defined ones, fail with no derivative rule, and derivatives of more than 5000 nodes with expression too large.
+// @Param X-Tenant-ID header string false "Tenant scope of user-defined functions" default(default)
+// @Param input body SymbolicDerivativeRequest true "Expression and variable"
+// @Success 200 {object} SymbolicResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /v1/symbolic/derivative [post]
+func symbolicDerivativeHandler(registry *expr.Registry) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var input SymbolicDerivativeRequest
+		if err := c.ShouldBindJSON(&input); err != nil {
+			writeErrorResponse(c, err)
+			return
+		}
+		if input.Variable == "" {
+			input.Variable = "x"
+		}
+		if input.Order == 0 {
+			input.Order = 1
+		}
+		if input.Order < 1 || input.Order > maxSymbolicOrder {
+			writeErrorResponse(c, fmt.Errorf("%w: %d, want 1 to %d", numeric.ErrInvalidOrder, input.Order, maxSymbolicOrder))
+			return
+		}
+		if !expr.IsIdent(input.Variable) {
+			writeErrorResponse(c, fmt.Errorf("%w: %q", expr.ErrInvalidVariable, input.Variable))
+			return
+		}
+		n, err := parseSymbolic(c, registry, input.Expression)
+		if err != nil {
+			writeErrorResponse(c, err)
+			return
+		}
+		for i := range input.Order {
+			if n, err = expr.Derivative(n, input.Variable); err != nil {
+				writeErrorResponse(c, err)
+				return
+			}
+			if size := expr.Size(n); size > maxSymbolicSize {
+				writeErrorResponse(c, fmt.Errorf("%w: derivative %d has %d nodes, limit is %d",
+					expr.ErrTooLarge, i+1, size, maxSymbolicSize))
+				return
+			}
+			n = expr.Simplify(n)
+		}
+		writeSymbolic(c, n)
+	}
+}
+
+// @Summary Simplify an expression
+// @Description Folds constants, exactly for integers and their fractions, combines like terms and powers of the same base, and eliminates identities such as x+0, x*1, x*0, x^1 and x^0. Like terms cancel regardless of domains, so x/x is 1.
+// @Param X-Tenant-ID header string false "Tenant scope of user-defined functions" default(default)
+// @Param input body SimplifyRequest true "Expression"
+// @Success 200 {object} SymbolicResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /v1/symbolic/simplify [post]
+func simplifyHandler(registry *expr.Registry) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var input SimplifyRequest
+		if err := c.ShouldBindJSON(&input); err != nil {
+			writeErrorResponse(c, err)
+			return
+		}
+		n, err := parseSymbolic(c, registry, input.Expression)
+		if err != nil {
+			writeErrorResponse(c, err)
+			return
+		}
+		writeSymbolic(c, expr.Simplify(n))
+	}
+}
+
+// parseSymbolic parses expression and checks its size and that it compiles
+// in the tenant's environment.
+func parseSymbolic(c *gin.Context, registry *expr.Registry, expression string) (expr.Node, error) {
+	n, err := expr.Parse(expression)
+	if err != nil {
+		return nil, err
+	}
+	if size := expr.Size(n); size > maxSymbolicSize {
+		return nil, fmt.Errorf("%w: %d nodes, limit is %d", expr.ErrTooLarge, size, maxSymbolicSize)
+	}
+	env, err := tenantEnv(c, registry, "")
+	if err != nil {
+		return nil, err
+	}
+	if err := env.Check(n); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+func writeSymbolic(c *gin.Context, n expr.Node) {
+	c.JSON(http.StatusOK, SymbolicResponse{Expression: expr.Format(n), LaTeX: expr.LaTeX(n)})
+}
@@ -0,0 +1,72 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestSymbolic(t *testing.T) {
+	srv := setupExprServer()
+	defer srv.Close()
+	do(t, "PUT", srv.URL+"/v1/functions/fee", "acme", `{"params": ["x"], "body": "x * 2"}`)
+	tests := []struct {
+		name       string
+		path       string
+		tenant     string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{"derivative", "/derivative", "", `{"expression": "x^2*sin(x)"}`, http.StatusOK,
+			`{"expression":"2*x*sin(x) + x^2*cos(x)","latex":"2x \\cdot \\sin\\left(x\\right) + x^{2} \\cdot \\cos\\left(x\\right)"}`},
+		{"derivative variable", "/derivative", "", `{"expression": "a*t^3 + t", "variable": "t"}`, http.StatusOK,
+			`{"expression":"3*a*t^2 + 1","latex":"3a \\cdot t^{2} + 1"}`},
+		{"second derivative", "/derivative", "", `{"expression": "x^4 - x", "order": 2}`, http.StatusOK,
+			`{"expression":"12*x^2","latex":"12x^{2}"}`},
+		{"rational exponent", "/derivative", "", `{"expression": "x^(1/3)"}`, http.StatusOK,
+			`{"expression":"1/(3*x^(2/3))","latex":"\\frac{1}{3x^{\\frac{2}{3}}}"}`},
+		{"no rule", "/derivative", "", `{"expression": "floor(x)"}`, http.StatusBadRequest,
+			`{"error":"no derivative rule for floor"}`},
+		{"user function", "/derivative", "acme", `{"expression": "fee(x)"}`, http.StatusBadRequest,
+			`{"error":"no derivative rule for fee"}`},
+		{"unknown function", "/derivative", "", `{"expression": "fee(x)"}`, http.StatusBadRequest,
+			`{"error":"unknown function: fee"}`},
+		{"invalid variable", "/derivative", "", `{"expression": "x", "variable": "2x"}`, http.StatusBadRequest,
+			`{"error":"invalid variable name: \"2x\""}`},
+		{"invalid order", "/derivative", "", `{"expression": "x", "order": 11}`, http.StatusBadRequest,
+			`{"error":"invalid derivative order: 11, want 1 to 10","code":"invalid_order"}`},
+		{"too large", "/derivative", "", `{"expression": "x^x", "order": 10}`, http.StatusBadRequest,
+			`{"error":"expression too large: derivative 8 has 6923 nodes, limit is 5000"}`},
+		{"syntax error", "/derivative", "", `{"expression": "x +"}`, http.StatusBadRequest,
+			`{"error":"syntax error: unexpected end of expression"}`},
+		{"simplify", "/simplify", "", `{"expression": "x*x + 2*x - x + 0"}`, http.StatusOK,
+			`{"expression":"x^2 + x","latex":"x^{2} + x"}`},
+		{"simplify fractions", "/simplify", "", `{"expression": "1/3 + 1/6 + y/2 + y/2"}`, http.StatusOK,
+			`{"expression":"y + 1/2","latex":"y + \\frac{1}{2}"}`},
+		{"simplify user function", "/simplify", "acme", `{"expression": "fee(x) - fee(x)*1"}`, http.StatusOK,
+			`{"expression":"0","latex":"0"}`},
+		{"simplify overflow", "/simplify", "", `{"expression": "1e308*10"}`, http.StatusOK,
+			`{"expression":"1e+308*10","latex":"1 \\times 10^{308} \\cdot 10"}`},
+		{"missing expression", "/simplify", "", `{}`, http.StatusBadRequest, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := do(t, "POST", srv.URL+"/v1/symbolic"+tt.path, tt.tenant, tt.body)
+			assertBody(t, resp, tt.wantStatus, tt.wantBody)
+		})
+	}
+}
+
+func TestSymbolicDerivativeEvaluates(t *testing.T) {
+	srv := setupExprServer()
+	defer srv.Close()
+	resp := post(t, srv.URL+"/v1/symbolic/derivative", `{"expression": "exp(-x^2)*sqrt(x)"}`)
+	var d SymbolicResponse
+	if err := json.NewDecoder(resp.Body).Decode(&d); err != nil {
+		t.Fatal(err)
+	}
+	body, _ := json.Marshal(map[string]any{"expression": d.Expression, "variables": map[string]float64{"x": 1}})
+	resp = post(t, srv.URL+"/v1/evaluate", string(body))
+	assertBody(t, resp, http.StatusOK, `{"result":-0.5518191617571635}`)
+}
@@ -5,6 +5,9 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+
+	"github.com/igorgatis/sezzle/backend/pkg/internal/calculator"
+	"github.com/igorgatis/sezzle/backend/pkg/internal/money"
 )
 
 type Config struct {
@@ -12,6 +15,12 @@ type Config struct {
 	AllowCORS         bool
 	EnableSwagger     bool
 	ArtificialDelayMs int
+	// Rounding is the default rounding of results.
+	Rounding calculator.Rounding
+	// RatesFile is a JSON or CSV file of exchange rates; none when empty.
+	RatesFile string
+	// RatesBase is the currency rates are triangulated through.
+	RatesBase money.Currency
 }
 
 // NOTE FOR REVIEWER:
@@ -43,6 +52,11 @@ func (p *parser) Parse(args []string) Config {
 		fmt.Fprintln(out, "  ALLOW_CORS           set to 'true' to enable CORS headers (default: false)")
 		fmt.Fprintln(out, "  ENABLE_SWAGGER       set to 'true' to enable Swagger UI (default: false)")
 		fmt.Fprintln(out, "  ARTIFICIAL_DELAY_MS  max random delay in ms (default: 0, disabled)")
+		fmt.Fprintln(out, "  ROUNDING_MODE        half_up, half_even, down, up, ceiling or floor (default: half_even)")
+		fmt.Fprintln(out, "  ROUNDING_DECIMALS    round results to decimal places (default: -1, disabled)")
+		fmt.Fprintln(out, "  ROUNDING_SIGNIFICANT round results to significant digits (default: 0, disabled)")
+		fmt.Fprintln(out, "  RATES_FILE           JSON or CSV file of exchange rates (default: none)")
+		fmt.Fprintln(out, "  RATES_BASE           currency to triangulate exchange rates through (default: USD)")
 	}
 	help := fs.Bool("help", false, "print help and exit")
 	_ = fs.Parse(args[1:])
@@ -55,12 +69,32 @@ func (p *parser) Parse(args []string) Config {
 		fmt.Fprintf(out, "Error: %s\n", msg)
 		p.ExitFn(1)
 	}
-	return Config{
+	usd, _ := money.LookupCurrency("USD")
+	cfg := Config{
 		Port:              getEnv("PORT", 3001, strconv.Atoi, errorFn),
 		AllowCORS:         getEnv("ALLOW_CORS", false, strconv.ParseBool, errorFn),
 		EnableSwagger:     getEnv("ENABLE_SWAGGER", false, strconv.ParseBool, errorFn),
 		ArtificialDelayMs: getEnv("ARTIFICIAL_DELAY_MS", 0, strconv.Atoi, errorFn),
+		Rounding: calculator.Rounding{
+			Mode: getEnv("ROUNDING_MODE", calculator.HalfEven, calculator.ParseRoundingMode, errorFn),
+		},
+		RatesFile: os.Getenv("RATES_FILE"),
+		RatesBase: getEnv("RATES_BASE", usd, money.LookupCurrency, errorFn),
+	}
+	decimals := getEnv("ROUNDING_DECIMALS", -1, strconv.Atoi, errorFn)
+	significant := getEnv("ROUNDING_SIGNIFICANT", 0, strconv.Atoi, errorFn)
+	switch {
+	case decimals >= 0 && significant != 0:
+		errorFn("ROUNDING_DECIMALS and ROUNDING_SIGNIFICANT are exclusive")
+	case significant != 0:
+		cfg.Rounding.Scale, cfg.Rounding.Digits = calculator.Significant, significant
+	case decimals >= 0:
+		cfg.Rounding.Scale, cfg.Rounding.Digits = calculator.Decimals, decimals
+	}
+	if err := cfg.Rounding.Validate(); err != nil {
+		errorFn(err.Error())
 	}
+	return cfg
 }
 
 func getEnv[T any](name string, defaultVal T, parse func(string) (T, error), errorFn func(string)) T {
@@ -2,6 +2,8 @@ package service
 
 import (
 	"testing"
+
+	"github.com/igorgatis/sezzle/backend/pkg/internal/calculator"
 )
 
 func TestParseEnvVarsHelp(t *testing.T) {
@@ -108,3 +110,55 @@ func TestParseEnvVarsInvalidEnableSwagger(t *testing.T) {
 		t.Errorf("expected exit code 1, got %d", exitCode)
 	}
 }
+
+func TestParseEnvVarsRounding(t *testing.T) {
+	tests := []struct {
+		name         string
+		env          map[string]string
+		wantRounding calculator.Rounding
+		wantExit     int
+	}{
+		{"disabled by default", nil, calculator.Rounding{Mode: calculator.HalfEven}, -1},
+		{"decimals", map[string]string{"ROUNDING_MODE": "half_up", "ROUNDING_DECIMALS": "2"},
+			calculator.Rounding{Mode: calculator.HalfUp, Scale: calculator.Decimals, Digits: 2}, -1},
+		{"significant", map[string]string{"ROUNDING_SIGNIFICANT": "6"},
+			calculator.Rounding{Mode: calculator.HalfEven, Scale: calculator.Significant, Digits: 6}, -1},
+		{"invalid mode", map[string]string{"ROUNDING_MODE": "nearest"}, calculator.Rounding{}, 1},
+		{"both", map[string]string{"ROUNDING_DECIMALS": "2", "ROUNDING_SIGNIFICANT": "6"}, calculator.Rounding{}, 1},
+		{"out of range", map[string]string{"ROUNDING_SIGNIFICANT": "-3"}, calculator.Rounding{}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			exitCode := -1
+			p := &parser{ExitFn: func(code int) { exitCode = code }}
+			cfg := p.Parse([]string{"test"})
+
+			if exitCode != tt.wantExit {
+				t.Fatalf("expected exit code %d, got %d", tt.wantExit, exitCode)
+			}
+			if exitCode == -1 && cfg.Rounding != tt.wantRounding {
+				t.Errorf("Parse().Rounding = %+v, want %+v", cfg.Rounding, tt.wantRounding)
+			}
+		})
+	}
+}
+
+func TestParseEnvVarsRates(t *testing.T) {
+	t.Setenv("RATES_FILE", "rates.csv")
+	t.Setenv("RATES_BASE", "eur")
+	exitCode := -1
+	p := &parser{ExitFn: func(code int) { exitCode = code }}
+	cfg := p.Parse([]string{"test"})
+	if exitCode != -1 || cfg.RatesFile != "rates.csv" || cfg.RatesBase.Code != "EUR" {
+		t.Errorf("Parse() = %q %+v, exit code %d", cfg.RatesFile, cfg.RatesBase, exitCode)
+	}
+
+	t.Setenv("RATES_BASE", "XYZ")
+	p.Parse([]string{"test"})
+	if exitCode != 1 {
+		t.Errorf("expected exit code 1 for unknown base, got %d", exitCode)
+	}
+}
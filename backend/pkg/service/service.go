@@ -8,14 +8,19 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
-	swaggerFiles "github.com/swaggo/files"
-	ginSwagger "github.com/swaggo/gin-swagger"
 
-	_ "github.com/igorgatis/sezzle/backend/docs"
+	"github.com/igorgatis/sezzle/backend/docs"
 	"github.com/igorgatis/sezzle/backend/pkg/internal/calculator"
+	"github.com/igorgatis/sezzle/backend/pkg/internal/expr"
+	"github.com/igorgatis/sezzle/backend/pkg/internal/money"
+	"github.com/igorgatis/sezzle/backend/pkg/internal/storage"
 	"github.com/igorgatis/sezzle/backend/pkg/internal/transport/rest"
 )
 
+// maxCompiledExpressions bounds the compiled expressions kept in memory,
+// beyond which the least recently used are forgotten.
+const maxCompiledExpressions = 10_000
+
 type Service interface {
 	Serve()
 }
@@ -55,10 +60,29 @@ func NewRest(cfg Config) *restService {
 		})
 	}
 
-	rest.RegisterCalculatorV1(engine, calculator.New())
+	ops := calculator.DefaultRegistry(calculator.New())
+	rest.RegisterCalculatorV1(engine, ops, cfg.Rounding)
+	registry := expr.NewRegistry(expr.NewEnv(ops), storage.NewMemory[expr.Definition]())
+	rest.RegisterExpressionsV1(engine, registry, storage.NewLRU[*expr.Program](maxCompiledExpressions), cfg.Rounding)
+	rest.RegisterFunctionsV1(engine, registry)
+	rest.RegisterProgrammerV1(engine, calculator.IntOperations())
+	rest.RegisterFormatV1(engine)
+	rest.RegisterMoneyV1(engine, newRates(cfg))
+	rest.RegisterFinanceV1(engine, cfg.Rounding)
+	rest.RegisterStatsV1(engine)
+	rest.RegisterRandomV1(engine)
+	rest.RegisterLinalgV1(engine)
+	rest.RegisterSolveV1(engine, registry)
+	rest.RegisterCalculusV1(engine, registry)
+	rest.RegisterSymbolicV1(engine, registry)
+	rest.RegisterPolynomialV1(engine)
 
 	if cfg.EnableSwagger {
-		engine.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+		swagger, err := rest.SwaggerHandler(docs.SwaggerInfo.ReadDoc(), ops)
+		if err != nil {
+			log.Fatalf("invalid swagger document: %v", err)
+		}
+		engine.GET("/swagger/*any", swagger)
 	}
 
 	return &restService{
@@ -68,6 +92,25 @@ func NewRest(cfg Config) *restService {
 	}
 }
 
+// newRates loads the exchange rates file, if any. Without one, conversions
+// only succeed between equal currencies.
+func newRates(cfg Config) money.RatesProvider {
+	base := cfg.RatesBase
+	if base.Code == "" {
+		base, _ = money.LookupCurrency("USD")
+	}
+	if cfg.RatesFile == "" {
+		rates, _ := money.NewRateTable(base, nil)
+		return rates
+	}
+	rates, err := money.NewFileRates(cfg.RatesFile, base)
+	if err != nil {
+		log.Fatalf("invalid exchange rates: %v", err)
+	}
+	log.Printf("Loaded %d exchange rates from %s", rates.Len(), cfg.RatesFile)
+	return rates
+}
+
 func (s *restService) Handler() http.Handler {
 	return s.engine
 }
@@ -85,3 +85,22 @@ func TestEnableSwagger(t *testing.T) {
 		})
 	}
 }
+
+func TestSwaggerDocIncludesOperations(t *testing.T) {
+	h := NewRest(Config{EnableSwagger: true})
+	srv := httptest.NewServer(h.Handler())
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/swagger/doc.json")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	for _, path := range []string{`"/v1/add"`, `"/v1/sqrt"`, `"/v1/operations"`, `"/v1/evaluate"`} {
+		if !bytes.Contains(body, []byte(path)) {
+			t.Errorf("doc.json lacks %s", path)
+		}
+	}
+}